@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -10,18 +11,20 @@ import (
 
 	"stacksprint/backend/internal/api"
 	"stacksprint/backend/internal/generator"
+	"stacksprint/templates"
 )
 
 func main() {
-	templateRoot := os.Getenv("TEMPLATE_ROOT")
-	if templateRoot == "" {
-		templateRoot = "../templates"
-	}
-
-	registry, err := generator.NewTemplateRegistry(templateRoot)
+	// Templates are embedded; TEMPLATE_OVERLAYS is an optional path list of
+	// directories whose templates shadow the embedded ones.
+	overlays := filepath.SplitList(os.Getenv("TEMPLATE_OVERLAYS"))
+	registry, err := generator.NewTemplateRegistryWithOverlays(templates.FS, overlays...)
 	if err != nil {
 		log.Fatalf("failed to initialize template registry: %v", err)
 	}
+	for _, p := range registry.Overridden() {
+		log.Printf("template overlay: %s", p)
+	}
 
 	eng := generator.NewEngine(registry)
 	handler := api.NewHandler(eng)
@@ -31,7 +34,12 @@ func main() {
 	app.Use(cors.New())
 
 	app.Get("/health", handler.Health)
+	app.Get("/capabilities", handler.Capabilities)
 	app.Post("/generate", handler.Generate)
+	app.Post("/generate/archive", handler.GenerateArchive)
+	app.Post("/validate", handler.Validate)
+	app.Post("/models/from-sql", handler.ImportSQLModels)
+	app.Post("/models/from-openapi", handler.ImportOpenAPIModels)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -0,0 +1,345 @@
+// Command stacksprint generates a project from a GenerateRequest without the
+// HTTP server: it runs the engine in-process and writes the file tree to disk.
+//
+//	stacksprint -config stack.yaml -out ./my-api
+//	stacksprint -language go -framework gin -architecture clean -name my-api --dry-run
+//	stacksprint -upgrade -out ./my-api -infra redis
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"stacksprint/backend/internal/generator"
+	"stacksprint/templates"
+)
+
+// Exit codes: a usage error covers bad flags, an unreadable config and a
+// request that fails validation; strict mode failing is reported apart from
+// write and merge failures so scripts can tell them apart.
+const (
+	exitOK     = 0
+	exitFailed = 1
+	exitUsage  = 2
+	exitStrict = 3
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run is the whole command; it returns the process exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	err := execute(args, os.Stdin, stdout, stderr)
+	var (
+		usageErr  *usageError
+		strictErr *generator.StrictModeError
+	)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.As(err, &usageErr):
+		fmt.Fprintln(stderr, "stacksprint:", err)
+		return exitUsage
+	case errors.As(err, &strictErr):
+		fmt.Fprintln(stderr, "stacksprint:", err)
+		return exitStrict
+	default:
+		fmt.Fprintln(stderr, "stacksprint:", err)
+		return exitFailed
+	}
+}
+
+// usageError marks errors in how the command was invoked rather than in
+// generating or writing the project.
+type usageError struct{ err error }
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+func execute(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("stacksprint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		configPath   = flags.String("config", "", "request file (YAML or JSON, same shape as POST /generate); - reads stdin")
+		outDir       = flags.String("out", "", "target directory (default: root.path for existing projects, otherwise root.name)")
+		dryRun       = flags.Bool("dry-run", false, "list the files that would be written without touching disk")
+		force        = flags.Bool("force", false, "overwrite files that already exist in the target directory (ignores root.on_conflict)")
+		printScript  = flags.Bool("print-script", false, "print the bash setup script instead of writing files")
+		upgrade      = flags.Bool("upgrade", false, "regenerate the project in -out (default .) from its "+generator.ProjectManifestPath+" and three-way merge with local edits")
+		strict       = flags.Bool("strict", false, "fail when generation raises any error-severity warning")
+		overlays     = flags.String("template-overlays", os.Getenv("TEMPLATE_OVERLAYS"), "template overlay directories, path-list separated")
+		language     = flags.String("language", "", "go, node or python")
+		framework    = flags.String("framework", "", "framework for the language, e.g. gin, express, fastapi")
+		architecture = flags.String("architecture", "", "mvp, clean, hexagonal, modular-monolith or microservices")
+		db           = flags.String("db", "", "postgresql, mysql, mongodb, sqlite or none")
+		useORM       = flags.Bool("orm", false, "generate ORM models and connection code")
+		name         = flags.String("name", "", "project name (root.name)")
+		module       = flags.String("module", "", "Go module path (root.module)")
+		services     = flags.String("services", "", "microservices as name:port pairs, comma-separated")
+		infra        = flags.String("infra", "", "infra to enable, comma-separated: redis, kafka, nats, rabbitmq")
+		modelsSQL    = flags.String("models-sql", "", "SQL file whose CREATE TABLE statements become models (custom.models_from_sql)")
+		openAPI      = flags.String("openapi", "", "OpenAPI 3 file (YAML or JSON) whose schemas and operations become models and routes (custom.openapi)")
+	)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: stacksprint [-config file] [flags]")
+		fmt.Fprintln(stderr, "Flags override values from the config file.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &usageError{err}
+	}
+	if flags.NArg() > 0 {
+		return &usageError{fmt.Errorf("unexpected argument %q", flags.Arg(0))}
+	}
+
+	// An upgrade without -config starts from the request recorded in the project.
+	var req generator.GenerateRequest
+	var err error
+	if *upgrade && *configPath == "" {
+		manifest, merr := generator.ReadProjectManifest(upgradeDir(*outDir))
+		if merr != nil {
+			return merr
+		}
+		req = manifest.Request
+	} else if req, err = loadRequest(*configPath, stdin); err != nil {
+		return &usageError{err}
+	}
+	var flagErr error
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "language":
+			req.Language = *language
+		case "framework":
+			req.Framework = *framework
+		case "architecture":
+			req.Architecture = *architecture
+		case "db":
+			req.Database = *db
+		case "orm":
+			req.UseORM = *useORM
+		case "name":
+			req.Root.Name = *name
+		case "module":
+			req.Root.Module = *module
+		case "strict":
+			req.Strict = *strict
+		case "services":
+			req.Services, flagErr = parseServices(*services)
+		case "infra":
+			flagErr = enableInfra(&req.Infra, *infra)
+		case "models-sql":
+			var ddl []byte
+			if ddl, flagErr = os.ReadFile(*modelsSQL); flagErr == nil {
+				req.Custom.ModelsFromSQL = string(ddl)
+			}
+		case "openapi":
+			var doc []byte
+			if doc, flagErr = os.ReadFile(*openAPI); flagErr == nil {
+				req.Custom.OpenAPI = string(doc)
+			}
+		}
+	})
+	if flagErr != nil {
+		return &usageError{flagErr}
+	}
+
+	registry, err := generator.NewTemplateRegistryWithOverlays(templates.FS, filepath.SplitList(*overlays)...)
+	if err != nil {
+		return err
+	}
+	engine := generator.NewEngine(registry)
+	if *upgrade {
+		return runUpgrade(engine, upgradeDir(*outDir), req, *dryRun, stdout, stderr)
+	}
+
+	project, err := engine.GenerateProject(context.Background(), req)
+	if err != nil {
+		return requestError(err, stderr)
+	}
+	printWarnings(stderr, project.Response.Warnings)
+
+	if *printScript {
+		_, err := io.WriteString(stdout, project.Response.BashScript)
+		return err
+	}
+
+	dir := *outDir
+	if dir == "" {
+		dir = generator.ProjectDir(project.Request)
+	}
+	opts := generator.WriteOptions{Force: *force, DryRun: *dryRun}
+	if project.Request.Root.Mode == "existing" {
+		opts.Existing = &project.Request.Root
+	}
+	report, err := generator.WriteTree(dir, project.Tree, opts)
+	if *dryRun {
+		for _, f := range report {
+			fmt.Fprintf(stdout, "%-9s %s\n", f.Action, filepath.Join(dir, filepath.FromSlash(f.Path)))
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if !*dryRun {
+		written := 0
+		for _, f := range report {
+			if f.Action != "skip" && f.Action != "unchanged" {
+				written++
+			}
+		}
+		fmt.Fprintf(stdout, "wrote %d files to %s\n", written, dir)
+		// In existing mode, say what happened to each file that was already there.
+		for _, f := range report {
+			if opts.Existing != nil && f.Action != "create" {
+				fmt.Fprintf(stdout, "  %-9s %s\n", f.Action, f.Path)
+			}
+		}
+	}
+	return nil
+}
+
+func runUpgrade(engine *generator.Engine, dir string, req generator.GenerateRequest, dryRun bool, stdout, stderr io.Writer) error {
+	result, err := engine.Upgrade(context.Background(), dir, req)
+	if err != nil {
+		return requestError(err, stderr)
+	}
+	printWarnings(stderr, result.Warnings)
+
+	counts := map[string]int{}
+	for _, f := range result.Files {
+		counts[f.Action]++
+		if dryRun || f.Action != "unchanged" {
+			fmt.Fprintf(stdout, "%-9s %s\n", f.Action, filepath.Join(dir, filepath.FromSlash(f.Path)))
+		}
+	}
+	if dryRun {
+		return nil
+	}
+	if _, err := generator.WriteTree(dir, result.Tree, generator.WriteOptions{Force: true}); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "upgraded %s: %d created, %d updated, %d merged, %d conflicts\n",
+		dir, counts["create"], counts["update"], counts["merge"], counts["conflict"])
+	if counts["conflict"] > 0 {
+		return errors.New("upgrade finished with conflicts; resolve the markers and re-run tests")
+	}
+	return nil
+}
+
+func upgradeDir(out string) string {
+	if out == "" {
+		return "."
+	}
+	return out
+}
+
+func requestError(err error, stderr io.Writer) error {
+	var validationErrs generator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for _, e := range validationErrs {
+			fmt.Fprintf(stderr, "  %s: %s\n", e.Pointer, e.Message)
+		}
+		return &usageError{errors.New("invalid request")}
+	}
+	return err
+}
+
+func printWarnings(w io.Writer, warnings []generator.Warning) {
+	for _, warning := range warnings {
+		where := ""
+		if warning.Path != "" {
+			where = " " + warning.Path
+		}
+		fmt.Fprintf(w, "%s [%s]%s: %s\n", warning.Severity, warning.Code, where, warning.Message)
+	}
+}
+
+// loadRequest reads a GenerateRequest from a YAML or JSON file. YAML is
+// converted to JSON first so both formats use the API's json field names.
+func loadRequest(path string, stdin io.Reader) (generator.GenerateRequest, error) {
+	var req generator.GenerateRequest
+	if path == "" {
+		return req, nil
+	}
+	var (
+		raw []byte
+		err error
+	)
+	if path == "-" {
+		raw, err = io.ReadAll(stdin)
+	} else {
+		raw, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return req, err
+	}
+
+	var doc any
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return req, fmt.Errorf("parse %s: %w", path, err)
+	}
+	asJSON, err := json.Marshal(doc)
+	if err != nil {
+		return req, fmt.Errorf("parse %s: %w", path, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(asJSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return req, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return req, nil
+}
+
+func parseServices(list string) ([]generator.ServiceConfig, error) {
+	var out []generator.ServiceConfig
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, portText, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("service %q must be name:port", item)
+		}
+		port, err := strconv.Atoi(portText)
+		if err != nil {
+			return nil, fmt.Errorf("service %q has an invalid port", item)
+		}
+		out = append(out, generator.ServiceConfig{Name: name, Port: port})
+	}
+	return out, nil
+}
+
+func enableInfra(opts *generator.InfraOptions, list string) error {
+	for _, item := range strings.Split(list, ",") {
+		switch strings.ToLower(strings.TrimSpace(item)) {
+		case "":
+		case "redis":
+			opts.Redis = true
+		case "kafka":
+			opts.Kafka = true
+		case "nats":
+			opts.NATS = true
+		case "rabbitmq":
+			opts.RabbitMQ = true
+		default:
+			return fmt.Errorf("unknown infra %q", item)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var goMVP = []string{"-language", "go", "-framework", "gin", "-architecture", "mvp", "-db", "none", "-name", "demo"}
+
+func runCLI(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunWritesProject(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "demo")
+	code, stdout, stderr := runCLI(t, append(goMVP, "-out", out)...)
+	if code != exitOK {
+		t.Fatalf("exit %d, stderr:\n%s", code, stderr)
+	}
+	if !strings.Contains(stdout, "wrote ") {
+		t.Errorf("stdout = %q", stdout)
+	}
+	for _, f := range []string{"cmd/server/main.go", "go.mod", ".stacksprint.json"} {
+		if _, err := os.Stat(filepath.Join(out, f)); err != nil {
+			t.Errorf("%s not written: %v", f, err)
+		}
+	}
+
+	// A second run refuses to overwrite unless forced.
+	if code, _, stderr := runCLI(t, append(goMVP, "-out", out)...); code != exitFailed || !strings.Contains(stderr, "already exist") {
+		t.Errorf("rerun without -force: exit %d, stderr:\n%s", code, stderr)
+	}
+	if code, _, stderr := runCLI(t, append(goMVP, "-out", out, "-force")...); code != exitOK {
+		t.Errorf("rerun with -force: exit %d, stderr:\n%s", code, stderr)
+	}
+
+	code, stdout, stderr = runCLI(t, "-upgrade", "-out", out, "-infra", "redis")
+	if code != exitOK || !strings.Contains(stdout, "upgraded "+out) {
+		t.Fatalf("upgrade: exit %d, stdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(out, "internal/cache/redis.go")); err != nil {
+		t.Errorf("upgrade did not add the redis client: %v", err)
+	}
+}
+
+func TestRunDryRunWritesNothing(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "demo")
+	code, stdout, stderr := runCLI(t, append(goMVP, "-out", out, "-dry-run")...)
+	if code != exitOK {
+		t.Fatalf("exit %d, stderr:\n%s", code, stderr)
+	}
+	if !strings.Contains(stdout, "create    "+filepath.Join(out, "cmd", "server", "main.go")) {
+		t.Errorf("dry run plan missing main.go:\n%s", stdout)
+	}
+	if _, err := os.Stat(out); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("dry run created %s", out)
+	}
+}
+
+func TestRunPrintScript(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "demo")
+	code, stdout, stderr := runCLI(t, append(goMVP, "-out", out, "-print-script")...)
+	if code != exitOK || !strings.HasPrefix(stdout, "#!") || !strings.Contains(stdout, "cmd/server/main.go") {
+		t.Fatalf("exit %d, stdout:\n%.200s\nstderr:\n%s", code, stdout, stderr)
+	}
+	if _, err := os.Stat(out); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("-print-script wrote %s", out)
+	}
+}
+
+func TestRunUsageErrors(t *testing.T) {
+	dir := t.TempDir()
+	unknownField := filepath.Join(dir, "stack.yaml")
+	if err := os.WriteFile(unknownField, []byte("language: go\nlanguages: [go]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string][]string{
+		"unknown flag":     {"-colour"},
+		"stray argument":   {"demo"},
+		"missing config":   {"-config", filepath.Join(dir, "missing.yaml")},
+		"unknown field":    {"-config", unknownField},
+		"bad services":     {"-services", "users"},
+		"unknown infra":    {"-infra", "memcached"},
+		"invalid request":  append(append([]string{}, goMVP...), "-language", "rust"),
+		"unknown database": append(append([]string{}, goMVP...), "-db", "oracle"),
+	}
+	for name, args := range cases {
+		t.Run(name, func(t *testing.T) {
+			if code, _, stderr := runCLI(t, append(args, "-out", filepath.Join(dir, "out"))...); code != exitUsage {
+				t.Errorf("exit %d, want %d; stderr:\n%s", code, exitUsage, stderr)
+			}
+		})
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out")); !errors.Is(err, os.ErrNotExist) {
+		t.Error("a usage error must not write files")
+	}
+	if code, _, _ := runCLI(t, "-h"); code != exitOK {
+		t.Errorf("-h exit %d, want %d", code, exitOK)
+	}
+}
+
+func TestRunStrictExitCode(t *testing.T) {
+	// An overlay whose Go MVP manifest drops the routes marker, so route
+	// injection raises an error-severity warning.
+	overlay := t.TempDir()
+	manifest := filepath.Join(overlay, "go", "mvp", "manifest.yaml")
+	if err := os.MkdirAll(filepath.Dir(manifest), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	body := "files:\n  - template: cmd/server/main.tmpl\n    output: cmd/server/main.go\n    markers: [imports]\n"
+	if err := os.WriteFile(manifest, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "demo")
+	args := append(goMVP, "-out", out, "-template-overlays", overlay)
+
+	code, _, stderr := runCLI(t, args...)
+	if code != exitOK || !strings.Contains(stderr, "INJECTION_MARKER_MISSING") {
+		t.Fatalf("without -strict: exit %d, stderr:\n%s", code, stderr)
+	}
+	if code, _, stderr := runCLI(t, append(args, "-strict", "-force")...); code != exitStrict || !strings.Contains(stderr, "strict mode") {
+		t.Errorf("with -strict: exit %d, want %d; stderr:\n%s", code, exitStrict, stderr)
+	}
+}
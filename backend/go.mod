@@ -6,6 +6,7 @@ require (
 	github.com/gofiber/fiber/v2 v2.52.6
 	golang.org/x/tools v0.42.0
 	gopkg.in/yaml.v3 v3.0.1
+	stacksprint/templates v0.0.0
 )
 
 require (
@@ -23,3 +24,5 @@ require (
 	golang.org/x/sync v0.19.0 // indirect
 	golang.org/x/sys v0.41.0 // indirect
 )
+
+replace stacksprint/templates => ../templates
@@ -1,6 +1,12 @@
 package api
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 
 	"stacksprint/backend/internal/generator"
@@ -21,13 +27,122 @@ func (h *Handler) Health(c *fiber.Ctx) error {
 func (h *Handler) Generate(c *fiber.Ctx) error {
 	var req generator.GenerateRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid JSON body", "detail": err.Error()})
+		return invalidBody(c, err)
 	}
 
 	result, err := h.engine.Generate(c.Context(), req)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return generationError(c, err)
 	}
 
 	return c.JSON(result)
 }
+
+func (h *Handler) GenerateArchive(c *fiber.Ctx) error {
+	format, err := generator.ParseArchiveFormat(c.Query("format"))
+	if err != nil {
+		return generationError(c, generator.ValidationErrors{{
+			Pointer: "",
+			Code:    "ARCHIVE_FORMAT_UNSUPPORTED",
+			Message: err.Error(),
+			Allowed: []string{generator.ArchiveZip, generator.ArchiveTarGz},
+		}})
+	}
+
+	var req generator.GenerateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(c, err)
+	}
+
+	project, err := h.engine.GenerateProject(c.Context(), req)
+	if err != nil {
+		return generationError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, generator.ArchiveContentType(format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", generator.ArchiveFilename(project.Request, format)))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := generator.WriteArchive(w, project.Request, project.Tree, format); err != nil {
+			log.Printf("archive stream failed: %v", err)
+		}
+	})
+	return nil
+}
+
+// Capabilities lists the languages, frameworks and options the generator accepts.
+func (h *Handler) Capabilities(c *fiber.Ctx) error {
+	return c.JSON(generator.Capabilities())
+}
+
+// Validate is a dry run: it reports every violation in the request without generating files.
+func (h *Handler) Validate(c *fiber.Ctx) error {
+	var req generator.GenerateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(c, err)
+	}
+	return c.JSON(h.engine.Validate(c.Context(), req))
+}
+
+// ImportSQLModels parses CREATE TABLE statements into models for custom.models.
+func (h *Handler) ImportSQLModels(c *fiber.Ctx) error {
+	var body struct {
+		SQL string `json:"sql"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		return invalidBody(c, err)
+	}
+	if strings.TrimSpace(body.SQL) == "" {
+		return generationError(c, generator.ValidationErrors{{Pointer: "/sql", Code: "SQL_REQUIRED", Message: "sql must contain CREATE TABLE statements"}})
+	}
+	return c.JSON(generator.ImportSQLModels(body.SQL))
+}
+
+// ImportOpenAPIModels turns an OpenAPI 3 document's schemas and operations
+// into models and routes for custom.models.
+func (h *Handler) ImportOpenAPIModels(c *fiber.Ctx) error {
+	var body struct {
+		Document string `json:"document"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		return invalidBody(c, err)
+	}
+	if strings.TrimSpace(body.Document) == "" {
+		return generationError(c, generator.ValidationErrors{{Pointer: "/document", Code: "OPENAPI_REQUIRED", Message: "document must contain an OpenAPI 3 document (YAML or JSON)"}})
+	}
+	imported, err := generator.ImportOpenAPIModels(body.Document)
+	if err != nil {
+		return generationError(c, generator.ValidationErrors{{Pointer: "/document", Code: "OPENAPI_INVALID", Message: err.Error()}})
+	}
+	return c.JSON(imported)
+}
+
+func invalidBody(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error":  "invalid JSON body",
+		"detail": err.Error(),
+		"errors": []generator.ValidationError{{Pointer: "", Code: "INVALID_JSON", Message: err.Error()}},
+	})
+}
+
+// generationError maps engine failures to responses. Every body carries the
+// structured "errors" list used by /validate; "error" keeps the first message.
+// Strict-mode failures are 422 and carry the blocking warnings so the caller
+// can see which files broke.
+func generationError(c *fiber.Ctx, err error) error {
+	var strictErr *generator.StrictModeError
+	if errors.As(err, &strictErr) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error":    err.Error(),
+			"errors":   []generator.ValidationError{{Pointer: "", Code: "STRICT_MODE_WARNINGS", Message: err.Error()}},
+			"warnings": strictErr.Warnings,
+		})
+	}
+	var validationErrs generator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error(), "errors": validationErrs})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error":  err.Error(),
+		"errors": []generator.ValidationError{{Pointer: "", Code: "GENERATION_FAILED", Message: err.Error()}},
+	})
+}
@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	ArchiveZip   = "zip"
+	ArchiveTarGz = "tar.gz"
+)
+
+// ParseArchiveFormat normalizes the requested archive format. An empty value defaults to zip.
+func ParseArchiveFormat(format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "zip":
+		return ArchiveZip, nil
+	case "tar.gz", "tgz":
+		return ArchiveTarGz, nil
+	default:
+		return "", fmt.Errorf("archive format must be one of: zip, tar.gz")
+	}
+}
+
+// ArchiveContentType returns the MIME type for a normalized archive format.
+func ArchiveContentType(format string) string {
+	if format == ArchiveTarGz {
+		return "application/gzip"
+	}
+	return "application/zip"
+}
+
+// ArchiveFilename returns the download name, derived from the project root directory.
+func ArchiveFilename(req GenerateRequest, format string) string {
+	return archiveRootDir(req) + "." + format
+}
+
+// WriteArchive writes the file tree as a zip or tar.gz archive. All entries live
+// under the same root directory the bash script would create, and empty
+// directories carry their .gitkeep placeholder. The placeholders go into a
+// copy of tree.Files, so the caller's tree is left as it was.
+func WriteArchive(w io.Writer, req GenerateRequest, tree FileTree, format string) error {
+	tree.Files = maps.Clone(tree.Files)
+	ensureGitKeepFiles(&tree)
+	root := archiveRootDir(req)
+	modTime := time.Now()
+
+	switch format {
+	case ArchiveZip:
+		return writeZipArchive(w, root, tree, modTime)
+	case ArchiveTarGz:
+		return writeTarGzArchive(w, root, tree, modTime)
+	default:
+		return fmt.Errorf("unsupported archive format %q", format)
+	}
+}
+
+func writeZipArchive(w io.Writer, root string, tree FileTree, modTime time.Time) error {
+	zw := zip.NewWriter(w)
+	for _, d := range archiveDirs(root, tree) {
+		hdr := &zip.FileHeader{Name: d + "/", Modified: modTime}
+		hdr.SetMode(fs.ModeDir | 0o755)
+		if _, err := zw.CreateHeader(hdr); err != nil {
+			return err
+		}
+	}
+	for _, f := range fileNamesSorted(tree.Files) {
+		content := tree.Files[f]
+		hdr := &zip.FileHeader{Name: path.Join(root, f), Method: zip.Deflate, Modified: modTime}
+		hdr.SetMode(archiveFileMode(f, content))
+		fw, err := zw.CreateHeader(hdr)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeTarGzArchive(w io.Writer, root string, tree FileTree, modTime time.Time) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, d := range archiveDirs(root, tree) {
+		hdr := &tar.Header{Typeflag: tar.TypeDir, Name: d + "/", Mode: 0o755, ModTime: modTime}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+	}
+	for _, f := range fileNamesSorted(tree.Files) {
+		content := tree.Files[f]
+		hdr := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     path.Join(root, f),
+			Mode:     int64(archiveFileMode(f, content).Perm()),
+			Size:     int64(len(content)),
+			ModTime:  modTime,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(tw, content); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// archiveRootDir reduces rootDirName to a single safe path segment, since an
+// existing-mode root may be an absolute path on the user's machine.
+func archiveRootDir(req GenerateRequest) string {
+	name := path.Base(path.Clean(filepath.ToSlash(strings.TrimSpace(rootDirName(req)))))
+	if name == "." || name == "/" || name == ".." || name == "" {
+		return "stacksprint-generated"
+	}
+	return name
+}
+
+// archiveDirs lists every directory entry (root first) in sorted order.
+func archiveDirs(root string, tree FileTree) []string {
+	dirs := []string{root}
+	for _, d := range dirsSorted(tree.Dirs) {
+		if d == "." || d == "" {
+			continue
+		}
+		dirs = append(dirs, path.Join(root, d))
+	}
+	return dirs
+}
+
+// archiveFileMode marks shell scripts and shebang files as executable.
+func archiveFileMode(name, content string) fs.FileMode {
+	if strings.HasPrefix(content, "#!") || strings.HasSuffix(name, ".sh") {
+		return 0o755
+	}
+	return 0o644
+}
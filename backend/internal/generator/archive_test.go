@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+)
+
+func TestWriteArchive(t *testing.T) {
+	req := GenerateRequest{Root: RootOptions{Mode: "new", Name: "demo"}}
+	tree := FileTree{
+		Files: map[string]string{
+			"manage.py":    "#!/usr/bin/env python\n",
+			"src/app.js":   "console.log('hi');\n",
+			"scripts/x.sh": "echo hi\n",
+		},
+		Dirs: map[string]struct{}{".": {}, "src": {}, "scripts": {}, "empty": {}},
+	}
+
+	t.Run("zip", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteArchive(&buf, req, tree, ArchiveZip); err != nil {
+			t.Fatalf("WriteArchive() failed: %v", err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("invalid zip: %v", err)
+		}
+		modes := map[string]uint32{}
+		for _, f := range zr.File {
+			modes[f.Name] = uint32(f.Mode().Perm())
+		}
+		expectModes(t, modes)
+	})
+
+	t.Run("tar.gz", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteArchive(&buf, req, tree, ArchiveTarGz); err != nil {
+			t.Fatalf("WriteArchive() failed: %v", err)
+		}
+		gz, err := gzip.NewReader(&buf)
+		if err != nil {
+			t.Fatalf("invalid gzip: %v", err)
+		}
+		tr := tar.NewReader(gz)
+		modes := map[string]uint32{}
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("invalid tar: %v", err)
+			}
+			modes[hdr.Name] = uint32(hdr.Mode)
+		}
+		expectModes(t, modes)
+	})
+
+	if _, ok := tree.Files["empty/.gitkeep"]; ok {
+		t.Error("WriteArchive() added placeholders to the caller's tree")
+	}
+}
+
+func expectModes(t *testing.T, modes map[string]uint32) {
+	t.Helper()
+	expected := map[string]uint32{
+		"demo/manage.py":      0o755,
+		"demo/scripts/x.sh":   0o755,
+		"demo/src/app.js":     0o644,
+		"demo/empty/.gitkeep": 0o644,
+	}
+	for name, mode := range expected {
+		got, ok := modes[name]
+		if !ok {
+			t.Errorf("expected entry %q in archive", name)
+			continue
+		}
+		if got != mode {
+			t.Errorf("entry %q has mode %o, want %o", name, got, mode)
+		}
+	}
+}
+
+func TestParseArchiveFormat(t *testing.T) {
+	for in, want := range map[string]string{"": ArchiveZip, "ZIP": ArchiveZip, "tgz": ArchiveTarGz, "tar.gz": ArchiveTarGz} {
+		got, err := ParseArchiveFormat(in)
+		if err != nil || got != want {
+			t.Errorf("ParseArchiveFormat(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseArchiveFormat("rar"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
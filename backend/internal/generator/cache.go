@@ -0,0 +1,56 @@
+package generator
+
+// cache.go — the read-through Redis cache behind infra.redis.
+//
+// Like messaging.go, this file is language-agnostic. Every language gets a
+// Redis client with a health probe; when the models sit in a SQL database their
+// repositories are wrapped in a decorator that serves reads from Redis and
+// drops the cached entries on every write.
+
+import "fmt"
+
+// cacheTTLSeconds bounds how long a cached read can lag behind a write made
+// by another process.
+const cacheTTLSeconds = 300
+
+// cacheData is the cache templates' data, under .Cache.
+type cacheData struct {
+	AddrEnv    string // variable buildEnv writes the Redis address to
+	Prefix     string // namespaces the service's keys: <prefix>:<model>:...
+	TTLSeconds int
+	Models     bool // the repositories are wrapped in the read-through decorator
+}
+
+func newCacheData(req GenerateRequest, service string) cacheData {
+	prefix := service
+	if prefix == "" {
+		prefix = "app"
+	}
+	return cacheData{
+		AddrEnv:    serviceEnvKey(service, "REDIS_ADDR"),
+		Prefix:     prefix,
+		TTLSeconds: cacheTTLSeconds,
+		Models:     cachesModels(req),
+	}
+}
+
+// cachesModels reports whether the model repositories go through Redis. An
+// in-memory store is already as fast as the cache, so only models kept in a
+// SQL database are wrapped.
+func cachesModels(req GenerateRequest) bool {
+	return req.Infra.Redis && isSQLDB(req.Database) && isEnabled(req.FileToggles.ExampleCRUD)
+}
+
+// cacheReadmeSection documents the cache for the generated README.
+func cacheReadmeSection(req GenerateRequest) string {
+	if !req.Infra.Redis {
+		return ""
+	}
+	s := "\n## Cache\n\nRedis runs in docker-compose and `GET /health/redis` answers 200 while it responds to `PING`, 503 otherwise.\n"
+	if cachesModels(req) {
+		s += fmt.Sprintf("\nModel reads are read-through: `list` and `get` are served from Redis and fall back to the database on a miss, "+
+			"which caches the result for %d minutes. Creating, updating or deleting a record drops its cached entry and every cached page of its model. "+
+			"If Redis stops answering, the repositories keep working against the database.\n", cacheTTLSeconds/60)
+	}
+	return s
+}
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewCacheDataOnlyWrapsSQLModels(t *testing.T) {
+	req := GenerateRequest{Database: "postgresql", Infra: InfraOptions{Redis: true}}
+	data := newCacheData(req, "order-items")
+	if data.AddrEnv != "ORDER_ITEMS_REDIS_ADDR" || data.Prefix != "order-items" || data.TTLSeconds != cacheTTLSeconds || !data.Models {
+		t.Errorf("order-items data = %+v", data)
+	}
+	if monolith := newCacheData(req, ""); monolith.AddrEnv != "REDIS_ADDR" || monolith.Prefix != "app" {
+		t.Errorf("monolith data = %+v", monolith)
+	}
+	if newCacheData(GenerateRequest{Database: "mongodb", Infra: InfraOptions{Redis: true}}, "").Models {
+		t.Error("models outside a SQL database should not be cached")
+	}
+	if newCacheData(GenerateRequest{Database: "postgresql", Infra: InfraOptions{Redis: true}, FileToggles: FileToggleOptions{ExampleCRUD: ptr(false)}}, "").Models {
+		t.Error("without example CRUD there are no repositories to cache")
+	}
+	if cacheReadmeSection(GenerateRequest{}) != "" {
+		t.Error("the README should not mention a cache that is not there")
+	}
+
+	spec := newComposeSpec(GenerateRequest{
+		Architecture: "microservices",
+		Services:     []ServiceConfig{{Name: "users", Port: 8081}, {Name: "orders", Port: 8082}},
+		Infra:        InfraOptions{Redis: true},
+	})
+	if dep, ok := spec.Services["orders"].DependsOn["redis"]; !ok || dep.Condition != "service_healthy" {
+		t.Errorf("orders should start once redis is healthy: %+v", spec.Services["orders"].DependsOn)
+	}
+}
+
+func TestGenerateWiresRedisCache(t *testing.T) {
+	registry, err := NewTemplateRegistry("../../../templates")
+	if err != nil {
+		t.Fatalf("failed to init registry: %v", err)
+	}
+	engine := NewEngine(registry)
+	models := CustomOptions{Models: []DataModel{{Name: "Tag", Fields: []DataField{{Name: "label", Type: "string"}}}}}
+	cases := []struct {
+		name    string
+		req     GenerateRequest
+		files   map[string][]string
+		missing []string
+	}{
+		{
+			name: "go clean",
+			req:  GenerateRequest{Language: "go", Framework: "gin", Architecture: "clean", Database: "postgresql", Custom: models},
+			files: map[string][]string{
+				"cmd/server/main.go":           {"redisCache, err := cache.Connect()", `cache.Wrap[domain.Tag](repository.NewTagRepository(conn), redisCache, "tag")`, `r.GET("/health/redis", redisCache.Health())`},
+				"internal/cache/redis.go":      {`os.Getenv("REDIS_ADDR")`, "func readThrough[T any]("},
+				"internal/cache/repository.go": {"func Wrap[T any]("},
+				"go.mod":                       {"github.com/redis/go-redis/v9"},
+				"README.md":                    {"## Cache", "read-through"},
+			},
+		},
+		{
+			name: "go mvp",
+			req:  GenerateRequest{Language: "go", Framework: "fiber", Architecture: "mvp", Database: "mysql", Custom: models},
+			files: map[string][]string{
+				"cmd/server/main.go":             {"handlers.Cache = redisCache", `app.Get("/health/redis", redisCache.Health())`},
+				"internal/handlers/tag_cache.go": {"func tagCache()", "func tagList("},
+				"internal/handlers/db.go":        {"var Cache *cache.Cache"},
+			},
+		},
+		{
+			name:    "go without a database",
+			req:     GenerateRequest{Language: "go", Framework: "gin", Architecture: "mvp", Database: "none"},
+			files:   map[string][]string{"cmd/server/main.go": {`r.GET("/health/redis", redisCache.Health())`}},
+			missing: []string{"internal/cache/repository.go"},
+		},
+		{
+			name: "node hexagonal",
+			req:  GenerateRequest{Language: "node", Framework: "express", Architecture: "hexagonal", Database: "postgresql", Custom: models},
+			files: map[string][]string{
+				"src/adapters/primary/http/tagController.js": {"new CachedRepository(new TagRepositoryAdapter(), 'tag')"},
+				"src/cache/redis.js":                         {"process.env.REDIS_ADDR", "export async function redisHealth()"},
+				"package.json":                               {`"ioredis"`},
+			},
+		},
+		{
+			name: "fastapi clean",
+			req:  GenerateRequest{Language: "python", Framework: "fastapi", Architecture: "clean", Database: "postgresql", Custom: models},
+			files: map[string][]string{
+				"app/main.py":                    {"@app.get('/health/redis')", "redis_client.close()"},
+				"app/usecases/list_tags.py":      {"_repo = CachedRepository(TagRepository(), 'tag')"},
+				"app/cache/cached_repository.py": {"class CachedRepository"},
+				"requirements.txt":               {"redis=="},
+			},
+		},
+		{
+			name: "django",
+			req:  GenerateRequest{Language: "python", Framework: "django", Architecture: "mvp", Database: "postgresql", Custom: models},
+			files: map[string][]string{
+				"config/urls.py":     {"path('health/redis', redis_health)"},
+				"api/model_views.py": {"data = read_through(", "invalidate("},
+				"api/cache/views.py": {"def redis_health(request):"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req.Infra.Redis = true
+			out, err := engine.GenerateProject(context.Background(), tc.req)
+			if err != nil {
+				t.Fatalf("GenerateProject() error = %v", err)
+			}
+			for _, w := range out.Response.Warnings {
+				if w.Code == "INJECTION_MARKER_MISSING" || w.Code == "TEMPLATE_RENDER_FAILED" {
+					t.Errorf("unexpected warning %+v", w)
+				}
+			}
+			for file, want := range tc.files {
+				assertContainsAll(t, file, out.Tree.Files[file], want...)
+			}
+			for _, file := range tc.missing {
+				if _, ok := out.Tree.Files[file]; ok {
+					t.Errorf("%s should not be generated", file)
+				}
+			}
+		})
+	}
+}
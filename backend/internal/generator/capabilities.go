@@ -0,0 +1,106 @@
+package generator
+
+import "slices"
+
+// serviceCommunicationModes are the accepted values for service_communication.
+var serviceCommunicationModes = []string{"none", "http", "grpc"}
+
+// CapabilityMatrix describes everything the generator accepts. It is derived
+// from the generator registry and validator tables, so the frontend never
+// needs its own copy.
+type CapabilityMatrix struct {
+	Languages            []LanguageCapabilities `json:"languages"`
+	Architectures        []string               `json:"architectures"`
+	Databases            []string               `json:"databases"`
+	ServiceCommunication []string               `json:"service_communication"`
+	RootModes            []string               `json:"root_modes"`
+	ConflictPolicies     []string               `json:"conflict_policies"`
+	ServiceLimits        ServiceLimits          `json:"service_limits"`
+	FieldTypes           []FieldTypeMapping     `json:"field_types"`
+	RelationKinds        []string               `json:"relation_kinds"`
+}
+
+// LanguageCapabilities lists the frameworks, architectures and option keys a
+// language supports, as declared in its LanguageSpec. FrameworkGaps names the
+// option keys a framework of the language ignores.
+type LanguageCapabilities struct {
+	ID            string              `json:"id"`
+	Frameworks    []string            `json:"frameworks"`
+	Architectures []string            `json:"architectures"`
+	Infra         []string            `json:"infra"`
+	Features      []string            `json:"features"`
+	FileToggles   []string            `json:"file_toggles"`
+	FrameworkGaps map[string][]string `json:"framework_gaps,omitempty"`
+}
+
+// Supports reports whether framework acts on the option key, which may be an
+// infra, feature or file toggle key.
+func (c LanguageCapabilities) Supports(framework, key string) bool {
+	if slices.Contains(c.FrameworkGaps[framework], key) {
+		return false
+	}
+	return slices.Contains(c.Infra, key) || slices.Contains(c.Features, key) || slices.Contains(c.FileToggles, key)
+}
+
+// ServiceLimits bounds the service count in microservices mode.
+type ServiceLimits struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// FieldTypeMapping shows how an accepted model field type renders per target.
+type FieldTypeMapping struct {
+	Type       string `json:"type"`
+	Go         string `json:"go"`
+	Prisma     string `json:"prisma"`
+	SQLAlchemy string `json:"sqlalchemy"`
+	SQL        string `json:"sql"`
+}
+
+// Capabilities returns the full capability matrix.
+func Capabilities() CapabilityMatrix {
+	languages := make([]LanguageCapabilities, 0, len(languageRegistry))
+	for _, lang := range RegisteredLanguages() {
+		spec := languageRegistry[lang]
+		languages = append(languages, LanguageCapabilities{
+			ID:            lang,
+			Frameworks:    spec.Frameworks,
+			Architectures: spec.Architectures,
+			Infra:         nonNil(spec.Infra),
+			Features:      nonNil(spec.Features),
+			FileToggles:   nonNil(spec.FileToggles),
+			FrameworkGaps: spec.FrameworkGaps,
+		})
+	}
+
+	fieldTypes := make([]FieldTypeMapping, 0, len(acceptedFieldTypes))
+	for _, t := range acceptedFieldTypes {
+		fieldTypes = append(fieldTypes, FieldTypeMapping{
+			Type:       t,
+			Go:         goType(t),
+			Prisma:     prismaType(t),
+			SQLAlchemy: sqlalchemyType(t),
+			SQL:        sqlTypeFromField(t),
+		})
+	}
+
+	return CapabilityMatrix{
+		Languages:            languages,
+		Architectures:        sortedSet(registeredArchitectures()),
+		Databases:            sortedSet(allowedDBs),
+		ServiceCommunication: serviceCommunicationModes,
+		RootModes:            []string{"new", "existing"},
+		ConflictPolicies:     conflictPolicies,
+		ServiceLimits:        ServiceLimits{Min: minMicroservices, Max: maxMicroservices},
+		FieldTypes:           fieldTypes,
+		RelationKinds:        relationKinds,
+	}
+}
+
+// nonNil keeps an empty option list a JSON array rather than null.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
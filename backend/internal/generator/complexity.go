@@ -79,6 +79,8 @@ func dbWeight(db string) int {
 		return 10
 	case "mongodb":
 		return 8
+	case "sqlite":
+		return 4
 	default:
 		return 0
 	}
@@ -95,6 +97,9 @@ func infraWeightScore(req GenerateRequest) int {
 	if req.Infra.NATS {
 		w += 10
 	}
+	if req.Infra.RabbitMQ {
+		w += 10
+	}
 	if strings.EqualFold(req.ServiceCommunication, "grpc") {
 		w += 10
 	}
@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteOptions controls how WriteTree treats an existing target directory.
+type WriteOptions struct {
+	Force  bool // overwrite files that already exist
+	DryRun bool // report what would be written without touching disk
+	// Existing resolves files that already exist through the root's conflict
+	// policies (see conflictPolicy) instead of refusing or overwriting them.
+	Existing *RootOptions
+}
+
+// WrittenFile is one entry of a WriteTree report.
+type WrittenFile struct {
+	Path   string `json:"path"`
+	Action string `json:"action"` // "create" | "overwrite" | "backup" | "merge" | "skip" | "unchanged"
+}
+
+// ExistingFilesError lists the files WriteTree refused to overwrite.
+type ExistingFilesError struct {
+	Paths []string
+}
+
+func (e *ExistingFilesError) Error() string {
+	if len(e.Paths) == 1 {
+		return fmt.Sprintf("%s already exists (use force to overwrite)", e.Paths[0])
+	}
+	return fmt.Sprintf("%d files already exist, first %s (use force to overwrite)", len(e.Paths), e.Paths[0])
+}
+
+// WriteTree writes the file tree under dir, using the same layout and file
+// modes as WriteArchive. Without Force or Existing nothing is written if any
+// file already exists; the report is returned either way so callers can show
+// the plan. Backups are written next to the original as <path>.orig.
+func WriteTree(dir string, tree FileTree, opts WriteOptions) ([]WrittenFile, error) {
+	tree.Files = maps.Clone(tree.Files)
+	ensureGitKeepFiles(&tree)
+
+	report := make([]WrittenFile, 0, len(tree.Files))
+	contents := make(map[string]string, len(tree.Files))
+	var existing []string
+	for _, f := range fileNamesSorted(tree.Files) {
+		if err := validateRelPath(f); err != nil {
+			return nil, fmt.Errorf("refusing to write %q: %w", f, err)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(f))
+		info, err := os.Lstat(target)
+		switch {
+		case err == nil && info.IsDir():
+			return nil, fmt.Errorf("%s exists and is a directory", target)
+		case err == nil && opts.Existing != nil && !opts.Force:
+			current, err := os.ReadFile(target)
+			if err != nil {
+				return nil, err
+			}
+			content, action := resolveExisting(conflictPolicy(*opts.Existing, f), f, string(current), tree.Files[f])
+			contents[f] = content
+			report = append(report, WrittenFile{Path: f, Action: action})
+		case err == nil:
+			existing = append(existing, f)
+			contents[f] = tree.Files[f]
+			report = append(report, WrittenFile{Path: f, Action: "overwrite"})
+		case errors.Is(err, fs.ErrNotExist):
+			contents[f] = tree.Files[f]
+			report = append(report, WrittenFile{Path: f, Action: "create"})
+		default:
+			return nil, err
+		}
+	}
+	if len(existing) > 0 && !opts.Force {
+		return report, &ExistingFilesError{Paths: existing}
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	for _, d := range dirsSorted(tree.Dirs) {
+		if d == "." || d == "" {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Join(dir, filepath.FromSlash(d)), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range report {
+		if f.Action == "skip" || f.Action == "unchanged" {
+			continue
+		}
+		target := filepath.Join(dir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return nil, err
+		}
+		if f.Action == "backup" {
+			if err := backupFile(target); err != nil {
+				return nil, err
+			}
+		}
+		content := contents[f.Path]
+		if err := os.WriteFile(target, []byte(content), archiveFileMode(f.Path, content)); err != nil {
+			return nil, err
+		}
+		// WriteFile keeps the mode of an existing file; make replacements match
+		// too. Merged files keep the user's mode.
+		if f.Action == "overwrite" || f.Action == "backup" {
+			if err := os.Chmod(target, archiveFileMode(f.Path, content)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return report, nil
+}
+
+func backupFile(target string) error {
+	info, err := os.Stat(target)
+	if err != nil {
+		return err
+	}
+	raw, err := os.ReadFile(target)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(target+".orig", raw, info.Mode().Perm())
+}
+
+// ProjectDir is the directory a project is written to by default: the
+// existing root path, or the new project name.
+func ProjectDir(req GenerateRequest) string {
+	return strings.TrimSpace(rootDirName(req))
+}
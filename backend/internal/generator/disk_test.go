@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteTree(t *testing.T) {
+	dir := t.TempDir()
+	tree := FileTree{
+		Files: map[string]string{
+			"run.sh":     "#!/bin/sh\necho hi\n",
+			"src/app.js": "console.log('hi');\n",
+		},
+		Dirs: map[string]struct{}{".": {}, "src": {}, "empty": {}},
+	}
+
+	report, err := WriteTree(dir, tree, WriteOptions{DryRun: true})
+	if err != nil || len(report) != 3 {
+		t.Fatalf("dry run = %+v, %v", report, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "run.sh")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("dry run must not write files")
+	}
+	if _, ok := tree.Files["empty/.gitkeep"]; ok {
+		t.Fatal("WriteTree() added placeholders to the caller's tree")
+	}
+
+	if _, err := WriteTree(dir, tree, WriteOptions{}); err != nil {
+		t.Fatalf("WriteTree() failed: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "run.sh"))
+	if err != nil || info.Mode().Perm() != 0o755 {
+		t.Fatalf("run.sh mode = %v, %v", info, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "empty", ".gitkeep")); err != nil {
+		t.Fatalf("expected .gitkeep in empty dir: %v", err)
+	}
+
+	var existsErr *ExistingFilesError
+	if _, err := WriteTree(dir, tree, WriteOptions{}); !errors.As(err, &existsErr) || len(existsErr.Paths) != 3 {
+		t.Fatalf("expected ExistingFilesError, got %v", err)
+	}
+	tree.Files["src/app.js"] = "changed\n"
+	report, err = WriteTree(dir, tree, WriteOptions{Force: true})
+	if err != nil || report[len(report)-1].Action != "overwrite" {
+		t.Fatalf("force = %+v, %v", report, err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "src", "app.js")); string(got) != "changed\n" {
+		t.Errorf("expected overwritten content, got %q", got)
+	}
+}
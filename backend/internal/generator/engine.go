@@ -2,6 +2,7 @@ package generator
 
 import (
 	"context"
+	"fmt"
 	"path"
 	"path/filepath"
 	"strings"
@@ -15,32 +16,112 @@ func NewEngine(registry *TemplateRegistry) *Engine {
 	return &Engine{registry: registry}
 }
 
-func (e *Engine) Generate(_ context.Context, req GenerateRequest) (GenerateResponse, error) {
+// GeneratedProject is the in-memory result of a generation run: the normalized
+// request that drove it, the final file tree, and the response metadata.
+type GeneratedProject struct {
+	Request  GenerateRequest
+	Tree     FileTree
+	Response GenerateResponse
+}
+
+func (e *Engine) Generate(ctx context.Context, req GenerateRequest) (GenerateResponse, error) {
+	project, err := e.GenerateProject(ctx, req)
+	if err != nil {
+		return GenerateResponse{}, err
+	}
+	return project.Response, nil
+}
+
+// GenerateProject runs the full pipeline and keeps the file tree, so callers
+// that write files directly (archives, CLI) share the exact output of Generate.
+func (e *Engine) GenerateProject(_ context.Context, req GenerateRequest) (GeneratedProject, error) {
+	original := req
 	req = NormalizeConfig(req)
 	req, decisions, ruleWarnings := ApplyRuleEngine(req)
 	if err := ValidateConfig(req); err != nil {
-		return GenerateResponse{}, err
+		return GeneratedProject{}, err
 	}
 
 	// Complexity analysis is advisory-only — runs after validation, before generation.
 	// It does NOT modify req and does NOT block generation.
 	complexityReport := AnalyzeComplexity(req)
 
-	tree, err := GenerateFileTree(req, e)
+	tree, genWarnings, genDecisions, err := GenerateFileTree(req, e)
 	if err != nil {
-		return GenerateResponse{}, err
+		return GeneratedProject{}, err
 	}
 
 	mutWarnings := ApplyMutations(&tree, req.Custom)
+	// The manifest keeps the request as submitted, so an upgrade replays
+	// exactly the same pipeline to rebuild its merge base.
+	if err := addProjectManifest(&tree, original); err != nil {
+		return GeneratedProject{}, err
+	}
 	resp, err := BuildScripts(req, tree)
 	if err != nil {
-		return GenerateResponse{}, err
+		return GeneratedProject{}, err
 	}
 
-	allWarnings := append(ruleWarnings, mutWarnings...)
-	result := BuildMetadata(&resp, allWarnings, decisions)
+	allWarnings := append(append(ruleWarnings, genWarnings...), mutWarnings...)
+	allDecisions := append(decisions, genDecisions...)
+	result := BuildMetadata(&resp, allWarnings, allDecisions)
 	result.ComplexityReport = complexityReport
-	return result, nil
+	if req.Strict {
+		if err := strictWarningsError(result.Warnings); err != nil {
+			return GeneratedProject{}, err
+		}
+	}
+	return GeneratedProject{Request: req, Tree: tree, Response: result}, nil
+}
+
+// Validate normalizes req exactly like Generate and reports every violation,
+// plus the rule-engine warnings and decisions, without generating any files.
+func (e *Engine) Validate(_ context.Context, req GenerateRequest) ValidationReport {
+	req = NormalizeConfig(req)
+	req, decisions, warnings := ApplyRuleEngine(req)
+	errs := ValidateAll(req)
+
+	var meta GenerateResponse
+	meta = BuildMetadata(&meta, warnings, decisions)
+	if errs == nil {
+		errs = ValidationErrors{}
+	}
+	return ValidationReport{
+		Valid:     len(errs) == 0,
+		Errors:    errs,
+		Warnings:  meta.Warnings,
+		Decisions: meta.Decisions,
+	}
+}
+
+// StrictModeError is returned when a strict request raises error-severity warnings.
+type StrictModeError struct {
+	Warnings []Warning
+}
+
+func (e *StrictModeError) Error() string {
+	w := e.Warnings[0]
+	msg := fmt.Sprintf("strict mode: %s: %s", w.Code, w.Message)
+	if w.Path != "" {
+		msg += " (" + w.Path + ")"
+	}
+	if len(e.Warnings) > 1 {
+		msg += fmt.Sprintf(" and %d more", len(e.Warnings)-1)
+	}
+	return msg
+}
+
+func strictWarningsError(warnings []Warning) error {
+	var blocking []Warning
+	for _, w := range warnings {
+		if w.Severity == "error" {
+			blocking = append(blocking, w)
+		}
+	}
+	if len(blocking) == 0 {
+		return nil
+	}
+	return &StrictModeError{Warnings: blocking}
 }
 
 func NormalizeConfig(req GenerateRequest) GenerateRequest {
@@ -58,6 +139,12 @@ func NormalizeConfig(req GenerateRequest) GenerateRequest {
 	if req.Root.Mode == "new" && req.Root.Name == "" {
 		req.Root.Name = "stacksprint-generated"
 	}
+	if req.Root.Mode == "existing" {
+		req.Root.OnConflict = strings.ToLower(strings.TrimSpace(req.Root.OnConflict))
+		if req.Root.OnConflict == "" {
+			req.Root.OnConflict = ConflictMerge
+		}
+	}
 	return req
 }
 
@@ -85,6 +172,16 @@ func ApplyRuleEngine(req GenerateRequest) (GenerateRequest, []Decision, []Warnin
 			TriggeredBy: "ApplyRuleEngine",
 		})
 	}
+	req, importDecisions, importWarnings := importModels(req)
+	decisions = append(decisions, importDecisions...)
+	warnings = append(warnings, importWarnings...)
+	req, rbacDecisions := applyRBACDefaults(req)
+	decisions = append(decisions, rbacDecisions...)
+	req, k8sDecisions := applyKubernetesDefaults(req)
+	decisions = append(decisions, k8sDecisions...)
+	req, messagingDecisions := applyMessagingDefaults(req)
+	decisions = append(decisions, messagingDecisions...)
+
 	if req.Database == "none" && req.UseORM {
 		req.UseORM = false
 		decisions = append(decisions, Decision{
@@ -110,7 +207,9 @@ func ValidateConfig(req GenerateRequest) error {
 	return Validate(req)
 }
 
-func GenerateFileTree(req GenerateRequest, e *Engine) (FileTree, error) {
+// GenerateFileTree runs the language generator and returns the tree together with
+// every warning and decision the generator raised on its GenerationContext.
+func GenerateFileTree(req GenerateRequest, e *Engine) (FileTree, []Warning, []Decision, error) {
 	tree := FileTree{Files: map[string]string{}, Dirs: map[string]struct{}{}}
 	tree.Dirs["."] = struct{}{}
 
@@ -121,22 +220,25 @@ func GenerateFileTree(req GenerateRequest, e *Engine) (FileTree, error) {
 		Registry:  e.registry,
 	}
 
-	gen := GetGenerator(req.Language)
+	gen, err := GetGenerator(req.Language)
+	if err != nil {
+		return tree, ctx.Warnings, ctx.Decisions, err
+	}
 
 	if err := gen.GenerateArchitecture(&req, ctx); err != nil {
-		return tree, err
+		return tree, ctx.Warnings, ctx.Decisions, err
 	}
 	if err := gen.GenerateModels(&req, ctx); err != nil {
-		return tree, err
+		return tree, ctx.Warnings, ctx.Decisions, err
 	}
 	if err := gen.GenerateInfra(&req, ctx); err != nil {
-		return tree, err
+		return tree, ctx.Warnings, ctx.Decisions, err
 	}
 	if err := gen.GenerateDevTools(&req, ctx); err != nil {
-		return tree, err
+		return tree, ctx.Warnings, ctx.Decisions, err
 	}
 
-	return tree, nil
+	return tree, ctx.Warnings, ctx.Decisions, nil
 }
 
 func ApplyMutations(tree *FileTree, custom CustomOptions) []Warning {
@@ -144,20 +246,22 @@ func ApplyMutations(tree *FileTree, custom CustomOptions) []Warning {
 }
 
 func BuildMetadata(resp *GenerateResponse, warnings []Warning, decisions []Decision) GenerateResponse {
+	// Deduplicate per code, file and message, so the same problem in two files,
+	// or on two lines of an imported schema, is reported twice.
 	uniqueWarnings := make(map[string]Warning)
 	for _, w := range warnings {
-		uniqueWarnings[w.Code] = w
+		uniqueWarnings[w.Code+"|"+w.Path+"|"+w.Message] = w
 	}
 	for _, w := range resp.Warnings {
-		uniqueWarnings[w.Code] = w
+		uniqueWarnings[w.Code+"|"+w.Path+"|"+w.Message] = w
 	}
 
 	uniqueDecisions := make(map[string]Decision)
 	for _, d := range decisions {
-		uniqueDecisions[d.Code] = d
+		uniqueDecisions[d.Code+"|"+d.Path] = d
 	}
 	for _, d := range resp.Decisions {
-		uniqueDecisions[d.Code] = d
+		uniqueDecisions[d.Code+"|"+d.Path] = d
 	}
 
 	resp.Warnings = make([]Warning, 0, len(uniqueWarnings))
@@ -182,7 +286,7 @@ func sortWarnings(w []Warning) {
 		for j := i + 1; j < len(w); j++ {
 			p1 := priority[w[i].Severity]
 			p2 := priority[w[j].Severity]
-			if p1 < p2 || (p1 == p2 && w[i].Code > w[j].Code) {
+			if p1 < p2 || (p1 == p2 && (w[i].Code > w[j].Code || (w[i].Code == w[j].Code && (w[i].Path > w[j].Path || (w[i].Path == w[j].Path && w[i].Message > w[j].Message))))) {
 				w[i], w[j] = w[j], w[i]
 			}
 		}
@@ -192,7 +296,7 @@ func sortWarnings(w []Warning) {
 func sortDecisions(d []Decision) {
 	for i := 0; i < len(d); i++ {
 		for j := i + 1; j < len(d); j++ {
-			if d[i].Code > d[j].Code {
+			if d[i].Code > d[j].Code || (d[i].Code == d[j].Code && d[i].Path > d[j].Path) {
 				d[i], d[j] = d[j], d[i]
 			}
 		}
@@ -2,6 +2,9 @@ package generator
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -114,3 +117,253 @@ func TestEngine_Generate(t *testing.T) {
 		})
 	}
 }
+
+func TestEngine_GenerateSurfacesContextWarnings(t *testing.T) {
+	// A template root whose model template fails at execution time, so the
+	// render failure is reported against its output path.
+	root := t.TempDir()
+	files := map[string]string{
+		"go/mvp/manifest.yaml":        "files:\n  - template: cmd/server/main.tmpl\n    output: cmd/server/main.go\n    markers: [imports, routes]\nmodels:\n  - template: handler.tmpl\n    output: internal/handlers/{lower}_handler.go\n",
+		"go/mvp/cmd/server/main.tmpl": "package main\n\nimport (\n\t// stacksprint:imports\n)\n\nfunc main() {\n\t// stacksprint:routes\n}\n",
+		"go/mvp/handler.tmpl":         "package handlers\n\n{{ .Model.Missing }}\n",
+	}
+	for name, body := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	registry, err := NewTemplateRegistry(root)
+	if err != nil {
+		t.Fatalf("failed to init registry: %v", err)
+	}
+	engine := NewEngine(registry)
+
+	req := GenerateRequest{
+		Language:     "go",
+		Framework:    "gin",
+		Architecture: "mvp",
+		Database:     "none",
+		FileToggles:  FileToggleOptions{ExampleCRUD: ptr(true)},
+	}
+	resp, err := engine.Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	found := false
+	for _, w := range resp.Warnings {
+		if w.Code == "TEMPLATE_RENDER_FAILED" && w.Path == "internal/handlers/item_handler.go" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected TEMPLATE_RENDER_FAILED for internal/handlers/item_handler.go, got %+v", resp.Warnings)
+	}
+
+	req.Strict = true
+	_, err = engine.Generate(context.Background(), req)
+	var strictErr *StrictModeError
+	if !errors.As(err, &strictErr) {
+		t.Fatalf("expected StrictModeError, got %v", err)
+	}
+}
+
+func TestEngine_GenerateReportsMissingRoutesMarker(t *testing.T) {
+	// Template roots whose entrypoint lacks the routes marker, so route
+	// injection has nowhere to go.
+	cases := []struct {
+		language, framework, entry string
+	}{
+		{"go", "gin", "cmd/server/main.go"},
+		{"node", "express", "src/index.js"},
+		{"python", "fastapi", "app/main.py"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.language, func(t *testing.T) {
+			root := t.TempDir()
+			manifest := "files:\n  - template: main.tmpl\n    output: " + tc.entry + "\n    markers: [imports]\n"
+			for name, body := range map[string]string{"manifest.yaml": manifest, "main.tmpl": "// stacksprint:imports\n"} {
+				p := filepath.Join(root, tc.language, "mvp", name)
+				if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			registry, err := NewTemplateRegistry(root)
+			if err != nil {
+				t.Fatalf("failed to init registry: %v", err)
+			}
+			req := GenerateRequest{
+				Language:     tc.language,
+				Framework:    tc.framework,
+				Architecture: "mvp",
+				Database:     "none",
+				FileToggles:  FileToggleOptions{ExampleCRUD: ptr(true)},
+			}
+			resp, err := NewEngine(registry).Generate(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+			found := false
+			for _, w := range resp.Warnings {
+				if w.Code == "INJECTION_MARKER_MISSING" && w.Severity == "error" {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("expected INJECTION_MARKER_MISSING, got %+v", resp.Warnings)
+			}
+		})
+	}
+}
+
+func TestGenerateServesJWTAuth(t *testing.T) {
+	registry, err := NewTemplateRegistry("../../../templates")
+	if err != nil {
+		t.Fatalf("failed to init registry: %v", err)
+	}
+	engine := NewEngine(registry)
+
+	cases := []struct {
+		language, framework, architecture, database string
+		files                                       map[string][]string
+	}{
+		{"go", "gin", "mvp", "postgresql", map[string][]string{
+			"cmd/server/main.go":     {"authStore, err := auth.NewStore(conn)", `r.POST("/auth/login", authHandler.Login)`, `r.GET("/tags/:id", requireAuth, handlers.GetTag)`},
+			"internal/auth/jwt.go":   {"jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()})"},
+			"internal/auth/users.go": {"CREATE TABLE IF NOT EXISTS auth_users (id SERIAL PRIMARY KEY"},
+			"go.mod":                 {"github.com/golang-jwt/jwt/v5", "golang.org/x/crypto"},
+		}},
+		{"go", "fiber", "clean", "none", map[string][]string{
+			"cmd/server/main.go":          {"authHandler := auth.NewHandler(auth.NewStore())", `app.Get("/tags", requireAuth, tagHandler.ListTags)`},
+			"internal/auth/middleware.go": {`c.Locals("userID", id.UserID)`},
+		}},
+		{"node", "express", "mvp", "mysql", map[string][]string{
+			"src/index.js":       {"import authRoutes from './auth/routes.js';", "app.use(authRoutes);"},
+			"src/routes/tags.js": {"router.get('/tags/:id', requireAuth, getTag);"},
+			"src/auth/users.js":  {"INSERT INTO auth_users (email, password_hash, role) VALUES (?, ?, ?)"},
+			"package.json":       {`"jsonwebtoken"`, `"bcryptjs"`},
+		}},
+		{"node", "fastify", "hexagonal", "postgresql", map[string][]string{
+			"src/index.js":           {"app.register(authRoutes);", "app.get('/tags', { preHandler: requireAuth }, listTags);"},
+			"src/auth/middleware.js": {"export async function requireAuth(request, reply)"},
+		}},
+		{"python", "fastapi", "clean", "postgresql", map[string][]string{
+			"app/main.py":        {"app.include_router(auth_router)\n", "app.include_router(tag_router, dependencies=[Depends(require_user)])\n"},
+			"app/auth/routes.py": {"bcrypt.hashpw("},
+			"requirements.txt":   {"PyJWT==", "bcrypt=="},
+		}},
+		{"python", "django", "mvp", "postgresql", map[string][]string{
+			"config/urls.py":     {"include('api.auth_urls')"},
+			"api/auth.py":        {"class JWTAuthentication(BaseAuthentication):"},
+			"api/model_views.py": {"@authentication_classes([JWTAuthentication])\n@permission_classes([IsAuthenticated])\ndef tags_view("},
+			"requirements.txt":   {"PyJWT=="},
+		}},
+	}
+	for _, tc := range cases {
+		name := tc.language + " " + tc.architecture
+		project, err := engine.GenerateProject(context.Background(), GenerateRequest{
+			Language:     tc.language,
+			Framework:    tc.framework,
+			Architecture: tc.architecture,
+			Database:     tc.database,
+			Features:     FeatureOptions{JWTAuth: true},
+			FileToggles:  FileToggleOptions{ExampleCRUD: ptr(true)},
+			Root:         RootOptions{Mode: "new", Name: "blog", Module: "example.com/blog"},
+			Custom:       CustomOptions{Models: []DataModel{{Name: "Tag", Fields: []DataField{{Name: "label", Type: "string"}}}}},
+		})
+		if err != nil {
+			t.Fatalf("%s: GenerateProject() error = %v", name, err)
+		}
+		for path, want := range tc.files {
+			assertContainsAll(t, name+" "+path, project.Tree.Files[path], want...)
+		}
+	}
+}
+
+func TestGenerateServesRBAC(t *testing.T) {
+	registry, err := NewTemplateRegistry("../../../templates")
+	if err != nil {
+		t.Fatalf("failed to init registry: %v", err)
+	}
+	engine := NewEngine(registry)
+
+	cases := []struct {
+		language, framework, architecture string
+		files                             map[string][]string
+	}{
+		{"go", "gin", "mvp", map[string][]string{
+			"cmd/server/main.go":     {`r.DELETE("/tags/:id", requireAuth, auth.Require("tags:delete"), handlers.DeleteTag)`},
+			"internal/auth/rbac.go":  {`"admin": {"*"},`, `"editor": {"tags:*"},`, "func Require(permission string) gin.HandlerFunc"},
+			"internal/auth/users.go": {`const DefaultRole = "editor"`},
+		}},
+		{"node", "fastify", "hexagonal", map[string][]string{
+			"src/index.js":     {"import { authorize } from './auth/rbac.js';", "app.delete('/tags/:id', { preHandler: [requireAuth, authorize('tags:delete')] }, deleteTag);"},
+			"src/auth/rbac.js": {"'editor': ['tags:*'],"},
+		}},
+		{"python", "fastapi", "mvp", map[string][]string{
+			"app/routes/tags.py": {"@router.delete('/tags/{id}', status_code=204, dependencies=[Depends(authorize('tags:delete'))])"},
+			"app/auth/rbac.py":   {"'editor': ['tags:*'],"},
+		}},
+		{"python", "django", "mvp", map[string][]string{
+			"api/model_views.py": {`@permission_classes([requires({"GET": "tags:get", "PUT": "tags:update", "DELETE": "tags:delete"})])`},
+			"api/rbac.py":        {"DEFAULT_ROLE = 'editor'"},
+			"api/auth.py":        {"    assign_default_role(user)\n"},
+		}},
+	}
+	for _, tc := range cases {
+		name := tc.language + " " + tc.architecture
+		project, err := engine.GenerateProject(context.Background(), GenerateRequest{
+			Language:     tc.language,
+			Framework:    tc.framework,
+			Architecture: tc.architecture,
+			Database:     "postgresql",
+			Features:     FeatureOptions{RBAC: true},
+			FileToggles:  FileToggleOptions{ExampleCRUD: ptr(true), Readme: ptr(true)},
+			RBAC: RBACOptions{Roles: []RBACRole{
+				{Name: "admin", Permissions: []string{"*"}},
+				{Name: "editor", Permissions: []string{"tags:*"}},
+			}},
+			Root:   RootOptions{Mode: "new", Name: "blog", Module: "example.com/blog"},
+			Custom: CustomOptions{Models: []DataModel{{Name: "Tag", Fields: []DataField{{Name: "label", Type: "string"}}}}},
+		})
+		if err != nil {
+			t.Fatalf("%s: GenerateProject() error = %v", name, err)
+		}
+		for path, want := range tc.files {
+			assertContainsAll(t, name+" "+path, project.Tree.Files[path], want...)
+		}
+		assertContainsAll(t, name+" README.md", project.Tree.Files["README.md"],
+			"## Access control", "| `DELETE /tags/{id}` | `tags:delete` | ✓ | ✓ |")
+	}
+
+	errs := ValidateAll(GenerateRequest{
+		Language: "go", Framework: "gin", Architecture: "mvp", Database: "none",
+		Features:    FeatureOptions{JWTAuth: true, RBAC: true},
+		FileToggles: FileToggleOptions{ExampleCRUD: ptr(true)},
+		RBAC: RBACOptions{DefaultRole: "guest", Roles: []RBACRole{
+			{Name: "Admin", Permissions: []string{"orders:delete"}},
+			{Name: "user", Permissions: []string{"tags"}},
+		}},
+		Custom: CustomOptions{Models: []DataModel{{Name: "Tag", Fields: []DataField{{Name: "label", Type: "string"}}}}},
+	})
+	got := map[string]string{}
+	for _, e := range errs {
+		got[e.Pointer] = e.Code
+	}
+	for pointer, code := range map[string]string{
+		"/rbac/roles/0/name":          "RBAC_ROLE_INVALID",
+		"/rbac/roles/0/permissions/0": "RBAC_PERMISSION_UNKNOWN",
+		"/rbac/roles/1/permissions/0": "RBAC_PERMISSION_INVALID",
+		"/rbac/default_role":          "RBAC_DEFAULT_ROLE_UNKNOWN",
+	} {
+		if got[pointer] != code {
+			t.Errorf("pointer %s: got code %q, want %q", pointer, got[pointer], code)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Conflict policies for files that already exist when generating into an
+// existing root (RootOptions.OnConflict / RootOptions.FilePolicies).
+const (
+	ConflictSkip      = "skip"
+	ConflictOverwrite = "overwrite"
+	ConflictBackup    = "backup" // keep the old file as <path>.orig, then overwrite
+	ConflictMerge     = "merge"  // merge known formats; anything else is skipped
+)
+
+var conflictPolicies = []string{ConflictBackup, ConflictMerge, ConflictOverwrite, ConflictSkip}
+
+func isConflictPolicy(policy string) bool {
+	for _, p := range conflictPolicies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
+
+// conflictPolicy resolves the policy for one output path. A merge request for
+// a format without a merger degrades to skip, so the user's file is never lost.
+func conflictPolicy(root RootOptions, p string) string {
+	policy := root.OnConflict
+	if override, ok := root.FilePolicies[p]; ok {
+		policy = override
+	}
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	if policy == "" {
+		policy = ConflictMerge
+	}
+	if policy == ConflictMerge && !canMerge(p) {
+		return ConflictSkip
+	}
+	return policy
+}
+
+func canMerge(p string) bool {
+	switch path.Base(p) {
+	case ".gitignore", "go.mod", "package.json":
+		return true
+	}
+	return false
+}
+
+// resolveExisting decides what to write over an existing file. It returns the
+// content to write and the action taken; "skip" and "unchanged" write nothing.
+func resolveExisting(policy, p, existing, generated string) (string, string) {
+	if existing == generated {
+		return "", "unchanged"
+	}
+	switch policy {
+	case ConflictOverwrite:
+		return generated, "overwrite"
+	case ConflictBackup:
+		return generated, "backup"
+	case ConflictMerge:
+		merged, err := mergeExisting(p, existing, generated)
+		if err != nil {
+			return "", "skip"
+		}
+		if merged == existing {
+			return "", "unchanged"
+		}
+		return merged, "merge"
+	}
+	return "", "skip"
+}
+
+func mergeExisting(p, existing, generated string) (string, error) {
+	switch path.Base(p) {
+	case ".gitignore":
+		return mergeGitignore(existing, generated), nil
+	case "go.mod":
+		return mergeGoMod(existing, generated), nil
+	case "package.json":
+		return mergePackageJSON(existing, generated)
+	}
+	return "", fmt.Errorf("no merger for %s", p)
+}
+
+// mergeGitignore appends the generated patterns the existing file lacks.
+func mergeGitignore(existing, generated string) string {
+	have := map[string]struct{}{}
+	for _, line := range strings.Split(existing, "\n") {
+		have[strings.TrimSpace(line)] = struct{}{}
+	}
+	var missing []string
+	for _, line := range strings.Split(generated, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, ok := have[line]; !ok {
+			missing = append(missing, line)
+			have[line] = struct{}{}
+		}
+	}
+	if len(missing) == 0 {
+		return existing
+	}
+	if existing != "" && !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	return existing + "\n# Added by StackSprint\n" + strings.Join(missing, "\n") + "\n"
+}
+
+// mergeGoMod appends a require block with the generated requirements whose
+// module the existing go.mod does not mention; versions already pinned by the
+// user win. `go mod tidy` folds the extra block back in.
+func mergeGoMod(existing, generated string) string {
+	have := map[string]struct{}{}
+	for _, r := range goModRequires(existing) {
+		have[strings.Fields(r)[0]] = struct{}{}
+	}
+	var missing []string
+	for _, r := range goModRequires(generated) {
+		if _, ok := have[strings.Fields(r)[0]]; !ok {
+			missing = append(missing, "\t"+r)
+		}
+	}
+	if len(missing) == 0 {
+		return existing
+	}
+	if !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	return existing + "\nrequire (\n" + strings.Join(missing, "\n") + "\n)\n"
+}
+
+// goModRequires lists "module version [// comment]" for every require entry,
+// in both the single-line and the block form.
+func goModRequires(gomod string) []string {
+	var out []string
+	inBlock := false
+	sc := bufio.NewScanner(strings.NewReader(gomod))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case inBlock && strings.HasPrefix(line, ")"):
+			inBlock = false
+		case inBlock:
+			if line != "" && !strings.HasPrefix(line, "//") {
+				out = append(out, line)
+			}
+		case strings.HasPrefix(line, "require") && strings.TrimSpace(strings.TrimPrefix(line, "require")) == "(":
+			inBlock = true
+		case strings.HasPrefix(line, "require "):
+			out = append(out, strings.TrimSpace(strings.TrimPrefix(line, "require ")))
+		}
+	}
+	return out
+}
+
+// mergePackageJSON adds the generated dependencies and devDependencies the
+// existing package.json lacks, keeping the user's key order and versions.
+func mergePackageJSON(existing, generated string) (string, error) {
+	cur, err := decodeOrderedObject([]byte(existing))
+	if err != nil {
+		return "", fmt.Errorf("parse existing package.json: %w", err)
+	}
+	gen, err := decodeOrderedObject([]byte(generated))
+	if err != nil {
+		return "", fmt.Errorf("parse generated package.json: %w", err)
+	}
+	changed := false
+	for _, section := range []string{"dependencies", "devDependencies"} {
+		genDeps, ok := lookupMember(gen, section)
+		if !ok {
+			continue
+		}
+		want, err := decodeOrderedObject(genDeps)
+		if err != nil {
+			return "", fmt.Errorf("parse generated %s: %w", section, err)
+		}
+		var have []jsonMember
+		if raw, ok := lookupMember(cur, section); ok {
+			if have, err = decodeOrderedObject(raw); err != nil {
+				return "", fmt.Errorf("parse existing %s: %w", section, err)
+			}
+		}
+		added := false
+		for _, dep := range want {
+			if _, ok := lookupMember(have, dep.Key); !ok {
+				have = append(have, dep)
+				added = true
+			}
+		}
+		if !added {
+			continue
+		}
+		changed = true
+		cur = setMember(cur, section, encodeOrderedObject(have))
+	}
+	if !changed {
+		return existing, nil
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, encodeOrderedObject(cur), "", "  "); err != nil {
+		return "", err
+	}
+	return out.String() + "\n", nil
+}
+
+type jsonMember struct {
+	Key   string
+	Value json.RawMessage
+}
+
+// decodeOrderedObject decodes one JSON object level, preserving key order.
+func decodeOrderedObject(raw []byte) ([]jsonMember, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+	var members []jsonMember
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		members = append(members, jsonMember{Key: tok.(string), Value: value})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func encodeOrderedObject(members []jsonMember) []byte {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(m.Key)
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(m.Value)
+	}
+	b.WriteByte('}')
+	return b.Bytes()
+}
+
+func lookupMember(members []jsonMember, key string) (json.RawMessage, bool) {
+	for _, m := range members {
+		if m.Key == key {
+			return m.Value, true
+		}
+	}
+	return nil, false
+}
+
+func setMember(members []jsonMember, key string, value json.RawMessage) []jsonMember {
+	for i := range members {
+		if members[i].Key == key {
+			members[i].Value = value
+			return members
+		}
+	}
+	return append(members, jsonMember{Key: key, Value: value})
+}
@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConflictPolicy(t *testing.T) {
+	root := RootOptions{Mode: "existing", OnConflict: "backup", FilePolicies: map[string]string{"go.mod": "merge", "README.md": "Skip"}}
+	tests := map[string]string{
+		"go.mod":         ConflictMerge,
+		"README.md":      ConflictSkip,
+		"cmd/server.go":  ConflictBackup,
+		"web/.gitignore": ConflictBackup,
+	}
+	for p, want := range tests {
+		if got := conflictPolicy(root, p); got != want {
+			t.Errorf("conflictPolicy(%s) = %q, want %q", p, got, want)
+		}
+	}
+	// merge is the default, and degrades to skip for formats without a merger.
+	if got := conflictPolicy(RootOptions{}, "main.go"); got != ConflictSkip {
+		t.Errorf("default policy for main.go = %q, want skip", got)
+	}
+	if got := conflictPolicy(RootOptions{}, ".gitignore"); got != ConflictMerge {
+		t.Errorf("default policy for .gitignore = %q, want merge", got)
+	}
+}
+
+func TestMergeExisting(t *testing.T) {
+	gitignore := mergeGitignore("bin/\n.env", "# Generated\n.env\nnode_modules/\n")
+	if gitignore != "bin/\n.env\n\n# Added by StackSprint\nnode_modules/\n" {
+		t.Errorf("mergeGitignore() = %q", gitignore)
+	}
+
+	gomod := mergeGoMod(
+		"module example.com/app\n\ngo 1.22\n\nrequire github.com/gin-gonic/gin v1.8.0\n",
+		"module example.com/app\n\ngo 1.24\n\nrequire (\n\tgithub.com/gin-gonic/gin v1.10.0\n\tgorm.io/gorm v1.25.12\n)\n",
+	)
+	if !strings.Contains(gomod, "require github.com/gin-gonic/gin v1.8.0\n") ||
+		!strings.HasSuffix(gomod, "\nrequire (\n\tgorm.io/gorm v1.25.12\n)\n") ||
+		strings.Contains(gomod, "v1.10.0") {
+		t.Errorf("mergeGoMod() kept the wrong requirements:\n%s", gomod)
+	}
+
+	pkg, err := mergePackageJSON(
+		`{"name": "app", "scripts": {"start": "node x.js"}, "dependencies": {"express": "^4.0.0"}}`,
+		`{"name": "generated", "dependencies": {"express": "^4.21.0", "pg": "^8.13.3"}, "devDependencies": {"jest": "^29.7.0"}}`,
+	)
+	if err != nil {
+		t.Fatalf("mergePackageJSON() failed: %v", err)
+	}
+	want := `{
+  "name": "app",
+  "scripts": {
+    "start": "node x.js"
+  },
+  "dependencies": {
+    "express": "^4.0.0",
+    "pg": "^8.13.3"
+  },
+  "devDependencies": {
+    "jest": "^29.7.0"
+  }
+}
+`
+	if pkg != want {
+		t.Errorf("mergePackageJSON() = %s, want %s", pkg, want)
+	}
+	if _, err := mergePackageJSON("not json", `{}`); err == nil {
+		t.Error("expected an error for an unparseable package.json")
+	}
+}
+
+func TestWriteTreeExistingPolicies(t *testing.T) {
+	dir := t.TempDir()
+	for p, content := range map[string]string{
+		".gitignore": "bin/\n",
+		"README.md":  "# Mine\n",
+		"main.go":    "package main // mine\n",
+		"Makefile":   "run:\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, p), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tree := FileTree{
+		Files: map[string]string{
+			".gitignore": ".env\n",
+			"README.md":  "# Generated\n",
+			"main.go":    "package main\n",
+			"Makefile":   "run:\n",
+			"app.go":     "package main\n",
+		},
+		Dirs: map[string]struct{}{".": {}},
+	}
+	root := &RootOptions{Mode: "existing", FilePolicies: map[string]string{"README.md": ConflictBackup}}
+
+	report, err := WriteTree(dir, tree, WriteOptions{Existing: root})
+	if err != nil {
+		t.Fatalf("WriteTree() failed: %v", err)
+	}
+	actions := map[string]string{}
+	for _, f := range report {
+		actions[f.Path] = f.Action
+	}
+	want := map[string]string{".gitignore": "merge", "README.md": "backup", "main.go": "skip", "Makefile": "unchanged", "app.go": "create"}
+	for p, action := range want {
+		if actions[p] != action {
+			t.Errorf("%s: action %q, want %q", p, actions[p], action)
+		}
+	}
+
+	read := func(p string) string {
+		raw, _ := os.ReadFile(filepath.Join(dir, p))
+		return string(raw)
+	}
+	if got := read("README.md.orig"); got != "# Mine\n" {
+		t.Errorf("README.md.orig = %q", got)
+	}
+	if got := read("README.md"); got != "# Generated\n" {
+		t.Errorf("README.md = %q", got)
+	}
+	if got := read("main.go"); got != "package main // mine\n" {
+		t.Errorf("skipped main.go was modified: %q", got)
+	}
+	if got := read(".gitignore"); !strings.HasPrefix(got, "bin/\n") || !strings.Contains(got, ".env\n") {
+		t.Errorf(".gitignore not merged: %q", got)
+	}
+}
+
+func TestBuildBashExistingRoot(t *testing.T) {
+	req := GenerateRequest{Root: RootOptions{Mode: "existing", Path: "app", OnConflict: ConflictMerge, FilePolicies: map[string]string{"README.md": ConflictOverwrite}}}
+	tree := FileTree{
+		Files: map[string]string{"go.mod": "module app\n", "README.md": "# App\n", "main.go": "package main\n"},
+		Dirs:  map[string]struct{}{".": {}},
+	}
+	script := buildBash(req, tree)
+	for _, want := range []string{
+		`stacksprint_place "go.mod" "merge" <<'`,
+		`stacksprint_place "README.md" "overwrite" <<'`,
+		`stacksprint_place "main.go" "skip" <<'`,
+		"StackSprint summary:",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+	if strings.Contains(script, `cat > "go.mod"`) {
+		t.Error("existing root must not write files with a bare cat >")
+	}
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// standardArchitectures are the architecture ids shared by the bundled
+// generators. A language may register a subset.
+var standardArchitectures = []string{"mvp", "clean", "hexagonal", "modular-monolith", "microservices"}
+
+// standardInfra, standardFeatures and standardFileToggles are every infra,
+// feature and file toggle key of the request, for generators that wire up all
+// of them. A language may register a subset.
+var (
+	standardInfra       = []string{"redis", "kafka", "nats", "nats_jetstream", "rabbitmq"}
+	standardFeatures    = []string{"jwt_auth", "rbac", "swagger", "github_actions_ci", "makefile", "logger", "global_error_handler", "health_endpoint", "sample_test", "kubernetes", "helm", "observability"}
+	standardFileToggles = []string{"env", "gitignore", "dockerfile", "docker_compose", "readme", "config_loader", "logger", "base_route", "example_crud", "health_check"}
+)
+
+// LanguageSpec describes one language generator: its id, what it supports and
+// how to build it. Validation and the capability listing are derived from the
+// registered specs, so adding a language is a single RegisterLanguage call.
+// Infra, Features and FileToggles hold the request option keys the generator
+// acts on; options left out are reported as unsupported. FrameworkGaps lists,
+// per framework, the keys among them that framework ignores.
+type LanguageSpec struct {
+	ID            string
+	Frameworks    []string
+	Architectures []string
+	Infra         []string
+	Features      []string
+	FileToggles   []string
+	FrameworkGaps map[string][]string
+	New           func() Generator
+}
+
+var languageRegistry = map[string]LanguageSpec{}
+
+// RegisterLanguage adds a generator to the registry. It is meant to be called
+// from init and panics on an incomplete or duplicate spec.
+func RegisterLanguage(spec LanguageSpec) {
+	if spec.ID == "" || spec.New == nil || len(spec.Frameworks) == 0 || len(spec.Architectures) == 0 {
+		panic(fmt.Sprintf("generator: incomplete language spec %q", spec.ID))
+	}
+	if _, dup := languageRegistry[spec.ID]; dup {
+		panic(fmt.Sprintf("generator: language %q registered twice", spec.ID))
+	}
+	languageRegistry[spec.ID] = spec
+}
+
+// LookupLanguage returns the registered spec for a language id.
+func LookupLanguage(lang string) (LanguageSpec, bool) {
+	spec, ok := languageRegistry[strings.ToLower(strings.TrimSpace(lang))]
+	return spec, ok
+}
+
+// RegisteredLanguages returns the registered language ids in sorted order.
+func RegisteredLanguages() []string {
+	out := make([]string, 0, len(languageRegistry))
+	for id := range languageRegistry {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// GetGenerator returns the generator registered for lang. An unknown language
+// is an error rather than a silent fallback.
+func GetGenerator(lang string) (Generator, error) {
+	spec, ok := LookupLanguage(lang)
+	if !ok {
+		return nil, fmt.Errorf("no generator registered for language %q", lang)
+	}
+	return spec.New(), nil
+}
+
+// registeredArchitectures is the union of every language's architectures.
+func registeredArchitectures() map[string]struct{} {
+	out := map[string]struct{}{}
+	for _, spec := range languageRegistry {
+		for _, arch := range spec.Architectures {
+			out[arch] = struct{}{}
+		}
+	}
+	return out
+}
+
+func setOf(values []string) map[string]struct{} {
+	out := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		out[v] = struct{}{}
+	}
+	return out
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGetGeneratorUnknownLanguageIsError(t *testing.T) {
+	if _, err := GetGenerator("rust"); err == nil {
+		t.Fatal("expected error for unregistered language")
+	}
+	for _, lang := range []string{"go", "node", "python"} {
+		if _, err := GetGenerator(lang); err != nil {
+			t.Errorf("GetGenerator(%q) failed: %v", lang, err)
+		}
+	}
+}
+
+func TestRegisteredLanguageDrivesValidationAndCapabilities(t *testing.T) {
+	RegisterLanguage(LanguageSpec{
+		ID:            "zig",
+		Frameworks:    []string{"zap"},
+		Architectures: []string{"mvp"},
+		Infra:         []string{"redis"},
+		New:           func() Generator { return &GoGenerator{} },
+	})
+	defer delete(languageRegistry, "zig")
+
+	req := GenerateRequest{Language: "zig", Framework: "zap", Architecture: "mvp", Database: "none", Root: RootOptions{Mode: "new", Name: "demo"}}
+	if errs := ValidateAll(req); len(errs) != 0 {
+		t.Fatalf("expected registered language to validate, got %v", errs)
+	}
+	req.Architecture = "clean"
+	errs := ValidateAll(req)
+	if len(errs) != 1 || errs[0].Pointer != "/architecture" {
+		t.Fatalf("expected architecture error for zig/clean, got %v", errs)
+	}
+
+	found := false
+	for _, lang := range Capabilities().Languages {
+		if lang.ID == "zig" && len(lang.Frameworks) == 1 && lang.Frameworks[0] == "zap" {
+			found = true
+			if !slices.Equal(lang.Infra, []string{"redis"}) || lang.Features == nil || len(lang.Features) != 0 {
+				t.Errorf("zig options should come from its spec, got infra %v features %v", lang.Infra, lang.Features)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected zig in capabilities")
+	}
+}
+
+func TestCapabilitiesReportPerLanguageSupport(t *testing.T) {
+	RegisterLanguage(LanguageSpec{
+		ID:            "zig",
+		Frameworks:    []string{"zap"},
+		Architectures: []string{"mvp"},
+		Infra:         []string{"redis"},
+		Features:      []string{"jwt_auth"},
+		New:           func() Generator { return &GoGenerator{} },
+	})
+	defer delete(languageRegistry, "zig")
+
+	langs := map[string]LanguageCapabilities{}
+	for _, lang := range Capabilities().Languages {
+		langs[lang.ID] = lang
+	}
+	cases := []struct {
+		lang, framework, key string
+		want                 bool
+	}{
+		{"go", "gin", "sample_test", true},
+		{"python", "fastapi", "sample_test", true},
+		{"python", "django", "sample_test", false},
+		{"python", "django", "config_loader", false},
+		{"python", "django", "jwt_auth", true},
+		{"zig", "zap", "redis", true},
+		{"zig", "zap", "kafka", false},
+		{"zig", "zap", "swagger", false},
+	}
+	for _, tc := range cases {
+		if got := langs[tc.lang].Supports(tc.framework, tc.key); got != tc.want {
+			t.Errorf("%s/%s supports %s = %v, want %v", tc.lang, tc.framework, tc.key, got, tc.want)
+		}
+	}
+}
+
+// The standard option lists must name every request option, so a new option
+// is either wired into the bundled generators or left out deliberately.
+func TestStandardOptionListsMatchRequest(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		list []string
+		opts any
+	}{
+		{"infra", standardInfra, InfraOptions{}},
+		{"features", standardFeatures, FeatureOptions{}},
+		{"file toggles", standardFileToggles, FileToggleOptions{}},
+	} {
+		if want := jsonFieldNames(tc.opts); !slices.Equal(tc.list, want) {
+			t.Errorf("standard %s = %v, want %v", tc.name, tc.list, want)
+		}
+	}
+}
+
+func jsonFieldNames(v any) []string {
+	t := reflect.TypeOf(v)
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -17,7 +17,7 @@ func resolveGoModule(root RootOptions, fallback string) string {
 	return fallback
 }
 
-func goModV2(framework string, root RootOptions, db string, useORM bool, useGRPC bool) string {
+func goModV2(framework string, root RootOptions, db string, useORM bool, useGRPC bool, useJWT bool, useOTel bool, infra InfraOptions) string {
 	module := resolveGoModule(root, "stacksprint/generated")
 
 	deps := []string{}
@@ -46,6 +46,50 @@ func goModV2(framework string, root RootOptions, db string, useORM bool, useGRPC
 			deps = append(deps, "github.com/go-sql-driver/mysql v1.8.1")
 		}
 	}
+	if db == "sqlite" {
+		if useORM {
+			deps = append(deps,
+				"gorm.io/gorm v1.25.12",
+				"github.com/glebarez/sqlite v1.11.0",
+			)
+		} else {
+			deps = append(deps, "modernc.org/sqlite v1.34.4")
+		}
+	}
+	if useJWT {
+		deps = append(deps,
+			"github.com/golang-jwt/jwt/v5 v5.2.1",
+			"golang.org/x/crypto v0.31.0",
+		)
+	}
+	if useOTel {
+		deps = append(deps,
+			"github.com/prometheus/client_golang v1.20.5",
+			"go.opentelemetry.io/otel v1.34.0",
+			"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.34.0",
+			"go.opentelemetry.io/otel/sdk v1.34.0",
+			"go.opentelemetry.io/otel/trace v1.34.0",
+		)
+		if isSQLDB(db) {
+			if useORM {
+				deps = append(deps, "gorm.io/plugin/opentelemetry v0.1.10")
+			} else {
+				deps = append(deps, "github.com/XSAM/otelsql v0.37.0")
+			}
+		}
+	}
+	if infra.Redis {
+		deps = append(deps, "github.com/redis/go-redis/v9 v9.7.0")
+	}
+	if infra.Kafka {
+		deps = append(deps, "github.com/segmentio/kafka-go v0.4.47")
+	}
+	if infra.NATS {
+		deps = append(deps, "github.com/nats-io/nats.go v1.38.0")
+	}
+	if infra.RabbitMQ {
+		deps = append(deps, "github.com/rabbitmq/amqp091-go v1.10.0")
+	}
 	if useGRPC {
 		deps = append(deps,
 			"google.golang.org/grpc v1.69.2",
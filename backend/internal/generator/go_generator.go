@@ -8,6 +8,18 @@ import (
 
 type GoGenerator struct{}
 
+func init() {
+	RegisterLanguage(LanguageSpec{
+		ID:            "go",
+		Frameworks:    []string{"fiber", "gin"},
+		Architectures: standardArchitectures,
+		Infra:         standardInfra,
+		Features:      standardFeatures,
+		FileToggles:   standardFileToggles,
+		New:           func() Generator { return &GoGenerator{} },
+	})
+}
+
 func (g *GoGenerator) GenerateArchitecture(req *GenerateRequest, ctx *GenerationContext) error {
 	if req.Architecture == "microservices" {
 		for _, svc := range req.Services {
@@ -18,19 +30,9 @@ func (g *GoGenerator) GenerateArchitecture(req *GenerateRequest, ctx *Generation
 			if isEnabled(req.FileToggles.BaseRoute) {
 				addFile(ctx.FileTree, path.Join(svcRoot, "internal/routes/base.go"), "package routes\n\nconst BasePath = \"/api/v1\"\n")
 			}
-			if isEnabled(req.FileToggles.ExampleCRUD) {
-				if req.Framework == "gin" {
-					addFile(ctx.FileTree, path.Join(svcRoot, "internal/handlers/items.go"), "package handlers\n\nimport \"github.com/gin-gonic/gin\"\n\nfunc ListItems(c *gin.Context) {\n\tc.JSON(200, []gin.H{{\"id\": 1, \"name\": \"sample\"}})\n}\n")
-				} else {
-					addFile(ctx.FileTree, path.Join(svcRoot, "internal/handlers/items.go"), "package handlers\n\nimport \"github.com/gofiber/fiber/v2\"\n\nfunc ListItems(c *fiber.Ctx) error {\n\treturn c.JSON([]map[string]any{{\"id\": 1, \"name\": \"sample\"}})\n}\n")
-				}
-			}
 			if isEnabled(req.FileToggles.HealthCheck) || req.Features.Health {
 				addFile(ctx.FileTree, path.Join(svcRoot, "internal/health/handler.go"), "package health\n\nfunc Message() string { return \"ok\" }\n")
 			}
-			if req.Features.JWTAuth {
-				addFile(ctx.FileTree, path.Join(svcRoot, "internal/auth/jwt.go"), "package auth\n\nimport \"os\"\n\nfunc Secret() string { return os.Getenv(\"JWT_SECRET\") }\n")
-			}
 			if strings.EqualFold(req.ServiceCommunication, "grpc") {
 				g.addGRPCBoilerplate(ctx.FileTree, req, svcRoot)
 			}
@@ -42,7 +44,9 @@ func (g *GoGenerator) GenerateArchitecture(req *GenerateRequest, ctx *Generation
 		if isEnabled(req.FileToggles.BaseRoute) {
 			addFile(ctx.FileTree, "internal/routes/base.go", "package routes\n\nconst BasePath = \"/api/v1\"\n")
 		}
-		if isEnabled(req.FileToggles.ExampleCRUD) {
+		// MVP renders its model handlers into internal/handlers from the
+		// manifest; a static ListItems there would collide with them.
+		if isEnabled(req.FileToggles.ExampleCRUD) && req.Architecture != "mvp" {
 			if req.Framework == "gin" {
 				addFile(ctx.FileTree, "internal/handlers/items.go", "package handlers\n\nimport \"github.com/gin-gonic/gin\"\n\nfunc ListItems(c *gin.Context) {\n\tc.JSON(200, []gin.H{{\"id\": 1, \"name\": \"sample\"}})\n}\n")
 			} else {
@@ -52,9 +56,6 @@ func (g *GoGenerator) GenerateArchitecture(req *GenerateRequest, ctx *Generation
 		if isEnabled(req.FileToggles.HealthCheck) || req.Features.Health {
 			addFile(ctx.FileTree, "internal/health/handler.go", "package health\n\nfunc Message() string { return \"ok\" }\n")
 		}
-		if req.Features.JWTAuth {
-			addFile(ctx.FileTree, "internal/auth/jwt.go", "package auth\n\nimport \"os\"\n\nfunc Secret() string { return os.Getenv(\"JWT_SECRET\") }\n")
-		}
 		if strings.EqualFold(req.ServiceCommunication, "grpc") {
 			addFile(ctx.FileTree, "proto/README.md", "# Shared proto definitions\n\nPlace your protobuf contracts here.\n")
 			addFile(ctx.FileTree, "proto/common.proto", "syntax = \"proto3\";\npackage stacksprint;\n\nservice InternalService {\n  rpc Ping(PingRequest) returns (PingReply);\n}\n\nmessage PingRequest {\n  string source = 1;\n}\n\nmessage PingReply {\n  string message = 1;\n}\n")
@@ -80,11 +81,13 @@ func (g *GoGenerator) GenerateModels(req *GenerateRequest, ctx *GenerationContex
 					"UseSQL":       isSQLDB(req.Database),
 					"UseORM":       req.UseORM,
 					"DBKind":       req.Database,
+					"Store":        goStore(req),
 					"Module":       fmt.Sprintf("stacksprint/%s", svc.Name),
 					"Service":      svc.Name,
+					"Cache":        newCacheData(*req, svc.Name),
 				}
-				for _, model := range resolvedModels(req.Custom.Models) {
-					g.renderGoOtherDynamicModel(ctx, data, model, req.Architecture, svcRoot)
+				if err := g.renderGoDynamicModels(ctx, req, data, svcRoot); err != nil {
+					return err
 				}
 			}
 		}
@@ -94,7 +97,9 @@ func (g *GoGenerator) GenerateModels(req *GenerateRequest, ctx *GenerationContex
 			module := resolveGoModule(req.Root, "stacksprint/generated")
 			addFile(ctx.FileTree, "cmd/seeder/main.go", renderGoSeederScript(module, req.Custom.Models, req.UseORM))
 			addFile(ctx.FileTree, "migrations/001_initial.sql", sampleMigration(req.Database, req.Custom.Models))
-			addFile(ctx.FileTree, "db/init/001_init.sql", sampleDBInit(req.Database, req.Custom.Models))
+			if req.Database != "sqlite" {
+				addFile(ctx.FileTree, "db/init/001_init.sql", sampleDBInit(req.Database, req.Custom.Models))
+			}
 		}
 		if isEnabled(req.FileToggles.ExampleCRUD) {
 			module := resolveGoModule(req.Root, "stacksprint/generated")
@@ -106,19 +111,13 @@ func (g *GoGenerator) GenerateModels(req *GenerateRequest, ctx *GenerationContex
 				"UseSQL":       isSQLDB(req.Database),
 				"UseORM":       req.UseORM,
 				"DBKind":       req.Database,
+				"Store":        goStore(req),
 				"Module":       module,
 				"Service":      "app",
+				"Cache":        newCacheData(*req, ""),
 			}
-			for _, model := range resolvedModels(req.Custom.Models) {
-				if req.Architecture == "clean" {
-					if err := g.renderGoCleanDynamicModel(ctx, data, model, ""); err != nil {
-						return err
-					}
-				} else {
-					if err := g.renderGoOtherDynamicModel(ctx, data, model, req.Architecture, ""); err != nil {
-						return err
-					}
-				}
+			if err := g.renderGoDynamicModels(ctx, req, data, ""); err != nil {
+				return err
 			}
 		}
 	}
@@ -127,13 +126,6 @@ func (g *GoGenerator) GenerateModels(req *GenerateRequest, ctx *GenerationContex
 
 func (g *GoGenerator) GenerateInfra(req *GenerateRequest, ctx *GenerationContext) error {
 	handleInfra := func(root string, port int) {
-		if req.Infra.Redis {
-			addFile(ctx.FileTree, path.Join(root, "internal/cache/redis.go"), "package cache\n\nimport \"os\"\n\ntype RedisCache struct {\n\tAddr string\n}\n\nfunc NewRedisCache() *RedisCache {\n\taddr := os.Getenv(\"REDIS_ADDR\")\n\tif addr == \"\" {\n\t\taddr = \"redis:6379\"\n\t}\n\treturn &RedisCache{Addr: addr}\n}\n\nfunc (r *RedisCache) Ping() string {\n\treturn \"redis configured at \" + r.Addr\n}\n")
-		}
-		if req.Infra.Kafka {
-			addFile(ctx.FileTree, path.Join(root, "internal/messaging/kafka_producer.go"), "package messaging\n\nimport \"os\"\n\ntype KafkaProducer struct {\n\tBrokers string\n}\n\nfunc NewKafkaProducer() *KafkaProducer {\n\tb := os.Getenv(\"KAFKA_BROKERS\")\n\tif b == \"\" {\n\t\tb = \"kafka:9092\"\n\t}\n\treturn &KafkaProducer{Brokers: b}\n}\n\nfunc (p *KafkaProducer) Publish(topic, payload string) string {\n\treturn \"publish stub to \" + topic + \" via \" + p.Brokers + \" payload=\" + payload\n}\n")
-			addFile(ctx.FileTree, path.Join(root, "internal/messaging/kafka_consumer.go"), "package messaging\n\nimport \"os\"\n\ntype KafkaConsumer struct {\n\tBrokers string\n}\n\nfunc NewKafkaConsumer() *KafkaConsumer {\n\tb := os.Getenv(\"KAFKA_BROKERS\")\n\tif b == \"\" {\n\t\tb = \"kafka:9092\"\n\t}\n\treturn &KafkaConsumer{Brokers: b}\n}\n\nfunc (c *KafkaConsumer) Subscribe(topic string) string {\n\treturn \"consumer stub subscribed to \" + topic + \" via \" + c.Brokers\n}\n")
-		}
 		if isEnabled(req.FileToggles.Env) {
 			svcName := ""
 			if root != "" {
@@ -158,6 +150,12 @@ func (g *GoGenerator) GenerateInfra(req *GenerateRequest, ctx *GenerationContext
 	if isEnabled(req.FileToggles.Compose) {
 		addFile(ctx.FileTree, "docker-compose.yaml", buildCompose(*req))
 	}
+	if req.Features.Observability {
+		addObservabilityFiles(ctx.FileTree, *req)
+	}
+	if req.Features.Kubernetes {
+		addKubernetesFiles(ctx.FileTree, *req)
+	}
 	return nil
 }
 
@@ -166,7 +164,7 @@ func (g *GoGenerator) GenerateDevTools(req *GenerateRequest, ctx *GenerationCont
 		addFile(ctx.FileTree, ".gitignore", "bin/\nobj/\n.env\n.DS_Store\nnode_modules/\nvendor/\n__pycache__/\n*.sqlite3\n")
 	}
 	if isEnabled(req.FileToggles.Readme) {
-		addFile(ctx.FileTree, "README.md", fmt.Sprintf("# StackSprint Generated Project\n\nLanguage: %s\nFramework: %s\nArchitecture: %s\nDatabase: %s\n\n## Run\n\n```bash\ndocker compose up --build\n```\n", req.Language, req.Framework, req.Architecture, req.Database))
+		addFile(ctx.FileTree, "README.md", fmt.Sprintf("# StackSprint Generated Project\n\nLanguage: %s\nFramework: %s\nArchitecture: %s\nDatabase: %s\n\n## Run\n\n```bash\ndocker compose up --build\n```\n", req.Language, req.Framework, req.Architecture, req.Database)+rbacReadmeSection(*req)+observabilityReadmeSection(*req)+cacheReadmeSection(*req)+messagingReadmeSection(*req)+kubernetesReadmeSection(*req))
 	}
 	if req.Features.GitHubActions {
 		addFile(ctx.FileTree, ".github/workflows/ci.yaml", "name: CI\n\non:\n  push:\n  pull_request:\n\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/checkout@v4\n      - uses: actions/setup-go@v5\n        with:\n          go-version: '1.23'\n      - run: go test ./...\n")
@@ -182,11 +180,22 @@ func (g *GoGenerator) GenerateDevTools(req *GenerateRequest, ctx *GenerationCont
 		addFile(ctx.FileTree, "Makefile", b.String())
 	}
 	if req.Features.Swagger {
-		addFile(ctx.FileTree, "docs/openapi.yaml", "openapi: 3.0.3\ninfo:\n  title: StackSprint API\n  version: 1.0.0\npaths:\n  /health:\n    get:\n      responses:\n        '200':\n          description: OK\n")
+		addOpenAPISpecs(ctx.FileTree, *req, "/health", true)
+		if req.Architecture == "microservices" {
+			for _, svc := range req.Services {
+				addFile(ctx.FileTree, path.Join("services", svc.Name, "docs/docs.go"), goDocsPackage)
+			}
+		} else {
+			addFile(ctx.FileTree, "docs/docs.go", goDocsPackage)
+		}
 	}
 	return nil
 }
 
+// goDocsPackage embeds docs/openapi.yaml, since the Docker image ships only
+// the binary.
+var goDocsPackage = "// Package docs serves the OpenAPI spec generated by StackSprint.\npackage docs\n\nimport _ \"embed\"\n\n//go:embed openapi.yaml\nvar Spec []byte\n\n// Page renders Spec with Swagger UI.\nconst Page = `" + swaggerUIPage + "`\n"
+
 // GetInitCommand returns the bash init command for Go projects.
 func (g *GoGenerator) GetInitCommand(req *GenerateRequest) string {
 	mod := req.Root.Module
@@ -202,7 +211,10 @@ func (g *GoGenerator) GetInitCommand(req *GenerateRequest) string {
 
 func (g *GoGenerator) generateMonolithArch(req *GenerateRequest, ctx *GenerationContext, root string) error {
 	module := resolveGoModule(req.Root, "stacksprint/generated")
-	specs := goMonolithTemplateSpecs(*req)
+	manifest, err := ctx.Registry.Manifest(req.Language, req.Architecture)
+	if err != nil {
+		return err
+	}
 	data := map[string]any{
 		"Framework":    req.Framework,
 		"Architecture": req.Architecture,
@@ -211,13 +223,17 @@ func (g *GoGenerator) generateMonolithArch(req *GenerateRequest, ctx *Generation
 		"UseSQL":       isSQLDB(req.Database),
 		"UseORM":       req.UseORM,
 		"DBKind":       req.Database,
+		"Store":        goStore(req),
 		"Module":       module,
 		"Service":      "app",
+		"Auth":         newAuthData(*req),
+		"Messaging":    newMessagingData(*req, ""),
+		"Cache":        newCacheData(*req, ""),
 	}
-	if err := g.renderSpecs(ctx, specs, data, root); err != nil {
+	if err := g.renderSpecs(ctx, manifest.fileSpecs(*req), data, root); err != nil {
 		return err
 	}
-	addFile(ctx.FileTree, "go.mod", goModV2(req.Framework, req.Root, req.Database, req.UseORM, strings.EqualFold(req.ServiceCommunication, "grpc")))
+	addFile(ctx.FileTree, "go.mod", goModV2(req.Framework, req.Root, req.Database, req.UseORM, strings.EqualFold(req.ServiceCommunication, "grpc"), req.Features.JWTAuth, req.Features.Observability, req.Infra))
 
 	if isEnabled(req.FileToggles.Config) {
 		addFile(ctx.FileTree, "internal/config/config.go", "package config\n\nimport (\n\t\"log\"\n\n\t\"github.com/kelseyhightower/envconfig\"\n)\n\ntype Config struct {\n\tPort        int    `envconfig:\"PORT\" default:\"8080\"`\n\tDatabaseURL string `envconfig:\"DATABASE_URL\"`\n\tJWTSecret   string `envconfig:\"JWT_SECRET\" default:\"default_dev_secret_replace_in_prod\"`\n}\n\nvar AppConfig Config\n\nfunc Init() {\n\terr := envconfig.Process(\"\", &AppConfig)\n\tif err != nil {\n\t\tlog.Fatalf(\"❌ Environment variable validation failed: %v\", err)\n\t}\n}\n\nfunc Port() string {\n\treturn \"%d\" // we will return fmt.Sprint(AppConfig.Port) implicitly by changing the references in main later. This is just a stub for backwards compat if needed, but the main template should use config.AppConfig.Port now.\n\t// We'll update main.go to call config.Init()\n}\n")
@@ -237,13 +253,16 @@ func (g *GoGenerator) generateMonolithArch(req *GenerateRequest, ctx *Generation
 	}
 	g.addAutopilotBoilerplate(ctx.FileTree, req, root)
 	g.addDBRetry(ctx.FileTree, req, root)
-	g.injectGoRoutes(ctx, req, root, module)
+	g.injectGoRoutes(ctx, req, manifest, root, module)
 	return nil
 }
 
 func (g *GoGenerator) generateServiceArch(req *GenerateRequest, ctx *GenerationContext, svcRoot string, svc ServiceConfig) error {
 	module := fmt.Sprintf("stacksprint/%s", svc.Name)
-	specs := goMicroserviceTemplateSpecs(*req)
+	manifest, err := ctx.Registry.Manifest(req.Language, req.Architecture)
+	if err != nil {
+		return err
+	}
 	data := map[string]any{
 		"Framework":    req.Framework,
 		"Architecture": req.Architecture,
@@ -252,17 +271,21 @@ func (g *GoGenerator) generateServiceArch(req *GenerateRequest, ctx *GenerationC
 		"UseSQL":       isSQLDB(req.Database),
 		"UseORM":       req.UseORM,
 		"DBKind":       req.Database,
+		"Store":        goStore(req),
 		"Module":       module,
 		"Service":      svc.Name,
+		"Auth":         newAuthData(*req),
+		"Messaging":    newMessagingData(*req, svc.Name),
+		"Cache":        newCacheData(*req, svc.Name),
 	}
-	if err := g.renderSpecs(ctx, specs, data, svcRoot); err != nil {
+	if err := g.renderSpecs(ctx, manifest.fileSpecs(*req), data, svcRoot); err != nil {
 		return err
 	}
-	addFile(ctx.FileTree, path.Join(svcRoot, "go.mod"), goModV2(req.Framework, RootOptions{Module: module}, req.Database, req.UseORM, strings.EqualFold(req.ServiceCommunication, "grpc")))
+	addFile(ctx.FileTree, path.Join(svcRoot, "go.mod"), goModV2(req.Framework, RootOptions{Module: module}, req.Database, req.UseORM, strings.EqualFold(req.ServiceCommunication, "grpc"), req.Features.JWTAuth, req.Features.Observability, req.Infra))
 
 	g.addAutopilotBoilerplate(ctx.FileTree, req, svcRoot)
 	g.addDBRetry(ctx.FileTree, req, svcRoot)
-	g.injectGoRoutes(ctx, req, svcRoot, module)
+	g.injectGoRoutes(ctx, req, manifest, svcRoot, module)
 	return nil
 }
 
@@ -297,15 +320,23 @@ func (g *GoGenerator) addDatabaseBoilerplate(tree *FileTree, req *GenerateReques
 		}
 		return root + "/" + strings.Join(parts, "/")
 	}
-	if isSQLDB(req.Database) && req.UseORM {
+	if req.Database == "sqlite" {
+		addFile(tree, p("internal", "db", "connection.go"), goSQLiteConnection(req))
+		if req.UseORM {
+			addFile(tree, p("internal", "models", "models.go"), renderGoORMModels(req.Custom.Models))
+		}
+	} else if isSQLDB(req.Database) && req.UseORM {
 		driverImport := "\"gorm.io/driver/postgres\""
 		driverOpen := "postgres.Open(dsn)"
 		if req.Database == "mysql" {
 			driverImport = "\"gorm.io/driver/mysql\""
-			driverOpen := "mysql.Open(dsn)"
-			_ = driverOpen
+			driverOpen = "mysql.Open(dsn)"
+		}
+		if req.Features.Observability {
+			addFile(tree, p("internal", "db", "connection.go"), "package db\n\nimport (\n\t\"os\"\n\n\t"+driverImport+"\n\t\"gorm.io/gorm\"\n\t\"gorm.io/plugin/opentelemetry/tracing\"\n)\n\n// Connect opens the database with every query traced; queries run with\n// WithContext(ctx) join the trace of the request ctx belongs to.\nfunc Connect() (*gorm.DB, error) {\n\tdsn := os.Getenv(\"DATABASE_URL\")\n\tconn, err := gorm.Open("+driverOpen+", &gorm.Config{})\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn conn, conn.Use(tracing.NewPlugin(tracing.WithoutMetrics()))\n}\n")
+		} else {
+			addFile(tree, p("internal", "db", "connection.go"), "package db\n\nimport (\n\t\"os\"\n\n\t"+driverImport+"\n\t\"gorm.io/gorm\"\n)\n\nfunc Connect() (*gorm.DB, error) {\n\tdsn := os.Getenv(\"DATABASE_URL\")\n\treturn gorm.Open("+driverOpen+", &gorm.Config{})\n}\n")
 		}
-		addFile(tree, p("internal", "db", "connection.go"), "package db\n\nimport (\n\t\"os\"\n\n\t"+driverImport+"\n\t\"gorm.io/gorm\"\n)\n\nfunc Connect() (*gorm.DB, error) {\n\tdsn := os.Getenv(\"DATABASE_URL\")\n\treturn gorm.Open("+driverOpen+", &gorm.Config{})\n}\n")
 		addFile(tree, p("internal", "models", "models.go"), renderGoORMModels(req.Custom.Models))
 	} else {
 		stdImport := "\"database/sql\"\n\t_ \"github.com/jackc/pgx/v5/stdlib\""
@@ -314,122 +345,180 @@ func (g *GoGenerator) addDatabaseBoilerplate(tree *FileTree, req *GenerateReques
 			stdImport = "\"database/sql\"\n\t_ \"github.com/go-sql-driver/mysql\""
 			driver = "\"mysql\""
 		}
-		addFile(tree, p("internal", "db", "connection.go"), "package db\n\nimport (\n\t"+stdImport+"\n\t\"os\"\n)\n\nfunc Connect() (*sql.DB, error) {\n\treturn sql.Open("+driver+", os.Getenv(\"DATABASE_URL\"))\n}\n")
+		if req.Features.Observability && isSQLDB(req.Database) {
+			addFile(tree, p("internal", "db", "connection.go"), "package db\n\nimport (\n\t"+stdImport+"\n\t\"os\"\n\n\t\"github.com/XSAM/otelsql\"\n)\n\n// Connect opens the database with every query traced; queries run with a\n// request's ctx (QueryContext, ExecContext) join that request's trace.\nfunc Connect() (*sql.DB, error) {\n\treturn otelsql.Open("+driver+", os.Getenv(\"DATABASE_URL\"))\n}\n")
+		} else {
+			addFile(tree, p("internal", "db", "connection.go"), "package db\n\nimport (\n\t"+stdImport+"\n\t\"os\"\n)\n\nfunc Connect() (*sql.DB, error) {\n\treturn sql.Open("+driver+", os.Getenv(\"DATABASE_URL\"))\n}\n")
+		}
 	}
 	if !req.UseORM || !isSQLDB(req.Database) {
 		addFile(tree, p("internal", "models", "item.go"), "package models\n\ntype Item struct {\n\tID int `json:\"id\"`\n\tName string `json:\"name\"`\n}\n")
 	}
 }
 
-func (g *GoGenerator) renderGoCleanDynamicModel(ctx *GenerationContext, baseData map[string]any, model DataModel, root string) error {
-	prefix := root
-	if prefix != "" {
-		prefix += "/"
+// goSQLiteConnection opens the SQLite file DATABASE_URL names. There is no
+// server to run db/init, so Connect applies the schema itself; every statement
+// in it is IF NOT EXISTS.
+func goSQLiteConnection(req *GenerateRequest) string {
+	imports := []string{"\"os\"", "\"path/filepath\"", "\"strings\""}
+	var third []string
+	open := "sql.Open(\"sqlite\", dsn)"
+	exec := "_, err = conn.Exec(schema)"
+	ret := "*sql.DB"
+	if req.UseORM {
+		third = append(third, "\"github.com/glebarez/sqlite\"", "\"gorm.io/gorm\"")
+		open = "gorm.Open(sqlite.Open(dsn), &gorm.Config{})"
+		exec = "err = conn.Exec(schema).Error"
+		ret = "*gorm.DB"
+		if req.Features.Observability {
+			third = append(third, "\"gorm.io/plugin/opentelemetry/tracing\"")
+		}
+	} else {
+		imports = append([]string{"\"database/sql\""}, imports...)
+		if req.Features.Observability {
+			third = append(third, "\"github.com/XSAM/otelsql\"")
+			open = "otelsql.Open(\"sqlite\", dsn)"
+		}
+		third = append(third, "_ \"modernc.org/sqlite\"")
 	}
-	modelNameLower := strings.ToLower(model.Name)
-	specs := []templateSpec{
-		{Template: "go/clean/internal/domain/dynamic.tmpl", Output: "internal/domain/" + modelNameLower + ".go"},
-		{Template: "go/clean/internal/usecase/dynamic.tmpl", Output: "internal/usecase/" + modelNameLower + "_usecase.go"},
-		{Template: "go/clean/internal/repository/dynamic.tmpl", Output: "internal/repository/" + modelNameLower + "_repository.go"},
-		{Template: "go/clean/internal/delivery/http/dynamic.tmpl", Output: "internal/delivery/http/" + modelNameLower + "_handler.go"},
+	done := "return conn, nil"
+	if req.UseORM && req.Features.Observability {
+		done = "return conn, conn.Use(tracing.NewPlugin(tracing.WithoutMetrics()))"
 	}
 
-	type goTemplateField struct {
-		Name     string
-		Type     string
-		JSONName string
-	}
-	type goTemplateModel struct {
-		Name   string
-		Fields []goTemplateField
-	}
-	templModel := goTemplateModel{Name: model.Name, Fields: make([]goTemplateField, 0, len(model.Fields))}
-	for _, field := range model.Fields {
-		if strings.EqualFold(field.Name, "id") {
-			continue
-		}
-		templModel.Fields = append(templModel.Fields, goTemplateField{
-			Name:     toPascal(field.Name),
-			Type:     goType(field.Type),
-			JSONName: strings.ToLower(field.Name),
-		})
-	}
+	var b strings.Builder
+	b.WriteString("package db\n\nimport (\n\t" + strings.Join(imports, "\n\t") + "\n\n\t" + strings.Join(third, "\n\t") + "\n)\n\n")
+	b.WriteString("// schema creates the tables the models map to.\nconst schema = `" + sampleMigration(req.Database, req.Custom.Models) + "`\n\n")
+	b.WriteString("// Connect opens the database file, creating it and its tables on first run.\n")
+	if req.Features.Observability {
+		b.WriteString("// Every query is traced; queries run with a request's ctx join its trace.\n")
+	}
+	b.WriteString("func Connect() (" + ret + ", error) {\n" +
+		"\tpath := strings.TrimPrefix(os.Getenv(\"DATABASE_URL\"), \"file:\")\n" +
+		"\tif err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {\n\t\treturn nil, err\n\t}\n" +
+		"\t// Foreign keys are off in SQLite unless asked for; the busy timeout lets\n" +
+		"\t// concurrent writers wait for the lock instead of failing.\n" +
+		"\tdsn := path + \"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)\"\n" +
+		"\tconn, err := " + open + "\n" +
+		"\tif err != nil {\n\t\treturn nil, err\n\t}\n" +
+		"\tif " + exec + "; err != nil {\n\t\treturn nil, err\n\t}\n" +
+		"\t" + done + "\n}\n")
+	return b.String()
+}
 
-	for _, spec := range specs {
-		data := make(map[string]any, len(baseData)+1)
-		for k, v := range baseData {
-			data[k] = v
-		}
-		data["Model"] = templModel
+// goStore names what the generated repositories persist to: "gorm" with the
+// ORM on a SQL database, "sql" (database/sql) without it, and "memory" when
+// there is no SQL database to talk to.
+func goStore(req *GenerateRequest) string {
+	switch {
+	case isSQLDB(req.Database) && req.UseORM:
+		return "gorm"
+	case isSQLDB(req.Database):
+		return "sql"
+	}
+	return "memory"
+}
 
-		body, err := ctx.Registry.Render(spec.Template, data)
-		if err != nil {
-			return err
-		}
-		addFile(ctx.FileTree, prefix+spec.Output, body)
-	}
-	return nil
+type goTemplateField struct {
+	Name     string
+	Type     string
+	JSONName string
+	GormTag  string
 }
 
-func (g *GoGenerator) renderGoOtherDynamicModel(ctx *GenerationContext, baseData map[string]any, model DataModel, arch, root string) error {
-	prefix := root
-	if prefix != "" {
-		prefix += "/"
-	}
-	modelNameLower := strings.ToLower(model.Name)
-	var specs []templateSpec
+type goTemplateRoute struct {
+	Handler     string // exported handler name, from the operation id
+	OperationID string
+	Method      string
+	Path        string // :param form shared by gin and fiber
+	Action      string
+	Param       string // path parameter holding the id on item routes
+	Permission  string // resource:action the route requires under RBAC
+}
 
-	switch arch {
-	case "hexagonal":
-		specs = []templateSpec{
-			{Template: "go/hexagonal/internal/core/ports/dynamic_port.tmpl", Output: "internal/core/ports/" + modelNameLower + "_port.go"},
-			{Template: "go/hexagonal/internal/core/services/dynamic_service.tmpl", Output: "internal/core/services/" + modelNameLower + "_service.go"},
-			{Template: "go/hexagonal/internal/adapters/primary/http/dynamic_handler.tmpl", Output: "internal/adapters/primary/http/" + modelNameLower + "_handler.go"},
-			{Template: "go/hexagonal/internal/adapters/secondary/database/dynamic_adapter.tmpl", Output: "internal/adapters/secondary/database/" + modelNameLower + "_adapter.go"},
-		}
-	case "modular-monolith":
-		specs = []templateSpec{
-			{Template: "go/modular/internal/modules/dynamic/http.tmpl", Output: "internal/modules/" + modelNameLower + "/http.go"},
-			{Template: "go/modular/internal/modules/dynamic/service.tmpl", Output: "internal/modules/" + modelNameLower + "/service.go"},
-			{Template: "go/modular/internal/modules/dynamic/repository.tmpl", Output: "internal/modules/" + modelNameLower + "/repository.go"},
-		}
-	default:
-		specs = []templateSpec{
-			{Template: "go/mvp/internal/handlers/dynamic_handler.tmpl", Output: "internal/handlers/" + modelNameLower + "_handler.go"},
-		}
-	}
+type goTemplateModel struct {
+	Name      string
+	Lower     string
+	Table     string
+	Fields    []goTemplateField
+	Routes    []goTemplateRoute
+	SQL       crudStatements // database/sql statements, in the driver's bind style
+	HasList   bool           // some route lists the model
+	HasBody   bool           // some route binds the model from the request body
+	ParsesID  bool           // some route reads an id from its path
+	HasCustom bool           // some route is a not-implemented stub
+}
 
-	type goTemplateField struct {
-		Name     string
-		Type     string
-		JSONName string
-	}
-	type goTemplateModel struct {
-		Name   string
-		Fields []goTemplateField
-	}
-	templModel := goTemplateModel{Name: model.Name, Fields: make([]goTemplateField, 0, len(model.Fields))}
-	for _, field := range model.Fields {
-		if strings.EqualFold(field.Name, "id") {
-			continue
-		}
+// newGoTemplateModel flattens a model for the Go templates: declared fields
+// plus belongs_to foreign keys, without the id every template declares itself,
+// the routes its handlers serve and the statements the database/sql store runs.
+func newGoTemplateModel(model DataModel, db string) goTemplateModel {
+	bind := questionBind
+	if db == "postgresql" {
+		bind = dollarBind
+	}
+	templModel := goTemplateModel{
+		Name:   model.Name,
+		Lower:  strings.ToLower(model.Name),
+		Table:  modelTable(model),
+		Fields: make([]goTemplateField, 0, len(model.Fields)),
+		SQL:    newCRUDStatements(model, db, bind),
+	}
+	for _, col := range storedColumns(model) {
 		templModel.Fields = append(templModel.Fields, goTemplateField{
-			Name:     toPascal(field.Name),
-			Type:     goType(field.Type),
-			JSONName: strings.ToLower(field.Name),
+			Name:     col.GoName,
+			Type:     goType(col.Type),
+			JSONName: col.Name,
+			GormTag:  gormColumnTag(model, col),
 		})
 	}
+	for _, r := range modelRoutes(model) {
+		templModel.Routes = append(templModel.Routes, goTemplateRoute{
+			Handler:     goHandlerName(r.OperationID),
+			OperationID: r.OperationID,
+			Method:      r.Method,
+			Path:        colonPath(r.Path),
+			Action:      r.Action,
+			Param:       r.Param,
+			Permission:  routePermission(model, r),
+		})
+		templModel.HasList = templModel.HasList || r.Action == ActionList
+		templModel.HasBody = templModel.HasBody || r.Action == ActionCreate || r.Action == ActionUpdate
+		templModel.ParsesID = templModel.ParsesID || itemRoute(r.Action)
+		templModel.HasCustom = templModel.HasCustom || r.Action == ActionCustom
+	}
+	return templModel
+}
 
-	for _, spec := range specs {
+// renderGoDynamicModels renders the manifest's per-model templates for every
+// resolved model. A failing template is reported against its output path so
+// the rest of the project still generates.
+func (g *GoGenerator) renderGoDynamicModels(ctx *GenerationContext, req *GenerateRequest, baseData map[string]any, root string) error {
+	manifest, err := ctx.Registry.Manifest(req.Language, req.Architecture)
+	if err != nil {
+		return err
+	}
+	for _, model := range resolvedModels(req.Custom.Models) {
 		data := make(map[string]any, len(baseData)+1)
 		for k, v := range baseData {
 			data[k] = v
 		}
-		data["Model"] = templModel
-
-		body, err := ctx.Registry.Render(spec.Template, data)
-		if err == nil {
-			addFile(ctx.FileTree, prefix+spec.Output, body)
+		data["Model"] = newGoTemplateModel(model, req.Database)
+
+		for _, spec := range manifest.modelSpecs(*req, model) {
+			out := path.Join(root, spec.Output)
+			body, err := ctx.Registry.Render(spec.Template, data)
+			if err != nil {
+				ctx.AddWarning(Warning{
+					Code:     "TEMPLATE_RENDER_FAILED",
+					Severity: "error",
+					Message:  "Failed to render dynamic model template for " + model.Name,
+					Reason:   err.Error(),
+					Path:     out,
+				})
+				continue
+			}
+			addFile(ctx.FileTree, out, body)
 		}
 	}
 	return nil
@@ -468,72 +557,6 @@ func (g *GoGenerator) GetConfigWarnings(_ *GenerateRequest) []Warning {
 	return nil
 }
 
-// -------------------------------------------------------------------------
-// Go Template Spec Builders
-// -------------------------------------------------------------------------
-
-func goMonolithTemplateSpecs(req GenerateRequest) []templateSpec {
-	arch := archTemplateName(req.Architecture)
-	withCRUD := isEnabled(req.FileToggles.ExampleCRUD)
-
-	switch req.Architecture {
-	case "clean":
-		base := []templateSpec{
-			{Template: "go/clean/cmd/server/main.tmpl", Output: "cmd/server/main.go"},
-		}
-		if withCRUD {
-			return base
-		}
-		return append(base,
-			templateSpec{Template: "go/clean/internal/domain/item.tmpl", Output: "internal/domain/item.go"},
-			templateSpec{Template: "go/clean/internal/usecase/item.tmpl", Output: "internal/usecase/item_usecase.go"},
-			templateSpec{Template: "go/clean/internal/repository/item.tmpl", Output: "internal/repository/item_repository.go"},
-			templateSpec{Template: "go/clean/internal/delivery/http/item.tmpl", Output: "internal/delivery/http/item_handler.go"},
-		)
-	case "hexagonal":
-		base := []templateSpec{
-			{Template: "go/hexagonal/cmd/server/main.tmpl", Output: "cmd/server/main.go"},
-		}
-		if withCRUD {
-			return base
-		}
-		return append(base,
-			templateSpec{Template: "go/hexagonal/internal/core/ports/item_port.tmpl", Output: "internal/core/ports/item_port.go"},
-			templateSpec{Template: "go/hexagonal/internal/core/services/item_service.tmpl", Output: "internal/core/services/item_service.go"},
-			templateSpec{Template: "go/hexagonal/internal/adapters/primary/http/item_handler.tmpl", Output: "internal/adapters/primary/http/item_handler.go"},
-			templateSpec{Template: "go/hexagonal/internal/adapters/secondary/database/item_adapter.tmpl", Output: "internal/adapters/secondary/database/item_adapter.go"},
-		)
-	case "modular-monolith":
-		base := []templateSpec{
-			{Template: "go/modular/cmd/server/main.tmpl", Output: "cmd/server/main.go"},
-		}
-		if withCRUD {
-			return base
-		}
-		return append(base,
-			templateSpec{Template: "go/modular/internal/modules/item/http.tmpl", Output: "internal/modules/item/http.go"},
-			templateSpec{Template: "go/modular/internal/modules/item/service.tmpl", Output: "internal/modules/item/service.go"},
-			templateSpec{Template: "go/modular/internal/modules/item/repository.tmpl", Output: "internal/modules/item/repository.go"},
-		)
-	default: // mvp
-		base := []templateSpec{
-			{Template: fmt.Sprintf("go/%s/cmd/server/main.tmpl", arch), Output: "cmd/server/main.go"},
-		}
-		if withCRUD {
-			return base
-		}
-		return append(base,
-			templateSpec{Template: fmt.Sprintf("go/%s/internal/handlers/item_handler.tmpl", arch), Output: "internal/handlers/item_handler.go"},
-		)
-	}
-}
-
-func goMicroserviceTemplateSpecs(req GenerateRequest) []templateSpec {
-	return []templateSpec{
-		{Template: "go/microservice/cmd/server/main.tmpl", Output: "cmd/server/main.go"},
-	}
-}
-
 func renderGoSeederScript(module string, models []DataModel, useORM bool) string {
 	resolved := resolvedModels(models)
 	var b strings.Builder
@@ -556,13 +579,13 @@ func renderGoSeederScript(module string, models []DataModel, useORM bool) string
 	return b.String()
 }
 
-func (g *GoGenerator) injectGoRoutes(ctx *GenerationContext, req *GenerateRequest, root, module string) {
-	if !isEnabled(req.FileToggles.ExampleCRUD) {
+func (g *GoGenerator) injectGoRoutes(ctx *GenerationContext, req *GenerateRequest, manifest *TemplateManifest, root, module string) {
+	if !isEnabled(req.FileToggles.ExampleCRUD) && !req.Features.Swagger && !req.Features.JWTAuth && !req.Features.Observability && !usesMessaging(*req) && !req.Infra.Redis {
 		return
 	}
-	mainPath := "cmd/server/main.go"
-	if root != "" {
-		mainPath = path.Join(root, mainPath)
+	mainPath, ok := manifest.routesTarget(ctx, *req, root)
+	if !ok {
+		return
 	}
 	main, ok := ctx.FileTree.Files[mainPath]
 	if !ok {
@@ -570,49 +593,194 @@ func (g *GoGenerator) injectGoRoutes(ctx *GenerationContext, req *GenerateReques
 	}
 
 	var imports, routes strings.Builder
-	for _, model := range resolvedModels(req.Custom.Models) {
-		nameLow := strings.ToLower(model.Name)
-		if req.Architecture == "clean" {
-			imports.WriteString(fmt.Sprintf("\n\t\"%s/internal/delivery/http\"\n\t\"%s/internal/repository\"\n\t\"%s/internal/usecase\"", module, module, module))
-			routes.WriteString(fmt.Sprintf("\n\t_ = http.New%sHandler(usecase.New%sUsecase(repository.New%sRepository()))", model.Name, model.Name, model.Name))
-		} else if req.Architecture == "hexagonal" {
-			imports.WriteString(fmt.Sprintf("\n\thttpPrimary \"%s/internal/adapters/primary/http\"\n\t\"%s/internal/adapters/secondary/database\"\n\t\"%s/internal/core/services\"", module, module, module))
-			routes.WriteString(fmt.Sprintf("\n\t_ = httpPrimary.New%sHandler(services.New%sService(database.New%sAdapter()))", model.Name, model.Name, model.Name))
-		} else if req.Architecture == "modular-monolith" {
-			imports.WriteString(fmt.Sprintf("\n\t\"%s/internal/modules/%s\"", module, nameLow))
-			routes.WriteString(fmt.Sprintf("\n\t_ = %s.NewService(%s.NewRepository())", nameLow, nameLow))
+	if req.Features.Observability {
+		writeGoTelemetryRoutes(&imports, &routes, req, module)
+	}
+	if usesMessaging(*req) {
+		imports.WriteString(fmt.Sprintf("\n\t\"%s/internal/messaging\"", module))
+	}
+	if req.Infra.Kafka {
+		routes.WriteString("\n\tstopKafka, err := messaging.StartKafka(messaging.LogEvent)\n\tif err != nil {\n\t\tfmt.Printf(\"kafka error: %v\\n\", err)\n\t\tos.Exit(1)\n\t}\n\tdefer stopKafka()")
+	}
+	if req.Infra.NATS {
+		routes.WriteString("\n\tstopNATS, err := messaging.StartNATS(messaging.LogEvent)\n\tif err != nil {\n\t\tfmt.Printf(\"nats error: %v\\n\", err)\n\t\tos.Exit(1)\n\t}\n\tdefer stopNATS()")
+	}
+	if req.Infra.RabbitMQ {
+		routes.WriteString("\n\tstopRabbitMQ, err := messaging.StartRabbitMQ(messaging.LogEvent)\n\tif err != nil {\n\t\tfmt.Printf(\"rabbitmq error: %v\\n\", err)\n\t\tos.Exit(1)\n\t}\n\tdefer stopRabbitMQ()")
+	}
+	if req.Infra.Redis {
+		writeGoCacheRoutes(&imports, &routes, req, module)
+	}
+	if req.Features.Swagger {
+		imports.WriteString(fmt.Sprintf("\n\t\"%s/docs\"", module))
+		if req.Framework == "gin" {
+			routes.WriteString("\n\tr.GET(\"/docs\", func(c *gin.Context) { c.Data(200, \"text/html; charset=utf-8\", []byte(docs.Page)) })")
+			routes.WriteString("\n\tr.GET(\"/docs/openapi.yaml\", func(c *gin.Context) { c.Data(200, \"application/yaml\", docs.Spec) })")
 		} else {
-			imports.WriteString(fmt.Sprintf("\n\t\"%s/internal/handlers\"", module))
-			if req.Framework == "gin" {
-				routes.WriteString(fmt.Sprintf("\n\tr.GET(\"/%ss\", handlers.List%ss)", nameLow, model.Name))
-				routes.WriteString(fmt.Sprintf("\n\tr.POST(\"/%ss\", handlers.Create%s)", nameLow, model.Name))
-			} else {
-				routes.WriteString(fmt.Sprintf("\n\tapp.Get(\"/%ss\", handlers.List%ss)", nameLow, model.Name))
-				routes.WriteString(fmt.Sprintf("\n\tapp.Post(\"/%ss\", handlers.Create%s)", nameLow, model.Name))
-			}
+			routes.WriteString("\n\tapp.Get(\"/docs\", func(c *fiber.Ctx) error { c.Type(\"html\"); return c.SendString(docs.Page) })")
+			routes.WriteString("\n\tapp.Get(\"/docs/openapi.yaml\", func(c *fiber.Ctx) error { c.Set(fiber.HeaderContentType, \"application/yaml\"); return c.Send(docs.Spec) })")
 		}
 	}
+	models := resolvedModels(req.Custom.Models)
+	if !isEnabled(req.FileToggles.ExampleCRUD) {
+		models = nil
+	}
+	conn := ""
+	if goStore(req) != "memory" && (len(models) > 0 || req.Features.JWTAuth) {
+		conn = "conn"
+		imports.WriteString(fmt.Sprintf("\n\t\"%s/internal/db\"", module))
+		routes.WriteString("\n\tconn, err := db.Connect()\n\tif err != nil {\n\t\tfmt.Printf(\"database error: %v\\n\", err)\n\t\tos.Exit(1)\n\t}")
+	}
+	if req.Features.JWTAuth {
+		writeGoAuthRoutes(&imports, &routes, req, module, conn, len(models) > 0)
+	}
+	if len(models) > 0 {
+		g.writeGoModelRoutes(&imports, &routes, req, module, models, conn)
+	}
 
 	var err error
 	main, err = InjectByMarker(main, "imports", imports.String())
 	if err != nil {
-		ctx.Warnings = append(ctx.Warnings, Warning{
+		ctx.AddWarning(Warning{
 			Code:     "INJECTION_MARKER_MISSING",
 			Severity: "error", // Use error severity to highlight injection breakage to the user
 			Message:  "Failed to inject dynamic imports",
 			Reason:   err.Error(),
+			Path:     mainPath,
 		})
 	}
 
 	main, err = InjectByMarker(main, "routes", routes.String())
 	if err != nil {
-		ctx.Warnings = append(ctx.Warnings, Warning{
+		ctx.AddWarning(Warning{
 			Code:     "INJECTION_MARKER_MISSING",
 			Severity: "error",
 			Message:  "Failed to inject dynamic routes",
 			Reason:   err.Error(),
+			Path:     mainPath,
 		})
 	}
 
 	ctx.FileTree.Files[mainPath] = main
 }
+
+// writeGoTelemetryRoutes starts the tracer before anything else in main and
+// mounts the request ID and telemetry middleware ahead of every route
+// injected after it, plus /metrics.
+func writeGoTelemetryRoutes(imports, routes *strings.Builder, req *GenerateRequest, module string) {
+	imports.WriteString(fmt.Sprintf("\n\t\"%s/internal/middleware\"\n\t\"%s/internal/telemetry\"", module, module))
+	routes.WriteString("\n\tshutdownTelemetry, err := telemetry.Setup()\n\tif err != nil {\n\t\tfmt.Printf(\"telemetry error: %v\\n\", err)\n\t\tos.Exit(1)\n\t}\n\tdefer shutdownTelemetry()")
+	if req.Framework == "gin" {
+		routes.WriteString("\n\tr.Use(middleware.RequestID(), telemetry.Middleware())\n\tr.GET(\"/metrics\", telemetry.MetricsHandler())")
+	} else {
+		routes.WriteString("\n\tapp.Use(middleware.RequestID(), telemetry.Middleware())\n\tapp.Get(\"/metrics\", telemetry.MetricsHandler())")
+	}
+}
+
+// writeGoCacheRoutes connects to Redis as redisCache, which the model
+// repositories read through, and mounts its /health/redis probe.
+func writeGoCacheRoutes(imports, routes *strings.Builder, req *GenerateRequest, module string) {
+	imports.WriteString(fmt.Sprintf("\n\t\"%s/internal/cache\"", module))
+	routes.WriteString("\n\tredisCache, err := cache.Connect()\n\tif err != nil {\n\t\tfmt.Printf(\"redis error: %v\\n\", err)\n\t\tos.Exit(1)\n\t}\n\tdefer redisCache.Close()")
+	if req.Framework == "gin" {
+		routes.WriteString("\n\tr.GET(\"/health/redis\", redisCache.Health())")
+	} else {
+		routes.WriteString("\n\tapp.Get(\"/health/redis\", redisCache.Health())")
+	}
+}
+
+// writeGoAuthRoutes mounts the public /auth endpoints on an account store
+// backed by conn, or kept in memory when conn is empty. With protect it also
+// declares the requireAuth middleware the model routes are mounted behind.
+func writeGoAuthRoutes(imports, routes *strings.Builder, req *GenerateRequest, module, conn string, protect bool) {
+	imports.WriteString(fmt.Sprintf("\n\t\"%s/internal/auth\"", module))
+	if conn == "" {
+		routes.WriteString("\n\tauthHandler := auth.NewHandler(auth.NewStore())")
+	} else {
+		routes.WriteString("\n\tauthStore, err := auth.NewStore(" + conn + ")\n\tif err != nil {\n\t\tfmt.Printf(\"auth store error: %v\\n\", err)\n\t\tos.Exit(1)\n\t}")
+		routes.WriteString("\n\tauthHandler := auth.NewHandler(authStore)")
+	}
+	for _, ep := range []string{"Register", "Login", "Refresh"} {
+		if req.Framework == "gin" {
+			routes.WriteString(fmt.Sprintf("\n\tr.POST(\"/auth/%s\", authHandler.%s)", strings.ToLower(ep), ep))
+		} else {
+			routes.WriteString(fmt.Sprintf("\n\tapp.Post(\"/auth/%s\", authHandler.%s)", strings.ToLower(ep), ep))
+		}
+	}
+	if protect {
+		routes.WriteString("\n\trequireAuth := auth.Middleware()")
+	}
+}
+
+// writeGoModelRoutes wires every model's handlers into main: it builds each
+// model's layers for the architecture on conn, the open database or "" for
+// in-memory stores, with its repository read through redisCache when the
+// models are cached, and mounts one route per operation, behind requireAuth
+// when JWT auth is on and auth.Require(<permission>) when RBAC is too.
+func (g *GoGenerator) writeGoModelRoutes(imports, routes *strings.Builder, req *GenerateRequest, module string, models []DataModel, conn string) {
+	cached := cachesModels(*req)
+	switch req.Architecture {
+	case "clean":
+		imports.WriteString(fmt.Sprintf("\n\tdelivery \"%s/internal/delivery/http\"\n\t\"%s/internal/repository\"\n\t\"%s/internal/usecase\"", module, module, module))
+		if cached {
+			imports.WriteString(fmt.Sprintf("\n\t\"%s/internal/domain\"", module))
+		}
+	case "hexagonal":
+		imports.WriteString(fmt.Sprintf("\n\thttpPrimary \"%s/internal/adapters/primary/http\"\n\t\"%s/internal/adapters/secondary/database\"\n\t\"%s/internal/core/services\"", module, module, module))
+		if cached {
+			imports.WriteString(fmt.Sprintf("\n\t\"%s/internal/core/ports\"", module))
+		}
+	case "modular-monolith":
+		for _, model := range models {
+			imports.WriteString(fmt.Sprintf("\n\t\"%s/internal/modules/%s\"", module, strings.ToLower(model.Name)))
+		}
+	default:
+		imports.WriteString(fmt.Sprintf("\n\t\"%s/internal/handlers\"", module))
+		if conn != "" {
+			routes.WriteString("\n\thandlers.DB = conn")
+		}
+		if cached {
+			routes.WriteString("\n\thandlers.Cache = redisCache")
+		}
+	}
+
+	for _, model := range models {
+		nameLow := strings.ToLower(model.Name)
+		handler := "handlers."
+		// through wraps a model's repository in the read-through cache.
+		through := func(entity, repo string) string {
+			if !cached {
+				return repo
+			}
+			return fmt.Sprintf("cache.Wrap[%s](%s, redisCache, %q)", entity, repo, nameLow)
+		}
+		switch req.Architecture {
+		case "clean":
+			repo := through("domain."+model.Name, fmt.Sprintf("repository.New%sRepository(%s)", model.Name, conn))
+			routes.WriteString(fmt.Sprintf("\n\t%sHandler := delivery.New%sHandler(usecase.New%sUsecase(%s))", nameLow, model.Name, model.Name, repo))
+			handler = nameLow + "Handler."
+		case "hexagonal":
+			repo := through("ports."+model.Name, fmt.Sprintf("database.New%sAdapter(%s)", model.Name, conn))
+			routes.WriteString(fmt.Sprintf("\n\t%sHandler := httpPrimary.New%sHandler(services.New%sService(%s))", nameLow, model.Name, model.Name, repo))
+			handler = nameLow + "Handler."
+		case "modular-monolith":
+			repo := through(nameLow+"."+model.Name, fmt.Sprintf("%s.NewRepository(%s)", nameLow, conn))
+			routes.WriteString(fmt.Sprintf("\n\t%sHandler := %s.NewHandler(%s.NewService(%s))", nameLow, nameLow, nameLow, repo))
+			handler = nameLow + "Handler."
+		}
+		if req.Features.JWTAuth {
+			handler = "requireAuth, " + handler
+		}
+		for _, r := range newGoTemplateModel(model, req.Database).Routes {
+			guard := handler
+			if req.Features.RBAC {
+				guard = fmt.Sprintf("requireAuth, auth.Require(%q), %s", r.Permission, strings.TrimPrefix(handler, "requireAuth, "))
+			}
+			if req.Framework == "gin" {
+				routes.WriteString(fmt.Sprintf("\n\tr.%s(%q, %s%s)", r.Method, r.Path, guard, r.Handler))
+			} else {
+				routes.WriteString(fmt.Sprintf("\n\tapp.%s(%q, %s%s)", toPascal(r.Method), r.Path, guard, r.Handler))
+			}
+		}
+	}
+}
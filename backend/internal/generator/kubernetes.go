@@ -0,0 +1,821 @@
+package generator
+
+// kubernetes.go — Kubernetes manifests and Helm chart generation.
+//
+// Like shared_infra.go, this file is language-agnostic: it must never look at
+// req.Language or req.Framework. Every manifest is produced by populating the
+// typed model below and marshaling it via yaml.v3.
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// =========================================================================
+// Kubernetes object model
+// =========================================================================
+
+// K8sMetadata is the metadata block shared by every object.
+type K8sMetadata struct {
+	Name   string            `yaml:"name,omitempty"`
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+// K8sDeployment is an apps/v1 Deployment running one container.
+type K8sDeployment struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   K8sMetadata       `yaml:"metadata"`
+	Spec       K8sDeploymentSpec `yaml:"spec"`
+}
+
+type K8sDeploymentSpec struct {
+	Replicas int            `yaml:"replicas"`
+	Selector K8sSelector    `yaml:"selector"`
+	Template K8sPodTemplate `yaml:"template"`
+}
+
+type K8sSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type K8sPodTemplate struct {
+	Metadata K8sMetadata `yaml:"metadata"`
+	Spec     K8sPodSpec  `yaml:"spec"`
+}
+
+type K8sPodSpec struct {
+	Containers []K8sContainer `yaml:"containers"`
+}
+
+// K8sContainer reads its environment from the ConfigMap and Secret named
+// after its workload.
+type K8sContainer struct {
+	Name            string             `yaml:"name"`
+	Image           string             `yaml:"image"`
+	ImagePullPolicy string             `yaml:"imagePullPolicy,omitempty"`
+	Args            []string           `yaml:"args,omitempty"`
+	Ports           []K8sContainerPort `yaml:"ports,omitempty"`
+	EnvFrom         []K8sEnvFrom       `yaml:"envFrom,omitempty"`
+	ReadinessProbe  *K8sProbe          `yaml:"readinessProbe,omitempty"`
+	Resources       *K8sResources      `yaml:"resources,omitempty"`
+}
+
+type K8sContainerPort struct {
+	ContainerPort int `yaml:"containerPort"`
+}
+
+type K8sEnvFrom struct {
+	ConfigMapRef *K8sObjectRef `yaml:"configMapRef,omitempty"`
+	SecretRef    *K8sObjectRef `yaml:"secretRef,omitempty"`
+}
+
+type K8sObjectRef struct {
+	Name string `yaml:"name"`
+}
+
+// K8sProbe is an exec probe, translated from a compose healthcheck.
+type K8sProbe struct {
+	Exec             K8sExecAction `yaml:"exec"`
+	PeriodSeconds    int           `yaml:"periodSeconds,omitempty"`
+	TimeoutSeconds   int           `yaml:"timeoutSeconds,omitempty"`
+	FailureThreshold int           `yaml:"failureThreshold,omitempty"`
+}
+
+type K8sExecAction struct {
+	Command []string `yaml:"command"`
+}
+
+type K8sResources struct {
+	Requests map[string]string `yaml:"requests,omitempty"`
+	Limits   map[string]string `yaml:"limits,omitempty"`
+}
+
+// K8sService is a ClusterIP Service in front of a Deployment.
+type K8sService struct {
+	APIVersion string         `yaml:"apiVersion"`
+	Kind       string         `yaml:"kind"`
+	Metadata   K8sMetadata    `yaml:"metadata"`
+	Spec       K8sServiceSpec `yaml:"spec"`
+}
+
+type K8sServiceSpec struct {
+	Selector map[string]string `yaml:"selector"`
+	Ports    []K8sServicePort  `yaml:"ports"`
+}
+
+type K8sServicePort struct {
+	Name       string `yaml:"name"`
+	Port       int    `yaml:"port"`
+	TargetPort int    `yaml:"targetPort"`
+}
+
+// K8sConfigMap holds a workload's non-secret environment.
+type K8sConfigMap struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   K8sMetadata       `yaml:"metadata"`
+	Data       map[string]string `yaml:"data"`
+}
+
+// K8sSecret holds a workload's credentials, unencoded under stringData.
+type K8sSecret struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   K8sMetadata       `yaml:"metadata"`
+	Type       string            `yaml:"type"`
+	StringData map[string]string `yaml:"stringData"`
+}
+
+// K8sIngress routes one host to an app Service.
+type K8sIngress struct {
+	APIVersion string         `yaml:"apiVersion"`
+	Kind       string         `yaml:"kind"`
+	Metadata   K8sMetadata    `yaml:"metadata"`
+	Spec       K8sIngressSpec `yaml:"spec"`
+}
+
+type K8sIngressSpec struct {
+	Rules []K8sIngressRule `yaml:"rules"`
+}
+
+type K8sIngressRule struct {
+	Host string         `yaml:"host"`
+	HTTP K8sIngressHTTP `yaml:"http"`
+}
+
+type K8sIngressHTTP struct {
+	Paths []K8sIngressPath `yaml:"paths"`
+}
+
+type K8sIngressPath struct {
+	Path     string            `yaml:"path"`
+	PathType string            `yaml:"pathType"`
+	Backend  K8sIngressBackend `yaml:"backend"`
+}
+
+type K8sIngressBackend struct {
+	Service K8sIngressServiceBackend `yaml:"service"`
+}
+
+type K8sIngressServiceBackend struct {
+	Name string                `yaml:"name"`
+	Port K8sServiceBackendPort `yaml:"port"`
+}
+
+type K8sServiceBackendPort struct {
+	Number int `yaml:"number"`
+}
+
+// K8sHPA is an autoscaling/v2 HorizontalPodAutoscaler scaling on CPU.
+type K8sHPA struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   K8sMetadata `yaml:"metadata"`
+	Spec       K8sHPASpec  `yaml:"spec"`
+}
+
+type K8sHPASpec struct {
+	ScaleTargetRef K8sScaleTargetRef `yaml:"scaleTargetRef"`
+	MinReplicas    int               `yaml:"minReplicas"`
+	MaxReplicas    int               `yaml:"maxReplicas"`
+	Metrics        []K8sMetric       `yaml:"metrics"`
+}
+
+type K8sScaleTargetRef struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Name       string `yaml:"name"`
+}
+
+type K8sMetric struct {
+	Type     string            `yaml:"type"`
+	Resource K8sMetricResource `yaml:"resource"`
+}
+
+type K8sMetricResource struct {
+	Name   string          `yaml:"name"`
+	Target K8sMetricTarget `yaml:"target"`
+}
+
+type K8sMetricTarget struct {
+	Type               string `yaml:"type"`
+	AverageUtilization int    `yaml:"averageUtilization"`
+}
+
+// K8sKustomization lists the manifests so `kubectl apply -k k8s` applies them all.
+type K8sKustomization struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Resources  []string `yaml:"resources"`
+}
+
+// KubernetesSpec is the full set of manifests, one component per app service
+// or infra dependency, in the order they are written.
+type KubernetesSpec struct {
+	Components []K8sComponent
+}
+
+// K8sComponent is every object one workload needs. ConfigMap and Secret are
+// nil when it has no such environment; only app services get an Ingress and
+// an HPA.
+type K8sComponent struct {
+	Name       string
+	Deployment K8sDeployment
+	Service    K8sService
+	ConfigMap  *K8sConfigMap
+	Secret     *K8sSecret
+	Ingress    *K8sIngress
+	HPA        *K8sHPA
+}
+
+// =========================================================================
+// Workloads — the shared source of the manifests and the Helm values
+// =========================================================================
+
+const (
+	k8sMinReplicas   = 1
+	k8sMaxReplicas   = 5
+	k8sCPUTarget     = 80
+	k8sAppPullPolicy = "IfNotPresent"
+)
+
+// k8sEnvOverrides replaces compose environment values that only hold inside
+// the compose network. A single-node Kafka must reach its own controller
+// through localhost, since the kafka Service only exposes the broker port.
+var k8sEnvOverrides = map[string]map[string]string{
+	"kafka": {"KAFKA_CFG_CONTROLLER_QUORUM_VOTERS": "1@localhost:9093"},
+}
+
+// k8sWorkload is one Deployment and what surrounds it.
+type k8sWorkload struct {
+	Name      string
+	Image     string
+	Tag       string
+	Port      int
+	Args      []string  // the compose command, passed to the image entrypoint
+	Env       []envPair // ConfigMap entries
+	SecretEnv []envPair // Secret entries
+	Probe     *K8sProbe
+	App       bool // app services get resources, an Ingress and an HPA
+}
+
+// k8sWorkloads lists the app services, their environment taken from
+// buildEnv, then the infra dependencies as docker-compose runs them.
+func k8sWorkloads(req GenerateRequest) []k8sWorkload {
+	var workloads []k8sWorkload
+	app := func(name, service string, port int) {
+		w := k8sWorkload{Name: k8sName(name), Image: k8sName(name), Tag: "latest", Port: port, App: true}
+		w.Env, w.SecretEnv = splitSecretEnv(envPairs(req, service, port))
+		workloads = append(workloads, w)
+	}
+	if req.Architecture == "microservices" {
+		for _, svc := range req.Services {
+			app(svc.Name, svc.Name, svc.Port)
+		}
+	} else {
+		app("app", "", 8080)
+	}
+
+	compose := newComposeSpec(req)
+	for _, name := range sortedKeys(compose.Services) {
+		svc := compose.Services[name]
+		if svc.Build != nil || observabilityServices[name] {
+			continue
+		}
+		image, tag, _ := strings.Cut(svc.Image, ":")
+		w := k8sWorkload{Name: name, Image: image, Tag: tag, Args: strings.Fields(svc.Command), Probe: k8sProbe(svc.Healthcheck)}
+		if len(svc.Ports) > 0 {
+			_, port, _ := strings.Cut(svc.Ports[0], ":")
+			w.Port, _ = strconv.Atoi(port)
+		}
+		var env []envPair
+		if svc.Environment != nil {
+			for _, p := range svc.Environment.pairs {
+				if v, ok := k8sEnvOverrides[name][p.k]; ok {
+					p.v = v
+				}
+				env = append(env, p)
+			}
+		}
+		w.Env, w.SecretEnv = splitSecretEnv(env)
+		workloads = append(workloads, w)
+	}
+	return workloads
+}
+
+// splitSecretEnv moves credentials (passwords, secrets and the database and
+// AMQP URLs, which embed the password) out of the ConfigMap entries.
+func splitSecretEnv(pairs []envPair) (plain, secret []envPair) {
+	for _, p := range pairs {
+		if p.k == "DATABASE_URL" || strings.HasSuffix(p.k, "AMQP_URL") || strings.Contains(p.k, "PASS") || strings.Contains(p.k, "SECRET") {
+			secret = append(secret, p)
+		} else {
+			plain = append(plain, p)
+		}
+	}
+	return plain, secret
+}
+
+// k8sProbe translates a compose healthcheck into an exec readiness probe.
+func k8sProbe(hc *ComposeHealthcheck) *K8sProbe {
+	if hc == nil || len(hc.Test) < 2 {
+		return nil
+	}
+	command := hc.Test[1:]
+	if hc.Test[0] == "CMD-SHELL" {
+		command = []string{"sh", "-c", strings.Join(hc.Test[1:], " ")}
+	}
+	return &K8sProbe{
+		Exec:             K8sExecAction{Command: command},
+		PeriodSeconds:    durationSeconds(hc.Interval),
+		TimeoutSeconds:   durationSeconds(hc.Timeout),
+		FailureThreshold: hc.Retries,
+	}
+}
+
+func durationSeconds(s string) int {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0
+	}
+	return int(d.Seconds())
+}
+
+// k8sName lowercases a service name and replaces underscores, since object
+// names must be DNS labels.
+func k8sName(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), "_", "-")
+}
+
+// k8sPartOf is the project name the objects are labelled with and the Helm
+// chart is named after.
+func k8sPartOf(req GenerateRequest) string {
+	name := k8sName(path.Base(strings.TrimSpace(req.Root.Name)))
+	if name == "" || name == "." || name == "/" {
+		return "app"
+	}
+	return name
+}
+
+func appResources() *K8sResources {
+	return &K8sResources{
+		Requests: map[string]string{"cpu": "100m", "memory": "128Mi"},
+		Limits:   map[string]string{"cpu": "500m", "memory": "512Mi"},
+	}
+}
+
+func envMap(pairs []envPair) map[string]string {
+	m := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		m[p.k] = p.v
+	}
+	return m
+}
+
+// =========================================================================
+// KubernetesSpec builder
+// =========================================================================
+
+// newKubernetesSpec populates a KubernetesSpec from the generate request.
+func newKubernetesSpec(req GenerateRequest) KubernetesSpec {
+	partOf := k8sPartOf(req)
+	var spec KubernetesSpec
+	for _, w := range k8sWorkloads(req) {
+		selector := map[string]string{"app.kubernetes.io/name": w.Name}
+		meta := K8sMetadata{Name: w.Name, Labels: map[string]string{"app.kubernetes.io/name": w.Name, "app.kubernetes.io/part-of": partOf}}
+		container := K8sContainer{
+			Name:           w.Name,
+			Image:          w.Image + ":" + w.Tag,
+			Args:           w.Args,
+			Ports:          []K8sContainerPort{{ContainerPort: w.Port}},
+			ReadinessProbe: w.Probe,
+		}
+		c := K8sComponent{Name: w.Name}
+		if len(w.Env) > 0 {
+			c.ConfigMap = &K8sConfigMap{APIVersion: "v1", Kind: "ConfigMap", Metadata: meta, Data: envMap(w.Env)}
+			container.EnvFrom = append(container.EnvFrom, K8sEnvFrom{ConfigMapRef: &K8sObjectRef{Name: w.Name}})
+		}
+		if len(w.SecretEnv) > 0 {
+			c.Secret = &K8sSecret{APIVersion: "v1", Kind: "Secret", Metadata: meta, Type: "Opaque", StringData: envMap(w.SecretEnv)}
+			container.EnvFrom = append(container.EnvFrom, K8sEnvFrom{SecretRef: &K8sObjectRef{Name: w.Name}})
+		}
+		if w.App {
+			container.ImagePullPolicy = k8sAppPullPolicy
+			container.Resources = appResources()
+			c.Ingress = &K8sIngress{
+				APIVersion: "networking.k8s.io/v1",
+				Kind:       "Ingress",
+				Metadata:   meta,
+				Spec: K8sIngressSpec{Rules: []K8sIngressRule{{
+					Host: w.Name + ".local",
+					HTTP: K8sIngressHTTP{Paths: []K8sIngressPath{{
+						Path:     "/",
+						PathType: "Prefix",
+						Backend:  K8sIngressBackend{Service: K8sIngressServiceBackend{Name: w.Name, Port: K8sServiceBackendPort{Number: w.Port}}},
+					}}},
+				}}},
+			}
+			c.HPA = &K8sHPA{
+				APIVersion: "autoscaling/v2",
+				Kind:       "HorizontalPodAutoscaler",
+				Metadata:   meta,
+				Spec: K8sHPASpec{
+					ScaleTargetRef: K8sScaleTargetRef{APIVersion: "apps/v1", Kind: "Deployment", Name: w.Name},
+					MinReplicas:    k8sMinReplicas,
+					MaxReplicas:    k8sMaxReplicas,
+					Metrics: []K8sMetric{{
+						Type:     "Resource",
+						Resource: K8sMetricResource{Name: "cpu", Target: K8sMetricTarget{Type: "Utilization", AverageUtilization: k8sCPUTarget}},
+					}},
+				},
+			}
+		}
+		c.Deployment = K8sDeployment{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Metadata:   meta,
+			Spec: K8sDeploymentSpec{
+				Replicas: 1,
+				Selector: K8sSelector{MatchLabels: selector},
+				Template: K8sPodTemplate{
+					Metadata: K8sMetadata{Labels: selector},
+					Spec:     K8sPodSpec{Containers: []K8sContainer{container}},
+				},
+			},
+		}
+		c.Service = K8sService{
+			APIVersion: "v1",
+			Kind:       "Service",
+			Metadata:   meta,
+			Spec: K8sServiceSpec{
+				Selector: selector,
+				Ports:    []K8sServicePort{{Name: "main", Port: w.Port, TargetPort: w.Port}},
+			},
+		}
+		spec.Components = append(spec.Components, c)
+	}
+	return spec
+}
+
+// documents lists the component's objects in apply order.
+func (c K8sComponent) documents() []any {
+	docs := []any{}
+	if c.ConfigMap != nil {
+		docs = append(docs, c.ConfigMap)
+	}
+	if c.Secret != nil {
+		docs = append(docs, c.Secret)
+	}
+	docs = append(docs, c.Deployment, c.Service)
+	if c.Ingress != nil {
+		docs = append(docs, c.Ingress)
+	}
+	if c.HPA != nil {
+		docs = append(docs, c.HPA)
+	}
+	return docs
+}
+
+// renderYAMLDocuments marshals docs into one YAML stream separated by "---".
+// Struct fields keep their declaration order and yaml.v3 sorts map keys, so
+// the output is deterministic.
+func renderYAMLDocuments(docs ...any) string {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	for _, doc := range docs {
+		if err := enc.Encode(doc); err != nil {
+			return "# kubernetes generation error: " + err.Error() + "\n"
+		}
+	}
+	_ = enc.Close()
+	return buf.String()
+}
+
+// addKubernetesFiles writes k8s/<component>.yaml per workload plus a
+// kustomization and, with features.helm, the Helm chart.
+func addKubernetesFiles(tree *FileTree, req GenerateRequest) {
+	spec := newKubernetesSpec(req)
+	kustomization := K8sKustomization{APIVersion: "kustomize.config.k8s.io/v1beta1", Kind: "Kustomization"}
+	for _, c := range spec.Components {
+		file := c.Name + ".yaml"
+		addFile(tree, path.Join("k8s", file), renderYAMLDocuments(c.documents()...))
+		kustomization.Resources = append(kustomization.Resources, file)
+	}
+	addFile(tree, "k8s/kustomization.yaml", renderYAMLDocuments(kustomization))
+	if req.Features.Helm {
+		addHelmChart(tree, req)
+	}
+}
+
+// applyKubernetesDefaults turns on the plain manifests the Helm chart is
+// documented against.
+func applyKubernetesDefaults(req GenerateRequest) (GenerateRequest, []Decision) {
+	if !req.Features.Helm || req.Features.Kubernetes {
+		return req, nil
+	}
+	req.Features.Kubernetes = true
+	return req, []Decision{{
+		Code:        "KUBERNETES_ENABLED_FOR_HELM",
+		Description: "Enabled Kubernetes manifests since the Helm chart templates the same objects.",
+		TriggeredBy: "ApplyRuleEngine",
+	}}
+}
+
+// =========================================================================
+// Helm chart
+// =========================================================================
+
+// HelmChart is Chart.yaml.
+type HelmChart struct {
+	APIVersion  string `yaml:"apiVersion"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Type        string `yaml:"type"`
+	Version     string `yaml:"version"`
+	AppVersion  string `yaml:"appVersion"`
+}
+
+// HelmValues is values.yaml: one entry per workload, keyed by its name.
+type HelmValues struct {
+	Components map[string]HelmComponent `yaml:"components"`
+}
+
+type HelmComponent struct {
+	Image          HelmImage         `yaml:"image"`
+	Replicas       int               `yaml:"replicas"`
+	Args           []string          `yaml:"args,omitempty"`
+	Port           int               `yaml:"port"`
+	Env            map[string]string `yaml:"env,omitempty"`
+	SecretEnv      map[string]string `yaml:"secretEnv,omitempty"`
+	ReadinessProbe *K8sProbe         `yaml:"readinessProbe,omitempty"`
+	Resources      *K8sResources     `yaml:"resources,omitempty"`
+	Ingress        *HelmIngress      `yaml:"ingress,omitempty"`
+	Autoscaling    *HelmAutoscaling  `yaml:"autoscaling,omitempty"`
+}
+
+type HelmImage struct {
+	Repository string `yaml:"repository"`
+	Tag        string `yaml:"tag"`
+	PullPolicy string `yaml:"pullPolicy,omitempty"`
+}
+
+type HelmIngress struct {
+	Enabled bool   `yaml:"enabled"`
+	Host    string `yaml:"host"`
+}
+
+type HelmAutoscaling struct {
+	Enabled                        bool `yaml:"enabled"`
+	MinReplicas                    int  `yaml:"minReplicas"`
+	MaxReplicas                    int  `yaml:"maxReplicas"`
+	TargetCPUUtilizationPercentage int  `yaml:"targetCPUUtilizationPercentage"`
+}
+
+// newHelmValues derives values.yaml from the same workloads as the plain
+// manifests, so app environments come from buildEnv.
+func newHelmValues(req GenerateRequest) HelmValues {
+	values := HelmValues{Components: map[string]HelmComponent{}}
+	for _, w := range k8sWorkloads(req) {
+		c := HelmComponent{
+			Image:          HelmImage{Repository: w.Image, Tag: w.Tag},
+			Replicas:       1,
+			Args:           w.Args,
+			Port:           w.Port,
+			ReadinessProbe: w.Probe,
+		}
+		if len(w.Env) > 0 {
+			c.Env = envMap(w.Env)
+		}
+		if len(w.SecretEnv) > 0 {
+			c.SecretEnv = envMap(w.SecretEnv)
+		}
+		if w.App {
+			c.Image.PullPolicy = k8sAppPullPolicy
+			c.Resources = appResources()
+			c.Ingress = &HelmIngress{Enabled: true, Host: w.Name + ".local"}
+			c.Autoscaling = &HelmAutoscaling{Enabled: true, MinReplicas: k8sMinReplicas, MaxReplicas: k8sMaxReplicas, TargetCPUUtilizationPercentage: k8sCPUTarget}
+		}
+		values.Components[w.Name] = c
+	}
+	return values
+}
+
+// addHelmChart writes helm/<project>/ with templates that range over
+// .Values.components.
+func addHelmChart(tree *FileTree, req GenerateRequest) {
+	name := k8sPartOf(req)
+	root := path.Join("helm", name)
+	addFile(tree, path.Join(root, "Chart.yaml"), renderYAMLDocuments(HelmChart{
+		APIVersion:  "v2",
+		Name:        name,
+		Description: "Helm chart generated by StackSprint",
+		Type:        "application",
+		Version:     "0.1.0",
+		AppVersion:  "latest",
+	}))
+	addFile(tree, path.Join(root, "values.yaml"), renderYAMLDocuments(newHelmValues(req)))
+	addFile(tree, path.Join(root, "templates/_helpers.tpl"), helmHelpers)
+	addFile(tree, path.Join(root, "templates/configmap.yaml"), helmConfigMap)
+	addFile(tree, path.Join(root, "templates/secret.yaml"), helmSecret)
+	addFile(tree, path.Join(root, "templates/deployment.yaml"), helmDeployment)
+	addFile(tree, path.Join(root, "templates/service.yaml"), helmService)
+	addFile(tree, path.Join(root, "templates/ingress.yaml"), helmIngress)
+	addFile(tree, path.Join(root, "templates/hpa.yaml"), helmHPA)
+}
+
+const helmHelpers = `{{- define "component.labels" -}}
+app.kubernetes.io/name: {{ .name }}
+app.kubernetes.io/instance: {{ .root.Release.Name }}
+app.kubernetes.io/part-of: {{ .root.Chart.Name }}
+{{- end }}
+`
+
+const helmConfigMap = `{{- range $name, $c := .Values.components }}
+{{- with $c.env }}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "component.labels" (dict "name" $name "root" $) | nindent 4 }}
+data:
+  {{- range $k, $v := . }}
+  {{ $k }}: {{ $v | quote }}
+  {{- end }}
+{{- end }}
+{{- end }}
+`
+
+const helmSecret = `{{- range $name, $c := .Values.components }}
+{{- with $c.secretEnv }}
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "component.labels" (dict "name" $name "root" $) | nindent 4 }}
+type: Opaque
+stringData:
+  {{- range $k, $v := . }}
+  {{ $k }}: {{ $v | quote }}
+  {{- end }}
+{{- end }}
+{{- end }}
+`
+
+const helmDeployment = `{{- range $name, $c := .Values.components }}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "component.labels" (dict "name" $name "root" $) | nindent 4 }}
+spec:
+  replicas: {{ $c.replicas }}
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: {{ $name }}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: {{ $name }}
+    spec:
+      containers:
+        - name: {{ $name }}
+          image: "{{ $c.image.repository }}:{{ $c.image.tag }}"
+          {{- with $c.image.pullPolicy }}
+          imagePullPolicy: {{ . }}
+          {{- end }}
+          {{- with $c.args }}
+          args:
+            {{- toYaml . | nindent 12 }}
+          {{- end }}
+          ports:
+            - containerPort: {{ $c.port }}
+          {{- if or $c.env $c.secretEnv }}
+          envFrom:
+            {{- if $c.env }}
+            - configMapRef:
+                name: {{ $name }}
+            {{- end }}
+            {{- if $c.secretEnv }}
+            - secretRef:
+                name: {{ $name }}
+            {{- end }}
+          {{- end }}
+          {{- with $c.readinessProbe }}
+          readinessProbe:
+            {{- toYaml . | nindent 12 }}
+          {{- end }}
+          {{- with $c.resources }}
+          resources:
+            {{- toYaml . | nindent 12 }}
+          {{- end }}
+{{- end }}
+`
+
+const helmService = `{{- range $name, $c := .Values.components }}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "component.labels" (dict "name" $name "root" $) | nindent 4 }}
+spec:
+  selector:
+    app.kubernetes.io/name: {{ $name }}
+  ports:
+    - name: main
+      port: {{ $c.port }}
+      targetPort: {{ $c.port }}
+{{- end }}
+`
+
+const helmIngress = `{{- range $name, $c := .Values.components }}
+{{- with $c.ingress }}
+{{- if .enabled }}
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "component.labels" (dict "name" $name "root" $) | nindent 4 }}
+spec:
+  rules:
+    - host: {{ .host }}
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: {{ $name }}
+                port:
+                  number: {{ $c.port }}
+{{- end }}
+{{- end }}
+{{- end }}
+`
+
+const helmHPA = `{{- range $name, $c := .Values.components }}
+{{- with $c.autoscaling }}
+{{- if .enabled }}
+---
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "component.labels" (dict "name" $name "root" $) | nindent 4 }}
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: {{ $name }}
+  minReplicas: {{ .minReplicas }}
+  maxReplicas: {{ .maxReplicas }}
+  metrics:
+    - type: Resource
+      resource:
+        name: cpu
+        target:
+          type: Utilization
+          averageUtilization: {{ .targetCPUUtilizationPercentage }}
+{{- end }}
+{{- end }}
+{{- end }}
+`
+
+// kubernetesReadmeSection tells the generated README how to deploy the
+// manifests and, with features.helm, the chart.
+func kubernetesReadmeSection(req GenerateRequest) string {
+	if !req.Features.Kubernetes {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n## Kubernetes\n\nBuild the app image")
+	if req.Architecture == "microservices" {
+		b.WriteString("s (`docker build -t <service>:latest services/<service>`)")
+	} else {
+		b.WriteString(" (`docker build -t app:latest .`)")
+	}
+	b.WriteString(", then apply every manifest:\n\n```bash\nkubectl apply -k k8s\n```\n\n")
+	b.WriteString("App services are reachable through their Ingress at `<service>.local`. Replace the values in the Secrets before deploying anywhere shared.\n")
+	if req.Features.Helm {
+		fmt.Fprintf(&b, "\nOr install the Helm chart, whose `values.yaml` holds the same settings:\n\n```bash\nhelm install %s helm/%s\n```\n", k8sPartOf(req), k8sPartOf(req))
+	}
+	return b.String()
+}
@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// manifestFile is the name of the per-architecture rendering manifest that
+// lives at templates/<lang>/<arch>/manifest.yaml.
+const manifestFile = "manifest.yaml"
+
+// TemplateManifest declares which templates an architecture renders, where
+// their output goes and under which request conditions. Adding a file to an
+// architecture is a template plus a manifest line, not a Go change.
+type TemplateManifest struct {
+	Dir    string         `yaml:"-"`
+	Files  []ManifestFile `yaml:"files"`
+	Models []ManifestFile `yaml:"models"`
+}
+
+// ManifestFile maps one template to one output path. Model outputs may use the
+// {name}, {lower} and {snake} placeholders; markers names the injection
+// markers (stacksprint:<marker>) the rendered file must keep.
+type ManifestFile struct {
+	Template string            `yaml:"template"`
+	Output   string            `yaml:"output"`
+	When     ManifestCondition `yaml:"when"`
+	Markers  []string          `yaml:"markers"`
+}
+
+// ManifestCondition gates a manifest entry on the request. Unset fields match
+// every request.
+type ManifestCondition struct {
+	Framework     []string `yaml:"framework"`
+	UseDB         *bool    `yaml:"use_db"`
+	UseSQL        *bool    `yaml:"use_sql"`
+	UseORM        *bool    `yaml:"use_orm"`
+	ExampleCRUD   *bool    `yaml:"example_crud"`
+	JWTAuth       *bool    `yaml:"jwt_auth"`
+	RBAC          *bool    `yaml:"rbac"`
+	Observability *bool    `yaml:"observability"`
+	Redis         *bool    `yaml:"redis"`
+	Kafka         *bool    `yaml:"kafka"`
+	NATS          *bool    `yaml:"nats"`
+	RabbitMQ      *bool    `yaml:"rabbitmq"`
+	Messaging     *bool    `yaml:"messaging"` // kafka, nats or rabbitmq
+}
+
+func parseTemplateManifest(dir string, body []byte) (*TemplateManifest, error) {
+	var m TemplateManifest
+	dec := yaml.NewDecoder(strings.NewReader(string(body)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+	m.Dir = dir
+	for i := range m.Files {
+		if err := m.resolve(&m.Files[i]); err != nil {
+			return nil, fmt.Errorf("files[%d]: %w", i, err)
+		}
+	}
+	for i := range m.Models {
+		if err := m.resolve(&m.Models[i]); err != nil {
+			return nil, fmt.Errorf("models[%d]: %w", i, err)
+		}
+	}
+	return &m, nil
+}
+
+// resolve makes the template path registry-relative. Entries may reach into a
+// sibling architecture (../mvp/...) but never outside the template tree.
+func (m *TemplateManifest) resolve(f *ManifestFile) error {
+	if f.Template == "" || f.Output == "" {
+		return fmt.Errorf("template and output are required")
+	}
+	f.Template = path.Join(m.Dir, f.Template)
+	if f.Template == ".." || strings.HasPrefix(f.Template, "../") {
+		return fmt.Errorf("template %q escapes the template tree", f.Template)
+	}
+	if path.IsAbs(f.Output) || strings.HasPrefix(path.Clean(f.Output), "..") {
+		return fmt.Errorf("output %q must stay inside the project", f.Output)
+	}
+	return nil
+}
+
+// matches reports whether the entry applies to req.
+func (c ManifestCondition) matches(req GenerateRequest) bool {
+	if len(c.Framework) > 0 && !slices.Contains(c.Framework, req.Framework) {
+		return false
+	}
+	checks := []struct {
+		want *bool
+		got  bool
+	}{
+		{c.UseDB, req.Database != "none"},
+		{c.UseSQL, isSQLDB(req.Database)},
+		{c.UseORM, req.UseORM},
+		{c.ExampleCRUD, isEnabled(req.FileToggles.ExampleCRUD)},
+		{c.JWTAuth, req.Features.JWTAuth},
+		{c.RBAC, req.Features.RBAC},
+		{c.Observability, req.Features.Observability},
+		{c.Redis, req.Infra.Redis},
+		{c.Kafka, req.Infra.Kafka},
+		{c.NATS, req.Infra.NATS},
+		{c.RabbitMQ, req.Infra.RabbitMQ},
+		{c.Messaging, usesMessaging(req)},
+	}
+	for _, check := range checks {
+		if check.want != nil && *check.want != check.got {
+			return false
+		}
+	}
+	return true
+}
+
+// fileSpecs returns the architecture files that apply to req.
+func (m *TemplateManifest) fileSpecs(req GenerateRequest) []templateSpec {
+	var specs []templateSpec
+	for _, f := range m.Files {
+		if f.When.matches(req) {
+			specs = append(specs, templateSpec{Template: f.Template, Output: f.Output})
+		}
+	}
+	return specs
+}
+
+// modelSpecs returns the per-model files that apply to req, with the output
+// placeholders expanded for model.
+func (m *TemplateManifest) modelSpecs(req GenerateRequest, model DataModel) []templateSpec {
+	expand := strings.NewReplacer(
+		"{name}", model.Name,
+		"{lower}", strings.ToLower(model.Name),
+		"{snake}", toSnake(model.Name),
+	)
+	var specs []templateSpec
+	for _, f := range m.Models {
+		if f.When.matches(req) {
+			specs = append(specs, templateSpec{Template: f.Template, Output: expand.Replace(f.Output)})
+		}
+	}
+	return specs
+}
+
+// routesTarget returns the path under root of the file generated routes are
+// injected into. A manifest without a routes marker for req is reported as an
+// INJECTION_MARKER_MISSING error rather than skipped silently.
+func (m *TemplateManifest) routesTarget(ctx *GenerationContext, req GenerateRequest, root string) (string, bool) {
+	target, ok := m.markerTarget(req, "routes")
+	if !ok {
+		manifestPath := path.Join(req.Language, archTemplateName(req.Architecture), manifestFile)
+		ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic routes", Reason: "no file in the manifest declares the routes marker", Path: manifestPath})
+		return "", false
+	}
+	return path.Join(root, target), true
+}
+
+// markerTarget returns the output path of the file that carries marker for
+// req, i.e. where generated imports or routes get injected.
+func (m *TemplateManifest) markerTarget(req GenerateRequest, marker string) (string, bool) {
+	for _, f := range m.Files {
+		if f.When.matches(req) && slices.Contains(f.Markers, marker) {
+			return f.Output, true
+		}
+	}
+	return "", false
+}
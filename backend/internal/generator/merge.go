@@ -0,0 +1,150 @@
+package generator
+
+import "strings"
+
+// maxMergeCells bounds the LCS table used by merge3. Larger inputs are not
+// merged line by line; the whole file is reported as one conflict instead.
+const maxMergeCells = 4_000_000
+
+// Conflict marker labels written into files that could not be merged.
+const (
+	conflictOurs   = "<<<<<<< current"
+	conflictBase   = "||||||| previously generated"
+	conflictSep    = "======="
+	conflictTheirs = ">>>>>>> regenerated"
+)
+
+// merge3 is a line-based three-way merge in the style of diff3: changes made
+// on only one side since base are taken, identical changes are taken once,
+// and overlapping changes become a conflict block with both versions.
+func merge3(base, ours, theirs string) (string, bool) {
+	if ours == theirs {
+		return ours, false
+	}
+	if ours == base {
+		return theirs, false
+	}
+	if theirs == base {
+		return ours, false
+	}
+
+	b, o, t := splitLines(base), splitLines(ours), splitLines(theirs)
+	mo, okO := lcsMatch(b, o)
+	mt, okT := lcsMatch(b, t)
+	if !okO || !okT {
+		return conflictBlock(o, b, t), true
+	}
+
+	var out []string
+	conflict := false
+	emit := func(bc, oc, tc []string) {
+		switch {
+		case equalLines(oc, tc):
+			out = append(out, oc...)
+		case equalLines(oc, bc):
+			out = append(out, tc...)
+		case equalLines(tc, bc):
+			out = append(out, oc...)
+		default:
+			conflict = true
+			out = append(out, splitLines(conflictBlock(oc, bc, tc))...)
+		}
+	}
+
+	iB, iO, iT := 0, 0, 0
+	for k := 0; k < len(b); k++ {
+		// A base line kept by both sides is a stable point between chunks.
+		if mo[k] < 0 || mt[k] < 0 {
+			continue
+		}
+		emit(b[iB:k], o[iO:mo[k]], t[iT:mt[k]])
+		out = append(out, b[k])
+		iB, iO, iT = k+1, mo[k]+1, mt[k]+1
+	}
+	emit(b[iB:], o[iO:], t[iT:])
+	return strings.Join(out, ""), conflict
+}
+
+func conflictBlock(ours, base, theirs []string) string {
+	var sb strings.Builder
+	writeBlock := func(marker string, lines []string) {
+		sb.WriteString(marker + "\n")
+		for _, l := range lines {
+			sb.WriteString(l)
+			if !strings.HasSuffix(l, "\n") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	writeBlock(conflictOurs, ours)
+	writeBlock(conflictBase, base)
+	writeBlock(conflictSep, theirs)
+	sb.WriteString(conflictTheirs + "\n")
+	return sb.String()
+}
+
+// splitLines splits after each newline, keeping the terminators so joining
+// the result reproduces the input exactly.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lcsMatch maps each line of a to its partner in b under a longest common
+// subsequence, or -1 when unmatched.
+func lcsMatch(a, b []string) ([]int, bool) {
+	if len(a)*len(b) > maxMergeCells {
+		return nil, false
+	}
+	n, m := len(a), len(b)
+	dp := make([][]int32, n+1)
+	for i := range dp {
+		dp[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else if dp[i+1][j] >= dp[i][j+1] {
+				dp[i][j] = dp[i+1][j]
+			} else {
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+	match := make([]int, n)
+	for i := range match {
+		match[i] = -1
+	}
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case a[i] == b[j]:
+			match[i] = j
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match, true
+}
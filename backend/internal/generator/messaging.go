@@ -0,0 +1,213 @@
+package generator
+
+// messaging.go — the model event topics behind infra.kafka, infra.nats and
+// infra.rabbitmq.
+//
+// Like shared_infra.go, this file is language-agnostic: it must never look at
+// req.Language or req.Framework. Every resolved model gets a topic per change
+// event, which doubles as its NATS subject and RabbitMQ routing key; the
+// language templates render the clients, the JSON envelope and the consumer
+// runners from messagingData.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// modelEvents are the changes every model publishes, in topic order.
+var modelEvents = []string{"created", "updated", "deleted"}
+
+// Every generated topic gets the same layout; the compose broker is a single
+// node, so replicas beyond one could never be placed.
+const (
+	kafkaPartitions        = 3
+	kafkaReplicationFactor = 1
+)
+
+// rabbitExchange is the topic exchange every service publishes to; a queue
+// picks the events it wants by binding their topics as routing keys.
+const rabbitExchange = "events"
+
+// rabbitPrefetch caps the unacknowledged deliveries a consumer holds at once.
+const rabbitPrefetch = 10
+
+// eventTopic is the topic (or NATS subject) one model event is published to.
+type eventTopic struct {
+	Name  string // <table>.<event>, prefixed with the owning service in microservices
+	Ident string // TagCreated: Go constant and JS key suffix
+	Upper string // TAG_CREATED: Python constant
+	Model string
+	Event string
+}
+
+// messagingData is the messaging templates' data, under .Messaging.
+// Microservices consume the topics every other service publishes; a monolith
+// consumes its own.
+type messagingData struct {
+	Source            string // stamped on every event envelope
+	Group             string // consumer group, NATS queue group and durable name
+	BrokersEnv        string // variable buildEnv writes the broker list to
+	URLEnv            string // variable buildEnv writes the NATS URL to
+	AMQPURLEnv        string // variable buildEnv writes the RabbitMQ URL to
+	Exchange          string // RabbitMQ topic exchange
+	Queue             string // durable RabbitMQ queue shared by the service's replicas
+	Prefetch          int
+	Partitions        int
+	ReplicationFactor int
+	JetStream         bool
+	Topics            []eventTopic // what the service publishes
+	Subscriptions     []eventTopic // what its consumer group reads
+	Streams           []natsStream // JetStream streams the service declares
+}
+
+// natsStream is the JetStream stream holding one service's subjects.
+type natsStream struct {
+	Name     string
+	Subjects []string
+	Consumes []string // the subjects the service's durable consumer reads
+}
+
+func newMessagingData(req GenerateRequest, service string) messagingData {
+	source := service
+	if source == "" {
+		source = "app"
+	}
+	data := messagingData{
+		Source:            source,
+		Group:             source,
+		BrokersEnv:        serviceEnvKey(service, "KAFKA_BROKERS"),
+		URLEnv:            serviceEnvKey(service, "NATS_URL"),
+		AMQPURLEnv:        serviceEnvKey(service, "AMQP_URL"),
+		Exchange:          rabbitExchange,
+		Queue:             source + ".events",
+		Prefetch:          rabbitPrefetch,
+		Partitions:        kafkaPartitions,
+		ReplicationFactor: kafkaReplicationFactor,
+		JetStream:         req.Infra.JetStream,
+		Topics:            serviceTopics(req, service),
+	}
+	if service == "" {
+		data.Subscriptions = data.Topics
+		names := topicNames(data.Topics)
+		data.Streams = []natsStream{{Name: streamName(source), Subjects: names, Consumes: names}}
+		return data
+	}
+	for _, svc := range req.Services {
+		topics := serviceTopics(req, svc.Name)
+		stream := natsStream{Name: streamName(svc.Name), Subjects: topicNames(topics)}
+		if svc.Name != service {
+			data.Subscriptions = append(data.Subscriptions, topics...)
+			stream.Consumes = stream.Subjects
+		}
+		data.Streams = append(data.Streams, stream)
+	}
+	return data
+}
+
+// Declared lists every topic the service creates on startup: the ones it
+// publishes and the ones it subscribes to, each once.
+func (d messagingData) Declared() []eventTopic {
+	seen := map[string]bool{}
+	var out []eventTopic
+	for _, t := range append(append([]eventTopic{}, d.Topics...), d.Subscriptions...) {
+		if !seen[t.Name] {
+			seen[t.Name] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// streamName turns a service name into a JetStream stream name, which may
+// not contain dots or wildcards.
+func streamName(service string) string {
+	return strings.ToUpper(strings.ReplaceAll(service, "-", "_"))
+}
+
+func topicNames(topics []eventTopic) []string {
+	names := make([]string, 0, len(topics))
+	for _, t := range topics {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// usesMessaging reports whether the request generates a messaging package.
+func usesMessaging(req GenerateRequest) bool {
+	return req.Infra.Kafka || req.Infra.NATS || req.Infra.RabbitMQ
+}
+
+// applyMessagingDefaults turns on the NATS server JetStream runs in.
+func applyMessagingDefaults(req GenerateRequest) (GenerateRequest, []Decision) {
+	if !req.Infra.JetStream || req.Infra.NATS {
+		return req, nil
+	}
+	req.Infra.NATS = true
+	return req, []Decision{{
+		Code:        "NATS_ENABLED_FOR_JETSTREAM",
+		Description: "Enabled NATS since JetStream streams live on the NATS server.",
+		TriggeredBy: "ApplyRuleEngine",
+	}}
+}
+
+// serviceTopics returns the topics service publishes, "" being the monolith.
+func serviceTopics(req GenerateRequest, service string) []eventTopic {
+	prefix := ""
+	if service != "" {
+		prefix = service + "."
+	}
+	var topics []eventTopic
+	for _, m := range resolvedModels(req.Custom.Models) {
+		for _, event := range modelEvents {
+			ident := m.Name + toPascal(event)
+			topics = append(topics, eventTopic{
+				Name:  prefix + modelTable(m) + "." + event,
+				Ident: ident,
+				Upper: strings.ToUpper(toSnake(ident)),
+				Model: m.Name,
+				Event: event,
+			})
+		}
+	}
+	return topics
+}
+
+// messagingReadmeSection documents the topics and the event envelope in the
+// generated README.
+func messagingReadmeSection(req GenerateRequest) string {
+	if !usesMessaging(req) {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n## Events\n\n")
+	b.WriteString("The messaging package publishes model events as JSON envelopes (`id`, `type`, `source`, `time`, `data`); call its publish helper where a record changes. The consumers start with the app, log every event they read and stop on shutdown.")
+	if req.Infra.Kafka {
+		fmt.Fprintf(&b, " Kafka messages are keyed by record id and topics are created on startup with %d partitions.", kafkaPartitions)
+	}
+	if req.Infra.NATS {
+		if req.Infra.JetStream {
+			b.WriteString(" NATS subjects are stored in a JetStream stream per service, created on startup, and read by a durable consumer named after the consumer group; published events are deduplicated by id.")
+		} else {
+			b.WriteString(" NATS subscribers join a queue group named after the consumer group, so each event is handled by one replica, and drain on shutdown.")
+		}
+	}
+	if req.Infra.RabbitMQ {
+		fmt.Fprintf(&b, " RabbitMQ events go to the `%s` topic exchange with the topic as routing key, as persistent messages confirmed by the broker. "+
+			"Each service declares a durable `<group>.events` queue bound to the topics it consumes and acknowledges every event once handled; "+
+			"the management UI is at http://localhost:15672 (`app` / `app`).", rabbitExchange)
+	}
+	b.WriteString("\n\n")
+	services := []string{""}
+	if req.Architecture == "microservices" {
+		services = services[:0]
+		for _, svc := range req.Services {
+			services = append(services, svc.Name)
+		}
+	}
+	b.WriteString("| Service | Publishes | Consumer group |\n|---|---|---|\n")
+	for _, service := range services {
+		data := newMessagingData(req, service)
+		fmt.Fprintf(&b, "| `%s` | `%s` | `%s` |\n", data.Source, strings.Join(topicNames(data.Topics), "`, `"), data.Group)
+	}
+	return b.String()
+}
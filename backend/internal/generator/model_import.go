@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ModelImport is the result of ImportSQLModels and ImportOpenAPIModels.
+type ModelImport struct {
+	Models   []DataModel `json:"models"`
+	Warnings []Warning   `json:"warnings"`
+}
+
+// importModels folds custom.models_from_sql and then custom.openapi into
+// custom.models. A document that does not parse is left in place for
+// ValidateAll to report.
+func importModels(req GenerateRequest) (GenerateRequest, []Decision, []Warning) {
+	var decisions []Decision
+	var warnings []Warning
+	if strings.TrimSpace(req.Custom.ModelsFromSQL) != "" {
+		imported := ImportSQLModels(req.Custom.ModelsFromSQL)
+		models, merged := mergeImportedModels(req.Custom.Models, imported, "models_from_sql", "SQL_MODEL_SHADOWED")
+		req.Custom.Models = models
+		req.Custom.ModelsFromSQL = ""
+		warnings = append(warnings, merged...)
+		decisions = append(decisions, Decision{
+			Code:        "MODELS_IMPORTED_FROM_SQL",
+			Description: fmt.Sprintf("Imported %d model(s) from custom.models_from_sql.", len(imported.Models)),
+			TriggeredBy: "ApplyRuleEngine",
+		})
+	}
+	if strings.TrimSpace(req.Custom.OpenAPI) != "" {
+		imported, err := ImportOpenAPIModels(req.Custom.OpenAPI)
+		if err != nil {
+			return req, decisions, warnings
+		}
+		routes := 0
+		for _, m := range imported.Models {
+			routes += len(m.Routes)
+		}
+		models, merged := mergeImportedModels(req.Custom.Models, imported, "openapi", "OPENAPI_MODEL_SHADOWED")
+		req.Custom.Models = models
+		req.Custom.OpenAPI = ""
+		warnings = append(warnings, merged...)
+		decisions = append(decisions, Decision{
+			Code:        "MODELS_IMPORTED_FROM_OPENAPI",
+			Description: fmt.Sprintf("Imported %d model(s) and %d route(s) from custom.openapi.", len(imported.Models), routes),
+			TriggeredBy: "ApplyRuleEngine",
+		})
+	}
+	return req, decisions, warnings
+}
+
+// mergeImportedModels appends imported models to the declared ones. A model
+// that is already declared keeps its declaration, but takes the imported
+// routes when it has none, so SQL can describe the tables and OpenAPI the
+// endpoints.
+func mergeImportedModels(declared []DataModel, imported ModelImport, source, code string) ([]DataModel, []Warning) {
+	warnings := imported.Warnings
+	models := append([]DataModel(nil), declared...)
+	index := make(map[string]int, len(models))
+	for i, m := range models {
+		index[toPascal(m.Name)] = i
+	}
+	for _, m := range imported.Models {
+		i, ok := index[toPascal(m.Name)]
+		if !ok {
+			index[toPascal(m.Name)] = len(models)
+			models = append(models, m)
+			continue
+		}
+		msg := fmt.Sprintf("model %s from %s was ignored because custom.models declares it", m.Name, source)
+		if len(models[i].Routes) == 0 && len(m.Routes) > 0 {
+			models[i].Routes = m.Routes
+			msg = fmt.Sprintf("fields of model %s from %s were ignored because custom.models declares it; its routes were kept", m.Name, source)
+		}
+		warnings = append(warnings, Warning{
+			Code:     code,
+			Severity: "info",
+			Message:  msg,
+			Reason:   "Explicit models take precedence over imported ones.",
+		})
+	}
+	return models, warnings
+}
+
+// reservedFieldNames are JavaScript reserved words, Python keywords and SQL
+// reserved words. Node and Python code uses field names verbatim as
+// properties, parameters and attributes, and ORM tags and constraints put them
+// into SQL unquoted, so imported fields with these names get a trailing
+// underscore; Go code is unaffected since it uses the PascalCase form.
+var reservedFieldNames = setOf([]string{
+	"arguments", "await", "break", "case", "catch", "class", "const", "continue", "debugger",
+	"default", "delete", "do", "else", "enum", "eval", "export", "extends", "false", "finally",
+	"for", "function", "if", "implements", "import", "in", "instanceof", "interface", "let",
+	"new", "null", "package", "private", "protected", "public", "return", "static", "super",
+	"switch", "this", "throw", "true", "try", "typeof", "var", "void", "while", "with", "yield",
+	"and", "as", "assert", "async", "def", "del", "elif", "except", "from", "global", "is",
+	"lambda", "none", "nonlocal", "not", "or", "pass", "raise",
+	"all", "alter", "asc", "between", "by", "check", "column", "constraint", "create", "cross",
+	"current_date", "current_time", "current_timestamp", "desc", "distinct", "drop", "exists",
+	"foreign", "full", "grant", "group", "having", "index", "inner", "insert", "into", "join",
+	"key", "left", "like", "limit", "natural", "offset", "on", "order", "outer", "primary",
+	"range", "references", "right", "rows", "select", "set", "table", "then", "to", "union",
+	"unique", "update", "user", "using", "values", "when", "where", "window",
+})
+
+// importedFieldName maps an imported column or property name to a field name
+// every generator can use as an identifier: snake_case ASCII starting with a
+// letter, suffixed when it is a reserved word. ok is false when no such name
+// can be derived, e.g. for a leading digit or non-ASCII letters.
+func importedFieldName(raw string) (name string, ok bool) {
+	for _, r := range raw {
+		if r > unicode.MaxASCII {
+			return "", false
+		}
+	}
+	name = openAPIColumnName(raw)
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		return "", false
+	}
+	if _, reserved := reservedFieldNames[name]; reserved {
+		name += "_"
+	}
+	return name, true
+}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// crudStatements are the parameterised statements a driver-backed repository
+// runs for one model. Columns are the stored columns in field order: Insert
+// binds them in that order, Update binds them followed by the id, and List
+// and Get select the id followed by them. Table and Columns are the bare
+// names; the statements quote them in the database's dialect.
+type crudStatements struct {
+	Table   string
+	Columns []string
+	List    string // binds limit, offset
+	Get     string // binds id
+	Insert  string
+	Update  string
+	Delete  string // binds id
+	// Returning is set when Insert ends in RETURNING id (PostgreSQL); MySQL
+	// and SQLite drivers report the new id on the result instead.
+	Returning bool
+}
+
+// Bind styles: how a driver spells its n-th (1-based) statement parameter.
+var (
+	dollarBind   = func(n int) string { return fmt.Sprintf("$%d", n) } // pgx, pg
+	questionBind = func(int) string { return "?" }                     // go-sql-driver/mysql, mysql2, SQLite drivers
+	formatBind   = func(int) string { return "%s" }                    // psycopg, PyMySQL
+)
+
+func newCRUDStatements(m DataModel, db string, bind func(n int) string) crudStatements {
+	d := newSQLDialect(db)
+	table := modelTable(m)
+	st := crudStatements{Table: table, Returning: db == "postgresql"}
+	for _, c := range storedColumns(m) {
+		st.Columns = append(st.Columns, c.Name)
+	}
+	// Names are quoted so imported columns such as "order" or "select" still
+	// parse as identifiers.
+	id, from := d.quote("id"), d.quote(table)
+	quoted := make([]string, len(st.Columns))
+	for i, c := range st.Columns {
+		quoted[i] = d.quote(c)
+	}
+	selectList := strings.Join(append([]string{id}, quoted...), ", ")
+	st.List = fmt.Sprintf("SELECT %s FROM %s ORDER BY %s LIMIT %s OFFSET %s", selectList, from, id, bind(1), bind(2))
+	st.Get = fmt.Sprintf("SELECT %s FROM %s WHERE %s = %s", selectList, from, id, bind(1))
+	st.Delete = fmt.Sprintf("DELETE FROM %s WHERE %s = %s", from, id, bind(1))
+
+	values := make([]string, len(quoted))
+	sets := make([]string, len(quoted))
+	for i, c := range quoted {
+		values[i] = bind(i + 1)
+		sets[i] = c + " = " + bind(i+1)
+	}
+	switch {
+	case len(quoted) > 0:
+		st.Insert = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", from, strings.Join(quoted, ", "), strings.Join(values, ", "))
+	case db == "mysql":
+		st.Insert = "INSERT INTO " + from + " () VALUES ()"
+	default:
+		st.Insert = "INSERT INTO " + from + " DEFAULT VALUES"
+	}
+	if st.Returning {
+		st.Insert += " RETURNING " + id
+	}
+	if len(sets) == 0 {
+		// Nothing to change, but the statement still reports whether the row exists.
+		sets = []string{id + " = " + id}
+	}
+	st.Update = fmt.Sprintf("UPDATE %s SET %s WHERE %s = %s", from, strings.Join(sets, ", "), id, bind(len(st.Columns)+1))
+	return st
+}
@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNewCRUDStatements(t *testing.T) {
+	post := DataModel{Name: "Post", Fields: []DataField{{Name: "title", Type: "string"}, {Name: "author_id", Type: "int"}}}
+
+	pg := newCRUDStatements(post, "postgresql", dollarBind)
+	want := crudStatements{
+		Table:     "posts",
+		Columns:   []string{"title", "author_id"},
+		List:      `SELECT "id", "title", "author_id" FROM "posts" ORDER BY "id" LIMIT $1 OFFSET $2`,
+		Get:       `SELECT "id", "title", "author_id" FROM "posts" WHERE "id" = $1`,
+		Insert:    `INSERT INTO "posts" ("title", "author_id") VALUES ($1, $2) RETURNING "id"`,
+		Update:    `UPDATE "posts" SET "title" = $1, "author_id" = $2 WHERE "id" = $3`,
+		Delete:    `DELETE FROM "posts" WHERE "id" = $1`,
+		Returning: true,
+	}
+	if !reflect.DeepEqual(pg, want) {
+		t.Errorf("postgresql statements:\n got %+v\nwant %+v", pg, want)
+	}
+
+	my := newCRUDStatements(post, "mysql", questionBind)
+	if my.Returning || my.Insert != "INSERT INTO `posts` (`title`, `author_id`) VALUES (?, ?)" || my.Update != "UPDATE `posts` SET `title` = ?, `author_id` = ? WHERE `id` = ?" {
+		t.Errorf("mysql statements: %+v", my)
+	}
+
+	empty := DataModel{Name: "Tag"}
+	if got := newCRUDStatements(empty, "mysql", questionBind); got.Insert != "INSERT INTO `tags` () VALUES ()" || got.Update != "UPDATE `tags` SET `id` = `id` WHERE `id` = ?" {
+		t.Errorf("mysql statements without columns: %+v", got)
+	}
+	if got := newCRUDStatements(empty, "postgresql", dollarBind).Insert; got != `INSERT INTO "tags" DEFAULT VALUES RETURNING "id"` {
+		t.Errorf("postgresql insert without columns = %q", got)
+	}
+}
+
+func TestNewCRUDStatementsQuoteReservedWords(t *testing.T) {
+	// Declared fields may be SQL reserved words.
+	model := DataModel{Name: "Order", Fields: []DataField{{Name: "select", Type: "string"}, {Name: "group", Type: "int"}}}
+
+	pg := newCRUDStatements(model, "postgresql", dollarBind)
+	assertContainsAll(t, "postgresql", pg.List+"\n"+pg.Get+"\n"+pg.Insert+"\n"+pg.Update+"\n"+pg.Delete,
+		`SELECT "id", "select", "group" FROM "orders" ORDER BY "id" LIMIT $1 OFFSET $2`,
+		`SELECT "id", "select", "group" FROM "orders" WHERE "id" = $1`,
+		`INSERT INTO "orders" ("select", "group") VALUES ($1, $2) RETURNING "id"`,
+		`UPDATE "orders" SET "select" = $1, "group" = $2 WHERE "id" = $3`,
+		`DELETE FROM "orders" WHERE "id" = $1`,
+	)
+
+	my := newCRUDStatements(model, "mysql", questionBind)
+	assertContainsAll(t, "mysql", my.List+"\n"+my.Get+"\n"+my.Insert+"\n"+my.Update+"\n"+my.Delete,
+		"SELECT `id`, `select`, `group` FROM `orders` ORDER BY `id` LIMIT ? OFFSET ?",
+		"SELECT `id`, `select`, `group` FROM `orders` WHERE `id` = ?",
+		"INSERT INTO `orders` (`select`, `group`) VALUES (?, ?)",
+		"UPDATE `orders` SET `select` = ?, `group` = ? WHERE `id` = ?",
+		"DELETE FROM `orders` WHERE `id` = ?",
+	)
+	if !reflect.DeepEqual(my.Columns, []string{"select", "group"}) {
+		t.Errorf("columns = %v, want the bare names", my.Columns)
+	}
+}
+
+func TestGenerateServesCRUDHandlers(t *testing.T) {
+	registry, err := NewTemplateRegistry("../../../templates")
+	if err != nil {
+		t.Fatalf("failed to init registry: %v", err)
+	}
+	engine := NewEngine(registry)
+
+	cases := []struct {
+		language, framework, architecture, database string
+		orm                                         bool
+		files                                       map[string][]string
+	}{
+		{"go", "gin", "mvp", "postgresql", false, map[string][]string{
+			"cmd/server/main.go":               {"handlers.DB = conn", `r.GET("/tags/:id", handlers.GetTag)`, `r.DELETE("/tags/:id", handlers.DeleteTag)`},
+			"internal/handlers/tag_handler.go": {`DB.QueryRow("INSERT INTO \"tags\" (\"label\") VALUES ($1) RETURNING \"id\"", in.Label)`, "pagination.Parse(limit, offset)"},
+		}},
+		{"go", "fiber", "clean", "mysql", true, map[string][]string{
+			"cmd/server/main.go":                    {"delivery.NewTagHandler(usecase.NewTagUsecase(repository.NewTagRepository(conn)))", `app.Put("/tags/:id", tagHandler.UpdateTag)`},
+			"internal/repository/tag_repository.go": {"r.db.WithContext(ctx).Order(\"id\").Limit(page.Limit).Offset(page.Offset)"},
+		}},
+		{"go", "gin", "hexagonal", "none", false, map[string][]string{
+			"cmd/server/main.go": {"httpPrimary.NewTagHandler(services.NewTagService(database.NewTagAdapter()))"},
+		}},
+		{"node", "express", "mvp", "postgresql", false, map[string][]string{
+			"src/routes/tags.js":                {"repo.findAll(page)", "res.status(204).end()"},
+			"src/repositories/tagRepository.js": {`RETURNING "id"`},
+		}},
+		{"python", "fastapi", "mvp", "mysql", false, map[string][]string{
+			"app/routes/tags.py":               {"_repo.find_all(page.limit, page.offset)", "@router.delete('/tags/{id}', status_code=204)"},
+			"app/repository/tag_repository.py": {"INSERT_SQL = \"INSERT INTO `tags` (`label`) VALUES (%s)\""},
+		}},
+		{"python", "django", "mvp", "postgresql", false, map[string][]string{
+			"api/models.py":     {"class Tag(models.Model):", `db_table = "tags"`},
+			"api/model_urls.py": {"path('tags/<int:id>', views.tags_id_view)"},
+			"config/urls.py":    {"include('api.model_urls')"},
+		}},
+	}
+	for _, tc := range cases {
+		name := tc.language + " " + tc.architecture
+		project, err := engine.GenerateProject(context.Background(), GenerateRequest{
+			Language:     tc.language,
+			Framework:    tc.framework,
+			Architecture: tc.architecture,
+			Database:     tc.database,
+			UseORM:       tc.orm,
+			FileToggles:  FileToggleOptions{ExampleCRUD: ptr(true)},
+			Custom:       CustomOptions{Models: []DataModel{{Name: "Tag", Fields: []DataField{{Name: "label", Type: "string"}}}}},
+		})
+		if err != nil {
+			t.Fatalf("%s: GenerateProject() error = %v", name, err)
+		}
+		for path, want := range tc.files {
+			assertContainsAll(t, name+" "+path, project.Tree.Files[path], want...)
+		}
+	}
+}
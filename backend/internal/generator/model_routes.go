@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Route actions: what a generated handler does with its model.
+const (
+	ActionList   = "list"
+	ActionGet    = "get"
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+	ActionCustom = "custom" // no CRUD meaning; the handler answers 501
+)
+
+var routeActions = []string{ActionList, ActionGet, ActionCreate, ActionUpdate, ActionDelete, ActionCustom}
+
+var routeMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// operationIDRegex keeps operation ids usable as handler names in Go,
+// JavaScript and Python.
+var operationIDRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+var pathParamRegex = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// modelRoute is a ModelRoute with its method upper-cased and its action
+// resolved, plus the path parameters the handlers read.
+type modelRoute struct {
+	ModelRoute
+	Param  string   // trailing path parameter holding the item id; empty on collection routes
+	Params []string // every path parameter, in order
+}
+
+// modelRoutes returns the model's declared routes, or list/get/create/
+// update/delete on /<table> when it declares none.
+func modelRoutes(m DataModel) []modelRoute {
+	routes := m.Routes
+	if len(routes) == 0 {
+		routes = defaultRoutes(m)
+	}
+	out := make([]modelRoute, 0, len(routes))
+	for _, r := range routes {
+		r.OperationID = strings.TrimSpace(r.OperationID)
+		r.Method = strings.ToUpper(strings.TrimSpace(r.Method))
+		r.Path = strings.TrimSpace(r.Path)
+		r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+		if r.Action == "" {
+			r.Action = routeAction(m.Name, r.Method, r.Path)
+		}
+		out = append(out, modelRoute{ModelRoute: r, Param: itemParam(r.Path), Params: pathParams(r.Path)})
+	}
+	return out
+}
+
+func defaultRoutes(m DataModel) []ModelRoute {
+	name := m.Name
+	collection := "/" + modelTable(m)
+	item := collection + "/{id}"
+	return []ModelRoute{
+		{OperationID: "list" + name + "s", Method: "GET", Path: collection, Action: ActionList},
+		{OperationID: "get" + name, Method: "GET", Path: item, Action: ActionGet},
+		{OperationID: "create" + name, Method: "POST", Path: collection, Action: ActionCreate},
+		{OperationID: "update" + name, Method: "PUT", Path: item, Action: ActionUpdate},
+		{OperationID: "delete" + name, Method: "DELETE", Path: item, Action: ActionDelete},
+	}
+}
+
+// routeAction infers a route's action from its method and path. Item routes
+// end in a parameter; collection routes end in the model's plural name, so
+// POST /users/{id}/posts creates a Post but POST /posts/{id}/publish does not.
+func routeAction(model, method, path string) string {
+	if itemParam(path) != "" {
+		switch method {
+		case "GET":
+			return ActionGet
+		case "PUT", "PATCH":
+			return ActionUpdate
+		case "DELETE":
+			return ActionDelete
+		}
+		return ActionCustom
+	}
+	last := path[strings.LastIndex(path, "/")+1:]
+	if !sameColumn(singularModelName(strings.ToLower(last)), model) {
+		return ActionCustom
+	}
+	switch method {
+	case "GET":
+		return ActionList
+	case "POST":
+		return ActionCreate
+	}
+	return ActionCustom
+}
+
+// itemRoute reports whether an action reads the item id from the path.
+func itemRoute(action string) bool {
+	return action == ActionGet || action == ActionUpdate || action == ActionDelete
+}
+
+func pathParams(p string) []string {
+	var out []string
+	for _, m := range pathParamRegex.FindAllStringSubmatch(p, -1) {
+		out = append(out, m[1])
+	}
+	return out
+}
+
+// itemParam is the parameter of a path whose last segment is exactly {param}.
+func itemParam(p string) string {
+	last := p[strings.LastIndex(p, "/")+1:]
+	if m := pathParamRegex.FindStringSubmatch(last); m != nil && m[0] == last {
+		return m[1]
+	}
+	return ""
+}
+
+// colonPath rewrites {param} segments into the :param form gin, fiber,
+// express and fastify route on.
+func colonPath(p string) string {
+	return pathParamRegex.ReplaceAllString(p, ":$1")
+}
+
+// goHandlerName exports an operation id: listPosts -> ListPosts.
+func goHandlerName(operationID string) string {
+	return strings.ToUpper(operationID[:1]) + operationID[1:]
+}
+
+// pythonHandlerName snake-cases an operation id: listPosts -> list_posts.
+func pythonHandlerName(operationID string) string {
+	return strings.ToLower(toSnake(operationID))
+}
+
+// validateRoutes checks every declared route and that no two routes, across
+// all models and including the defaults of models without routes, share an
+// operation id or a method and path.
+func validateRoutes(v *validator, models []DataModel) {
+	operations := map[string]bool{}
+	endpoints := map[string]bool{}
+	endpointKey := func(r modelRoute) string {
+		return r.Method + " " + pathParamRegex.ReplaceAllString(r.Path, "{}")
+	}
+	for _, m := range models {
+		if len(m.Routes) == 0 && strings.TrimSpace(m.Name) != "" {
+			for _, r := range modelRoutes(DataModel{Name: toPascal(m.Name), Table: strings.TrimSpace(m.Table)}) {
+				operations[strings.ToLower(r.OperationID)] = true
+				endpoints[endpointKey(r)] = true
+			}
+		}
+	}
+	for i, m := range models {
+		for k, r := range modelRoutes(m)[:len(m.Routes)] {
+			pointer := fmt.Sprintf("/custom/models/%d/routes/%d", i, k)
+			if !operationIDRegex.MatchString(r.OperationID) {
+				v.add(pointer+"/operation_id", "ROUTE_OPERATION_INVALID", fmt.Sprintf("operation id %q must start with a letter and contain only letters, digits and underscores", r.OperationID))
+			} else if operations[strings.ToLower(r.OperationID)] {
+				v.add(pointer+"/operation_id", "ROUTE_OPERATION_DUPLICATE", fmt.Sprintf("operation id %q is used by another route", r.OperationID))
+			}
+			operations[strings.ToLower(r.OperationID)] = true
+
+			if !slices.Contains(routeMethods, r.Method) {
+				v.add(pointer+"/method", "ROUTE_METHOD_INVALID", "method must be one of: "+strings.Join(routeMethods, ", "), routeMethods...)
+				continue
+			}
+			if !strings.HasPrefix(r.Path, "/") || strings.ContainsAny(pathParamRegex.ReplaceAllString(r.Path, ""), "{}") {
+				v.add(pointer+"/path", "ROUTE_PATH_INVALID", fmt.Sprintf("path %q must start with / and use {param} placeholders", r.Path))
+				continue
+			}
+			badParam := false
+			for _, p := range r.Params {
+				if !enumValueRegex.MatchString(p) {
+					v.add(pointer+"/path", "ROUTE_PATH_INVALID", fmt.Sprintf("path parameter %q must start with a letter and contain only letters, digits and underscores", p))
+					badParam = true
+				}
+			}
+			if badParam {
+				continue
+			}
+			switch {
+			case !slices.Contains(routeActions, r.Action):
+				v.add(pointer+"/action", "ROUTE_ACTION_INVALID", "action must be one of: "+strings.Join(routeActions, ", "), routeActions...)
+			case itemRoute(r.Action) && r.Param == "":
+				v.add(pointer+"/action", "ROUTE_ACTION_INVALID", fmt.Sprintf("%s routes must end in a path parameter holding the id, e.g. /items/{id}", r.Action))
+			}
+			if key := endpointKey(r); endpoints[key] {
+				v.add(pointer, "ROUTE_DUPLICATE", fmt.Sprintf("%s %s is served by another route", r.Method, r.Path))
+			} else {
+				endpoints[key] = true
+			}
+		}
+	}
+}
@@ -0,0 +1,448 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Relation kinds accepted in DataModel.Relations.
+const (
+	RelationBelongsTo  = "belongs_to"
+	RelationHasMany    = "has_many"
+	RelationManyToMany = "many_to_many"
+)
+
+var relationKinds = []string{RelationBelongsTo, RelationHasMany, RelationManyToMany}
+
+// enumValueRegex keeps enum values usable as identifiers in every target
+// (Prisma enums, Python Literal, SQL CHECK lists).
+var enumValueRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// tableNameRegex limits declared tables to snake_case: they also name the
+// model's routes, permissions and event subjects.
+var tableNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+const defaultMaxLength = 255
+
+// modelColumn is one stored column of a model: a declared field, or the
+// foreign key a belongs_to relation adds. Name is the column name.
+type modelColumn struct {
+	DataField
+	GoName     string // exported Go field name
+	References string // target model of a foreign key; empty for declared fields
+}
+
+// joinTable is the association table behind a many_to_many relation.
+type joinTable struct {
+	Name        string
+	Other       string // the model on the other side
+	Column      string // this model's key column
+	OtherColumn string
+}
+
+// modelTable is the database table behind m: its declared table, otherwise
+// the lowercased name plus "s".
+func modelTable(m DataModel) string {
+	if m.Table != "" {
+		return m.Table
+	}
+	return strings.ToLower(m.Name) + "s"
+}
+
+func foreignKeyColumn(target string) string {
+	return toSnake(target) + "_id"
+}
+
+func isIDColumn(name string) bool {
+	return strings.EqualFold(name, "id")
+}
+
+func isStringField(f DataField) bool {
+	switch f.Type {
+	case "int", "integer", "float", "float64", "double", "bool", "boolean", "datetime", "timestamp", "time":
+		return false
+	}
+	return true
+}
+
+func isDateTimeField(f DataField) bool {
+	switch f.Type {
+	case "datetime", "timestamp", "time":
+		return true
+	}
+	return false
+}
+
+// maxLength is the VARCHAR length of a string column.
+func maxLength(f DataField) int {
+	if f.MaxLength > 0 {
+		return f.MaxLength
+	}
+	return defaultMaxLength
+}
+
+// normalizeRelations rewrites every has_many into a belongs_to on the target
+// and drops duplicate or dangling relations, so renderers only deal with
+// belongs_to (foreign key on the declaring model) and many_to_many. A foreign
+// key declared from both sides is required if either side says so.
+func normalizeRelations(models []DataModel) {
+	index := make(map[string]int, len(models))
+	for i, m := range models {
+		index[m.Name] = i
+	}
+	belongs := make([][]ModelRelation, len(models))
+	var manyToMany [][2]int
+	seenPair := map[[2]int]bool{}
+	addBelongsTo := func(from, to int, required bool) {
+		for k, r := range belongs[from] {
+			if r.Model == models[to].Name {
+				belongs[from][k].Required = r.Required || required
+				return
+			}
+		}
+		belongs[from] = append(belongs[from], ModelRelation{Kind: RelationBelongsTo, Model: models[to].Name, Required: required})
+	}
+	for i, m := range models {
+		for _, r := range m.Relations {
+			j, ok := index[toPascal(r.Model)]
+			if !ok || j == i {
+				continue
+			}
+			switch strings.ToLower(strings.TrimSpace(r.Kind)) {
+			case RelationBelongsTo:
+				addBelongsTo(i, j, r.Required)
+			case RelationHasMany:
+				addBelongsTo(j, i, r.Required)
+			case RelationManyToMany:
+				pair := [2]int{min(i, j), max(i, j)}
+				if !seenPair[pair] {
+					seenPair[pair] = true
+					manyToMany = append(manyToMany, [2]int{i, j})
+				}
+			}
+		}
+	}
+	for i := range models {
+		models[i].Relations = belongs[i]
+	}
+	for _, p := range manyToMany {
+		models[p[0]].Relations = append(models[p[0]].Relations, ModelRelation{Kind: RelationManyToMany, Model: models[p[1]].Name})
+	}
+}
+
+// modelColumns lists the declared fields followed by belongs_to foreign keys.
+// A declared field that already uses the foreign key's column name wins.
+func modelColumns(m DataModel) []modelColumn {
+	cols := make([]modelColumn, 0, len(m.Fields)+len(m.Relations))
+	seen := map[string]bool{}
+	for _, f := range m.Fields {
+		f.Name = strings.ToLower(f.Name)
+		cols = append(cols, modelColumn{DataField: f, GoName: toPascal(f.Name)})
+		seen[f.Name] = true
+	}
+	for _, r := range parentRelations(m) {
+		col := foreignKeyColumn(r.Model)
+		if seen[col] {
+			continue
+		}
+		cols = append(cols, modelColumn{
+			DataField:  DataField{Name: col, Type: "int", Required: r.Required},
+			GoName:     r.Model + "ID",
+			References: r.Model,
+		})
+	}
+	return cols
+}
+
+// storedColumns is modelColumns without the primary key, which every target
+// declares on its own.
+func storedColumns(m DataModel) []modelColumn {
+	all := modelColumns(m)
+	cols := all[:0:0]
+	for _, c := range all {
+		if !isIDColumn(c.Name) {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+func parentRelations(m DataModel) []ModelRelation {
+	var out []ModelRelation
+	for _, r := range m.Relations {
+		if r.Kind == RelationBelongsTo {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// childModels are the models holding a foreign key to m.
+func childModels(models []DataModel, m DataModel) []string {
+	var out []string
+	for _, other := range models {
+		for _, r := range parentRelations(other) {
+			if r.Model == m.Name {
+				out = append(out, other.Name)
+			}
+		}
+	}
+	return out
+}
+
+// joinTables lists m's many_to_many associations from either side.
+func joinTables(models []DataModel, m DataModel) []joinTable {
+	var out []joinTable
+	add := func(a, b DataModel) {
+		other := b.Name
+		if b.Name == m.Name {
+			other = a.Name
+		}
+		out = append(out, joinTable{
+			Name:        joinTableName(a, b),
+			Other:       other,
+			Column:      foreignKeyColumn(m.Name),
+			OtherColumn: foreignKeyColumn(other),
+		})
+	}
+	for _, owner := range models {
+		for _, r := range owner.Relations {
+			if r.Kind != RelationManyToMany {
+				continue
+			}
+			if owner.Name == m.Name || r.Model == m.Name {
+				add(owner, findModel(models, r.Model))
+			}
+		}
+	}
+	return out
+}
+
+// allJoinTables lists every many_to_many association once, as (owner, target).
+func allJoinTables(models []DataModel) [][2]DataModel {
+	var out [][2]DataModel
+	for _, m := range models {
+		for _, r := range m.Relations {
+			if r.Kind == RelationManyToMany {
+				out = append(out, [2]DataModel{m, findModel(models, r.Model)})
+			}
+		}
+	}
+	return out
+}
+
+func joinTableName(a, b DataModel) string {
+	names := []string{modelTable(a), modelTable(b)}
+	sort.Strings(names)
+	return names[0] + "_" + names[1]
+}
+
+// indexColumns maps an index's field names onto column names.
+func indexColumns(idx ModelIndex) []string {
+	cols := make([]string, 0, len(idx.Fields))
+	for _, f := range idx.Fields {
+		cols = append(cols, strings.ToLower(strings.TrimSpace(f)))
+	}
+	return cols
+}
+
+func indexName(table string, idx ModelIndex) string {
+	prefix := "idx_"
+	if idx.Unique {
+		prefix = "uq_"
+	}
+	return prefix + table + "_" + strings.Join(indexColumns(idx), "_")
+}
+
+// parentsFirst orders models so every belongs_to target comes before the
+// models referencing it; cycles keep their declared order.
+func parentsFirst(models []DataModel) []DataModel {
+	out := make([]DataModel, 0, len(models))
+	placed := map[string]bool{}
+	visiting := map[string]bool{}
+	byName := make(map[string]DataModel, len(models))
+	for _, m := range models {
+		byName[m.Name] = m
+	}
+	var visit func(m DataModel)
+	visit = func(m DataModel) {
+		if placed[m.Name] || visiting[m.Name] {
+			return
+		}
+		visiting[m.Name] = true
+		for _, r := range parentRelations(m) {
+			visit(byName[r.Model])
+		}
+		visiting[m.Name] = false
+		placed[m.Name] = true
+		out = append(out, m)
+	}
+	for _, m := range models {
+		visit(m)
+	}
+	return out
+}
+
+// validateModels checks the schema builder's constraints, enums, indexes,
+// relations and routes. Names are matched the way resolvedModels normalises them.
+func validateModels(v *validator, models []DataModel) {
+	names := map[string]bool{}
+	for _, m := range models {
+		if strings.TrimSpace(m.Name) != "" {
+			names[toPascal(m.Name)] = true
+		}
+	}
+	// Foreign keys a has_many puts on the other model can be indexed there.
+	implied := map[string][]string{}
+	for _, m := range models {
+		for _, r := range m.Relations {
+			if strings.ToLower(strings.TrimSpace(r.Kind)) == RelationHasMany {
+				target := toPascal(r.Model)
+				implied[target] = append(implied[target], foreignKeyColumn(toPascal(m.Name)))
+			}
+		}
+	}
+
+	pairs := map[[2]string]string{}
+	tables := map[string]string{}
+	for i, m := range models {
+		base := fmt.Sprintf("/custom/models/%d", i)
+		name := toPascal(m.Name)
+		declared := strings.TrimSpace(m.Table)
+		table := modelTable(DataModel{Name: name, Table: declared})
+		switch {
+		case declared != "" && !tableNameRegex.MatchString(declared):
+			v.add(base+"/table", "TABLE_NAME_INVALID", fmt.Sprintf("table %q must start with a lowercase letter and contain only lowercase letters, digits and underscores", table))
+		case tables[table] != "" && tables[table] != name:
+			v.add(base+"/table", "TABLE_DUPLICATE", fmt.Sprintf("table %q is already used by %s", table, tables[table]))
+		default:
+			tables[table] = name
+		}
+		columns := map[string]bool{}
+		for _, col := range implied[name] {
+			columns[col] = true
+		}
+		for j, f := range m.Fields {
+			columns[strings.ToLower(strings.TrimSpace(f.Name))] = true
+			validateField(v, fmt.Sprintf("%s/fields/%d", base, j), f)
+		}
+		for k, r := range m.Relations {
+			pointer := fmt.Sprintf("%s/relations/%d", base, k)
+			kind := strings.ToLower(strings.TrimSpace(r.Kind))
+			target := toPascal(r.Model)
+			switch {
+			case !slices.Contains(relationKinds, kind):
+				v.add(pointer+"/kind", "RELATION_KIND_INVALID", "relation kind must be one of: "+strings.Join(relationKinds, ", "), relationKinds...)
+				continue
+			case strings.TrimSpace(r.Model) == "" || !names[target]:
+				v.add(pointer+"/model", "RELATION_TARGET_UNKNOWN", fmt.Sprintf("relation target %q is not a model in this request", r.Model))
+				continue
+			case target == name:
+				v.add(pointer+"/model", "RELATION_SELF_UNSUPPORTED", "relations from a model to itself are not supported")
+				continue
+			}
+
+			// Only one relation per model pair. has_many on one side describes
+			// the same foreign key as belongs_to on the other, so the shape is
+			// keyed by which model holds the key.
+			shape := RelationManyToMany
+			switch kind {
+			case RelationBelongsTo:
+				shape = "fk on " + name
+				columns[foreignKeyColumn(target)] = true
+			case RelationHasMany:
+				shape = "fk on " + target
+			}
+			key := [2]string{name, target}
+			if key[1] < key[0] {
+				key[0], key[1] = key[1], key[0]
+			}
+			if prev, ok := pairs[key]; ok && prev != shape {
+				v.add(pointer, "RELATION_DUPLICATE", fmt.Sprintf("%s and %s already have a relation; only one relation per model pair is supported", name, target))
+				continue
+			}
+			pairs[key] = shape
+		}
+		for k, idx := range m.Indexes {
+			pointer := fmt.Sprintf("%s/indexes/%d", base, k)
+			if len(idx.Fields) == 0 {
+				v.add(pointer+"/fields", "INDEX_EMPTY", "index must list at least one field")
+				continue
+			}
+			for n, col := range indexColumns(idx) {
+				if !columns[col] && !isIDColumn(col) {
+					v.add(fmt.Sprintf("%s/fields/%d", pointer, n), "INDEX_FIELD_UNKNOWN", fmt.Sprintf("index field %q is not a field or belongs_to key of %s", idx.Fields[n], name))
+				}
+			}
+		}
+	}
+	validateRoutes(v, models)
+}
+
+func validateField(v *validator, pointer string, f DataField) {
+	f.Type = strings.ToLower(strings.TrimSpace(f.Type))
+	if f.Type == "enum" && len(f.Enum) == 0 {
+		v.add(pointer+"/enum", "ENUM_VALUES_REQUIRED", "enum fields must list their values")
+	}
+	if len(f.Enum) > 0 && !isStringField(f) {
+		v.add(pointer+"/enum", "ENUM_TYPE_INVALID", "enum values are only allowed on string or enum fields")
+	}
+	seen := map[string]bool{}
+	for n, value := range f.Enum {
+		value = strings.TrimSpace(value)
+		if !enumValueRegex.MatchString(value) {
+			v.add(fmt.Sprintf("%s/enum/%d", pointer, n), "ENUM_VALUE_INVALID", fmt.Sprintf("enum value %q must start with a letter and contain only letters, digits and underscores", value))
+		} else if seen[value] {
+			v.add(fmt.Sprintf("%s/enum/%d", pointer, n), "ENUM_VALUE_DUPLICATE", fmt.Sprintf("duplicate enum value %q", value))
+		}
+		seen[value] = true
+	}
+	if f.MaxLength < 0 || (f.MaxLength > 0 && !isStringField(f)) {
+		v.add(pointer+"/max_length", "MAX_LENGTH_INVALID", "max_length must be a positive number on a string field")
+	}
+	if def := strings.TrimSpace(f.Default); def != "" {
+		if err := checkDefault(f, def); err != nil {
+			v.add(pointer+"/default", "DEFAULT_INVALID", err.Error())
+		}
+	}
+}
+
+func checkDefault(f DataField, def string) error {
+	switch {
+	case len(f.Enum) > 0:
+		if !slices.Contains(trimmedAll(f.Enum), def) {
+			return fmt.Errorf("default %q is not one of the enum values", def)
+		}
+	case isDateTimeField(f):
+		if def != "now" {
+			return fmt.Errorf("datetime defaults must be \"now\"")
+		}
+	case f.Type == "int" || f.Type == "integer":
+		if _, err := strconv.Atoi(def); err != nil {
+			return fmt.Errorf("default %q is not an integer", def)
+		}
+	case f.Type == "float" || f.Type == "float64" || f.Type == "double":
+		if _, err := strconv.ParseFloat(def, 64); err != nil {
+			return fmt.Errorf("default %q is not a number", def)
+		}
+	case f.Type == "bool" || f.Type == "boolean":
+		if _, err := strconv.ParseBool(def); err != nil {
+			return fmt.Errorf("default %q is not true or false", def)
+		}
+	case f.MaxLength > 0 && len(def) > f.MaxLength:
+		return fmt.Errorf("default is longer than max_length %d", f.MaxLength)
+	}
+	return nil
+}
+
+func trimmedAll(in []string) []string {
+	out := make([]string, 0, len(in))
+	for _, s := range in {
+		out = append(out, strings.TrimSpace(s))
+	}
+	return out
+}
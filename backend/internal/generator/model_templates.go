@@ -2,14 +2,23 @@ package generator
 
 import (
 	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"text/template"
 )
 
-type modelTemplateData struct {
-	Models []DataModel
+// acceptedFieldTypes are the model field types the type mappers recognise.
+// Anything else falls back to a string column; "enum" needs DataField.Enum.
+var acceptedFieldTypes = []string{
+	"string", "int", "integer", "float", "float64", "double",
+	"bool", "boolean", "datetime", "timestamp", "time", "enum",
 }
 
+// resolvedModels trims the schema builder's models, fills defaults and
+// normalises relations (see normalizeRelations). Validation has already
+// rejected anything the renderers cannot express.
 func resolvedModels(in []DataModel) []DataModel {
 	clean := make([]DataModel, 0, len(in))
 	for _, m := range in {
@@ -23,16 +32,31 @@ func resolvedModels(in []DataModel) []DataModel {
 			if fn == "" {
 				continue
 			}
-			ft := strings.TrimSpace(f.Type)
-			if ft == "" {
+			ft := strings.ToLower(strings.TrimSpace(f.Type))
+			if ft == "" || (ft == "enum" && len(f.Enum) == 0) {
 				ft = "string"
 			}
-			fields = append(fields, DataField{Name: fn, Type: ft})
+			fields = append(fields, DataField{
+				Name:      fn,
+				Type:      ft,
+				Required:  f.Required,
+				Unique:    f.Unique,
+				Default:   strings.TrimSpace(f.Default),
+				MaxLength: f.MaxLength,
+				Enum:      trimmedAll(f.Enum),
+			})
 		}
 		if len(fields) == 0 {
 			fields = []DataField{{Name: "name", Type: "string"}}
 		}
-		clean = append(clean, DataModel{Name: toPascal(name), Fields: fields})
+		clean = append(clean, DataModel{
+			Name:      toPascal(name),
+			Table:     strings.TrimSpace(m.Table),
+			Fields:    fields,
+			Indexes:   m.Indexes,
+			Relations: append([]ModelRelation(nil), m.Relations...),
+			Routes:    m.Routes,
+		})
 	}
 	if len(clean) == 0 {
 		return []DataModel{{
@@ -40,31 +64,162 @@ func resolvedModels(in []DataModel) []DataModel {
 			Fields: []DataField{{Name: "id", Type: "int"}, {Name: "name", Type: "string"}},
 		}}
 	}
+	normalizeRelations(clean)
 	return clean
 }
 
+type goORMField struct {
+	Name string
+	Type string
+	Tags string
+}
+
+type goORMModel struct {
+	Name   string
+	Table  string // gorm would pluralise Category to categories; the migrations say categorys
+	Fields []goORMField
+}
+
 func renderGoORMModels(models []DataModel) string {
 	const tpl = `package models
 
-{{ range .Models -}}
+{{ range . -}}
 type {{ .Name }} struct {
 {{- range .Fields }}
-	{{ goFieldName .Name }} {{ goType .Type }} ` + "`json:\"{{ .Name }}\" gorm:\"column:{{ .Name }}\"`" + `
+	{{ .Name }} {{ .Type }} ` + "`{{ .Tags }}`" + `
 {{- end }}
 }
 
+func ({{ .Name }}) TableName() string { return "{{ .Table }}" }
+
 {{ end -}}
 `
-	return renderModelTemplate(tpl, models, template.FuncMap{
-		"goType":      goType,
-		"goFieldName": func(v string) string { return toPascal(v) },
-	})
+	resolved := resolvedModels(models)
+	views := make([]goORMModel, 0, len(resolved))
+	for _, m := range resolved {
+		view := goORMModel{Name: m.Name, Table: modelTable(m), Fields: []goORMField{{Name: "ID", Type: "int", Tags: `json:"id" gorm:"primaryKey;column:id"`}}}
+		for _, c := range storedColumns(m) {
+			view.Fields = append(view.Fields, goORMField{
+				Name: c.GoName,
+				Type: goType(c.Type),
+				Tags: fmt.Sprintf(`json:"%s" gorm:"%s"`, c.Name, gormColumnTag(m, c)),
+			})
+		}
+		for _, r := range parentRelations(m) {
+			view.Fields = append(view.Fields, goORMField{
+				Name: r.Model,
+				Type: "*" + r.Model,
+				Tags: fmt.Sprintf(`json:"%s,omitempty" gorm:"foreignKey:%s"`, toSnake(r.Model), foreignKeyGoName(m, r.Model)),
+			})
+		}
+		for _, child := range childModels(resolved, m) {
+			view.Fields = append(view.Fields, goORMField{
+				Name: child + "s",
+				Type: "[]" + child,
+				Tags: fmt.Sprintf(`json:"%ss,omitempty" gorm:"foreignKey:%s"`, toSnake(child), foreignKeyGoName(findModel(resolved, child), m.Name)),
+			})
+		}
+		for _, jt := range joinTables(resolved, m) {
+			view.Fields = append(view.Fields, goORMField{
+				Name: jt.Other + "s",
+				Type: "[]" + jt.Other,
+				Tags: fmt.Sprintf(`json:"%ss,omitempty" gorm:"many2many:%s"`, toSnake(jt.Other), jt.Name),
+			})
+		}
+		views = append(views, view)
+	}
+
+	t, err := template.New("gorm").Parse(tpl)
+	if err != nil {
+		return ""
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, views); err != nil {
+		return ""
+	}
+	return b.String()
+}
+
+// gormColumnTag is the gorm struct tag body for one column, shared by the ORM
+// models and the clean-architecture domain template.
+func gormColumnTag(m DataModel, c modelColumn) string {
+	parts := []string{"column:" + c.Name}
+	switch {
+	case isDateTimeField(c.DataField):
+		parts = append(parts, "type:timestamp")
+	case isStringField(c.DataField):
+		parts = append(parts, fmt.Sprintf("size:%d", maxLength(c.DataField)))
+	}
+	if c.Required {
+		parts = append(parts, "not null")
+	}
+	if c.Unique {
+		parts = append(parts, "unique")
+	}
+	if c.Default != "" {
+		parts = append(parts, "default:"+gormDefault(c.DataField))
+	}
+	if len(c.Enum) > 0 {
+		parts = append(parts, fmt.Sprintf("check:chk_%s_%s,%s IN (%s)", modelTable(m), c.Name, c.Name, sqlStringList(c.Enum)))
+	}
+	for _, idx := range m.Indexes {
+		if !slices.Contains(indexColumns(idx), c.Name) {
+			continue
+		}
+		kind := "index"
+		if idx.Unique {
+			kind = "uniqueIndex"
+		}
+		parts = append(parts, kind+":"+indexName(modelTable(m), idx))
+	}
+	return strings.Join(parts, ";")
+}
+
+func gormDefault(f DataField) string {
+	switch {
+	case isDateTimeField(f):
+		return "(CURRENT_TIMESTAMP)"
+	case f.Type == "bool" || f.Type == "boolean":
+		b, _ := strconv.ParseBool(f.Default)
+		return strconv.FormatBool(b)
+	}
+	return f.Default
+}
+
+// foreignKeyGoName is the Go field holding m's foreign key to parent.
+func foreignKeyGoName(m DataModel, parent string) string {
+	col := foreignKeyColumn(parent)
+	for _, c := range modelColumns(m) {
+		if c.Name == col {
+			return c.GoName
+		}
+	}
+	return parent + "ID"
+}
+
+func findModel(models []DataModel, name string) DataModel {
+	for _, m := range models {
+		if m.Name == name {
+			return m
+		}
+	}
+	return DataModel{Name: name}
+}
+
+type prismaEnum struct {
+	Name   string
+	Values []string
+}
+
+type prismaModel struct {
+	Name  string
+	Lines []string
 }
 
 func renderPrismaSchema(db string, models []DataModel) string {
 	provider := "postgresql"
-	if db == "mysql" {
-		provider = "mysql"
+	if db == "mysql" || db == "sqlite" {
+		provider = db
 	}
 	const tpl = `generator client {
   provider = "prisma-client-js"
@@ -75,23 +230,79 @@ datasource db {
   url      = env("DATABASE_URL")
 }
 
+{{ range .Enums -}}
+enum {{ .Name }} {
+{{- range .Values }}
+  {{ . }}
+{{- end }}
+}
+
+{{ end -}}
 {{ range .Models -}}
 model {{ .Name }} {
-{{- range .Fields }}
-  {{ prismaFieldName .Name }} {{ prismaType .Type }}
+{{- range .Lines }}
+  {{ . }}
 {{- end }}
 }
 
 {{ end -}}
 `
+	resolved := resolvedModels(models)
 	data := struct {
 		Provider string
-		Models   []DataModel
-	}{Provider: provider, Models: resolvedModels(models)}
-	t, err := template.New("prisma").Funcs(template.FuncMap{
-		"prismaType":      prismaType,
-		"prismaFieldName": prismaFieldName,
-	}).Parse(tpl)
+		Enums    []prismaEnum
+		Models   []prismaModel
+	}{Provider: provider}
+	for _, m := range resolved {
+		view := prismaModel{Name: m.Name, Lines: []string{"id Int @id @default(autoincrement())"}}
+		for _, c := range storedColumns(m) {
+			typ := prismaType(c.Type)
+			if len(c.Enum) > 0 {
+				typ = m.Name + toPascal(c.Name)
+				data.Enums = append(data.Enums, prismaEnum{Name: typ, Values: c.Enum})
+			}
+			line := c.Name + " " + typ
+			if !c.Required {
+				line += "?"
+			}
+			if c.Unique {
+				line += " @unique"
+			}
+			if c.Default != "" {
+				line += " @default(" + prismaDefault(c.DataField) + ")"
+			}
+			// SQLite has no native types to size a string column with.
+			if c.MaxLength > 0 && len(c.Enum) == 0 && db != "sqlite" {
+				line += fmt.Sprintf(" @db.VarChar(%d)", c.MaxLength)
+			}
+			view.Lines = append(view.Lines, line)
+		}
+		for _, r := range parentRelations(m) {
+			optional := "?"
+			if r.Required {
+				optional = ""
+			}
+			view.Lines = append(view.Lines, fmt.Sprintf("%s %s%s @relation(fields: [%s], references: [id])",
+				lowerFirst(r.Model), r.Model, optional, foreignKeyColumn(r.Model)))
+		}
+		for _, child := range childModels(resolved, m) {
+			view.Lines = append(view.Lines, fmt.Sprintf("%ss %s[]", lowerFirst(child), child))
+		}
+		for _, jt := range joinTables(resolved, m) {
+			view.Lines = append(view.Lines, fmt.Sprintf("%ss %s[]", lowerFirst(jt.Other), jt.Other))
+		}
+		for _, idx := range m.Indexes {
+			attr := "@@index"
+			if idx.Unique {
+				attr = "@@unique"
+			}
+			view.Lines = append(view.Lines, fmt.Sprintf("%s([%s])", attr, strings.Join(indexColumns(idx), ", ")))
+		}
+		view.Lines = append(view.Lines, fmt.Sprintf("@@map(%q)", modelTable(m)))
+		data.Models = append(data.Models, view)
+	}
+
+	t, err := template.New("prisma").Parse(tpl)
 	if err != nil {
 		return ""
 	}
@@ -102,41 +313,308 @@ model {{ .Name }} {
 	return b.String()
 }
 
+func prismaDefault(f DataField) string {
+	switch {
+	case len(f.Enum) > 0:
+		return f.Default
+	case isDateTimeField(f):
+		return "now()"
+	case f.Type == "bool" || f.Type == "boolean":
+		b, _ := strconv.ParseBool(f.Default)
+		return strconv.FormatBool(b)
+	case isStringField(f):
+		return strconv.Quote(f.Default)
+	}
+	return f.Default
+}
+
+type sqlalchemyModel struct {
+	Name      string
+	Table     string
+	TableArgs string
+	Lines     []string
+}
+
+type sqlalchemyJoin struct {
+	Name                  string
+	Left, Right           string // column names
+	LeftTable, RightTable string
+}
+
 func renderSQLAlchemyModels(models []DataModel) string {
-	const tpl = `from sqlalchemy.orm import DeclarativeBase, Mapped, mapped_column
+	const tpl = `from typing import List, Optional
+
+from sqlalchemy.orm import DeclarativeBase, Mapped, mapped_column, relationship
+from sqlalchemy import Column, Enum, ForeignKey, Index, Table, func
 from sqlalchemy import Integer, String, Boolean, Float, DateTime
 
 class Base(DeclarativeBase):
     pass
 
+{{ range .Joins -}}
+{{ .Name }} = Table(
+    "{{ .Name }}",
+    Base.metadata,
+    Column("{{ .Left }}", ForeignKey("{{ .LeftTable }}.id"), primary_key=True),
+    Column("{{ .Right }}", ForeignKey("{{ .RightTable }}.id"), primary_key=True),
+)
+
+{{ end -}}
 {{ range .Models -}}
 class {{ .Name }}(Base):
-    __tablename__ = "{{ tableName .Name }}"
-{{- range .Fields }}
-    {{ .Name }}: Mapped[{{ pyHint .Type }}] = mapped_column({{ sqlalchemyType .Type }})
+    __tablename__ = "{{ .Table }}"
+{{- if .TableArgs }}
+    __table_args__ = ({{ .TableArgs }},)
+{{- end }}
+{{- range .Lines }}
+    {{ . }}
 {{- end }}
 
 {{ end -}}
 `
-	return renderModelTemplate(tpl, models, template.FuncMap{
-		"sqlalchemyType": sqlalchemyType,
-		"tableName":      func(v string) string { return strings.ToLower(v) + "s" },
-		"pyHint":         pythonHint,
-	})
-}
+	resolved := resolvedModels(models)
+	data := struct {
+		Joins  []sqlalchemyJoin
+		Models []sqlalchemyModel
+	}{}
+	for _, pair := range allJoinTables(resolved) {
+		data.Joins = append(data.Joins, sqlalchemyJoin{
+			Name:       joinTableName(pair[0], pair[1]),
+			Left:       foreignKeyColumn(pair[0].Name),
+			Right:      foreignKeyColumn(pair[1].Name),
+			LeftTable:  modelTable(pair[0]),
+			RightTable: modelTable(pair[1]),
+		})
+	}
+	for _, m := range resolved {
+		view := sqlalchemyModel{
+			Name:  m.Name,
+			Table: modelTable(m),
+			Lines: []string{"id: Mapped[int] = mapped_column(Integer, primary_key=True)"},
+		}
+		var indexes []string
+		for _, idx := range m.Indexes {
+			args := []string{strconv.Quote(indexName(view.Table, idx))}
+			for _, col := range indexColumns(idx) {
+				args = append(args, strconv.Quote(col))
+			}
+			if idx.Unique {
+				args = append(args, "unique=True")
+			}
+			indexes = append(indexes, "Index("+strings.Join(args, ", ")+")")
+		}
+		view.TableArgs = strings.Join(indexes, ", ")
+
+		for _, c := range storedColumns(m) {
+			hint := pythonHint(c.Type)
+			if !c.Required {
+				hint = "Optional[" + hint + "]"
+			}
+			args := []string{sqlalchemyColumnType(view.Table, c.DataField)}
+			if c.References != "" {
+				args = append(args, fmt.Sprintf("ForeignKey(%q)", modelTable(findModel(resolved, c.References))+".id"))
+			}
+			args = append(args, "nullable="+pythonBool(!c.Required))
+			if c.Unique {
+				args = append(args, "unique=True")
+			}
+			switch {
+			case c.Default != "" && isDateTimeField(c.DataField):
+				args = append(args, "server_default=func.now()")
+			case c.Default != "":
+				args = append(args, "default="+pythonLiteral(c.DataField))
+			}
+			view.Lines = append(view.Lines, fmt.Sprintf("%s: Mapped[%s] = mapped_column(%s)", c.Name, hint, strings.Join(args, ", ")))
+		}
+		for _, r := range parentRelations(m) {
+			hint := fmt.Sprintf("Optional[%q]", r.Model)
+			if r.Required {
+				hint = strconv.Quote(r.Model)
+			}
+			view.Lines = append(view.Lines, fmt.Sprintf("%s: Mapped[%s] = relationship(back_populates=%q)", toSnake(r.Model), hint, toSnake(m.Name)+"s"))
+		}
+		for _, child := range childModels(resolved, m) {
+			view.Lines = append(view.Lines, fmt.Sprintf("%ss: Mapped[List[%q]] = relationship(back_populates=%q)", toSnake(child), child, toSnake(m.Name)))
+		}
+		for _, jt := range joinTables(resolved, m) {
+			view.Lines = append(view.Lines, fmt.Sprintf("%ss: Mapped[List[%q]] = relationship(secondary=%s, back_populates=%q)", toSnake(jt.Other), jt.Other, jt.Name, toSnake(m.Name)+"s"))
+		}
+		data.Models = append(data.Models, view)
+	}
 
-func renderModelTemplate(tpl string, models []DataModel, funcs template.FuncMap) string {
-	t, err := template.New("models").Funcs(funcs).Parse(tpl)
+	t, err := template.New("sqlalchemy").Parse(tpl)
 	if err != nil {
 		return ""
 	}
 	var b bytes.Buffer
-	if err := t.Execute(&b, modelTemplateData{Models: resolvedModels(models)}); err != nil {
+	if err := t.Execute(&b, data); err != nil {
 		return ""
 	}
 	return b.String()
 }
 
+func sqlalchemyColumnType(table string, f DataField) string {
+	switch {
+	case len(f.Enum) > 0:
+		values := make([]string, 0, len(f.Enum))
+		for _, v := range f.Enum {
+			values = append(values, strconv.Quote(v))
+		}
+		return fmt.Sprintf("Enum(%s, name=%q, native_enum=False, create_constraint=True)", strings.Join(values, ", "), table+"_"+strings.ToLower(f.Name))
+	case isStringField(f):
+		return fmt.Sprintf("String(%d)", maxLength(f))
+	}
+	return sqlalchemyType(f.Type)
+}
+
+// renderPydanticModel renders the request/response schema for one model,
+// honouring required, default, max_length and enum values.
+func renderPydanticModel(model DataModel) string {
+	var fields strings.Builder
+	needOptional, needLiteral, needField := false, false, false
+	for _, c := range modelColumns(model) {
+		hint := pythonHint(c.Type)
+		if len(c.Enum) > 0 {
+			values := make([]string, 0, len(c.Enum))
+			for _, v := range c.Enum {
+				values = append(values, strconv.Quote(v))
+			}
+			hint = "Literal[" + strings.Join(values, ", ") + "]"
+			needLiteral = true
+		}
+		def := ""
+		switch {
+		case c.Default != "" && !isDateTimeField(c.DataField):
+			def = pythonLiteral(c.DataField)
+		case !c.Required:
+			def = "None"
+		}
+		if !c.Required {
+			hint = "Optional[" + hint + "]"
+			needOptional = true
+		}
+		line := "    " + c.Name + ": " + hint
+		switch {
+		case c.MaxLength > 0 && len(c.Enum) == 0 && def != "":
+			line += fmt.Sprintf(" = Field(default=%s, max_length=%d)", def, c.MaxLength)
+			needField = true
+		case c.MaxLength > 0 && len(c.Enum) == 0:
+			line += fmt.Sprintf(" = Field(max_length=%d)", c.MaxLength)
+			needField = true
+		case def != "":
+			line += " = " + def
+		}
+		fields.WriteString(line + "\n")
+	}
+
+	var b strings.Builder
+	var typing []string
+	if needLiteral {
+		typing = append(typing, "Literal")
+	}
+	if needOptional {
+		typing = append(typing, "Optional")
+	}
+	if len(typing) > 0 {
+		b.WriteString("from typing import " + strings.Join(typing, ", ") + "\n\n")
+	}
+	if needField {
+		b.WriteString("from pydantic import BaseModel, Field\n")
+	} else {
+		b.WriteString("from pydantic import BaseModel\n")
+	}
+	b.WriteString("\n\nclass " + model.Name + "(BaseModel):\n" + fields.String())
+	return b.String()
+}
+
+// renderZodSchema renders the zod schema validating a model's request body.
+// The id is assigned by the server, so it is not part of the schema.
+func renderZodSchema(model DataModel) string {
+	var b strings.Builder
+	b.WriteString("import { z } from 'zod';\n\n")
+	b.WriteString("export const " + lowerFirst(model.Name) + "Schema = z.object({\n")
+	for _, c := range storedColumns(model) {
+		var expr string
+		switch {
+		case len(c.Enum) > 0:
+			values := make([]string, 0, len(c.Enum))
+			for _, v := range c.Enum {
+				values = append(values, "'"+v+"'")
+			}
+			expr = "z.enum([" + strings.Join(values, ", ") + "])"
+		case c.Type == "int" || c.Type == "integer":
+			expr = "z.number().int()"
+		case c.Type == "float" || c.Type == "float64" || c.Type == "double":
+			expr = "z.number()"
+		case c.Type == "bool" || c.Type == "boolean":
+			expr = "z.boolean()"
+		case isDateTimeField(c.DataField):
+			expr = "z.coerce.date()"
+		default:
+			expr = "z.string()"
+			if c.MaxLength > 0 {
+				expr += fmt.Sprintf(".max(%d)", c.MaxLength)
+			}
+		}
+		switch {
+		case c.Default != "" && !isDateTimeField(c.DataField):
+			expr += ".default(" + jsLiteral(c.DataField) + ")"
+		case !c.Required:
+			expr += ".nullish()"
+		}
+		b.WriteString("  " + c.Name + ": " + expr + ",\n")
+	}
+	b.WriteString("});\n")
+	return b.String()
+}
+
+func pythonLiteral(f DataField) string {
+	switch {
+	case f.Type == "bool" || f.Type == "boolean":
+		if b, _ := strconv.ParseBool(f.Default); b {
+			return "True"
+		}
+		return "False"
+	case isStringField(f):
+		return strconv.Quote(f.Default)
+	}
+	return f.Default
+}
+
+func jsLiteral(f DataField) string {
+	switch {
+	case f.Type == "bool" || f.Type == "boolean":
+		b, _ := strconv.ParseBool(f.Default)
+		return strconv.FormatBool(b)
+	case isStringField(f):
+		return strconv.Quote(f.Default)
+	}
+	return f.Default
+}
+
+func pythonBool(b bool) string {
+	if b {
+		return "True"
+	}
+	return "False"
+}
+
+// sqlStringList renders values as a comma-separated list of SQL string literals.
+func sqlStringList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, "'"+strings.ReplaceAll(v, "'", "''")+"'")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
 func goType(v string) string {
 	switch strings.ToLower(strings.TrimSpace(v)) {
 	case "int", "integer":
@@ -167,14 +645,6 @@ func prismaType(v string) string {
 	}
 }
 
-func prismaFieldName(v string) string {
-	name := strings.TrimSpace(v)
-	if strings.EqualFold(name, "id") {
-		return "id Int @id @default(autoincrement())"
-	}
-	return name
-}
-
 func sqlalchemyType(v string) string {
 	switch strings.ToLower(strings.TrimSpace(v)) {
 	case "int", "integer":
@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// richModels is a blog schema exercising every model property: User has many
+// Posts, Posts and Tags are many-to-many, and Post carries constraints, an
+// enum and a composite index.
+func richModels() []DataModel {
+	return []DataModel{
+		{
+			Name:      "Post",
+			Fields:    []DataField{{Name: "title", Type: "string", Required: true, Unique: true, MaxLength: 120}, {Name: "status", Type: "enum", Enum: []string{"draft", "published"}, Default: "draft"}, {Name: "views", Type: "int", Default: "0"}},
+			Indexes:   []ModelIndex{{Fields: []string{"status", "user_id"}}},
+			Relations: []ModelRelation{{Kind: "many_to_many", Model: "Tag"}},
+		},
+		{Name: "Tag", Fields: []DataField{{Name: "label", Type: "string", Required: true}}},
+		{
+			Name:      "User",
+			Fields:    []DataField{{Name: "email", Type: "string", Required: true, Unique: true}},
+			Relations: []ModelRelation{{Kind: "has_many", Model: "Post", Required: true}},
+		},
+	}
+}
+
+func assertContainsAll(t *testing.T, what, got string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		if !strings.Contains(got, w) {
+			t.Errorf("%s missing %q:\n%s", what, w, got)
+		}
+	}
+}
+
+func TestRenderRichModels(t *testing.T) {
+	models := resolvedModels(richModels())
+
+	assertContainsAll(t, "gorm models", renderGoORMModels(models),
+		"Title string `json:\"title\" gorm:\"column:title;size:120;not null;unique\"`",
+		"check:chk_posts_status,status IN ('draft', 'published')",
+		"UserID int `json:\"user_id\" gorm:\"column:user_id;not null;index:idx_posts_status_user_id\"`",
+		"User *User `json:\"user,omitempty\" gorm:\"foreignKey:UserID\"`",
+		"Posts []Post `json:\"posts,omitempty\" gorm:\"foreignKey:UserID\"`",
+		"many2many:posts_tags",
+		"func (Post) TableName() string { return \"posts\" }",
+	)
+
+	assertContainsAll(t, "prisma schema", renderPrismaSchema("postgresql", models),
+		"enum PostStatus {\n  draft\n  published\n}",
+		"status PostStatus? @default(draft)",
+		"title String @unique @db.VarChar(120)",
+		"user User @relation(fields: [user_id], references: [id])",
+		"@@index([status, user_id])",
+		"tags Tag[]",
+		"@@map(\"posts\")",
+	)
+
+	assertContainsAll(t, "sqlalchemy models", renderSQLAlchemyModels(models),
+		"posts_tags = Table(",
+		"ForeignKey(\"users.id\"), nullable=False",
+		"secondary=posts_tags",
+		"Enum(\"draft\", \"published\", name=\"posts_status\", native_enum=False, create_constraint=True)",
+	)
+
+	ddl := renderSQLTablesTemplate("postgresql", models, true)
+	assertContainsAll(t, "sql ddl", ddl,
+		`"title" VARCHAR(120) NOT NULL UNIQUE`,
+		`"status" VARCHAR(255) DEFAULT 'draft' CHECK ("status" IN ('draft', 'published'))`,
+		`"user_id" INTEGER NOT NULL REFERENCES "users" ("id")`,
+		`CREATE INDEX IF NOT EXISTS "idx_posts_status_user_id" ON "posts" ("status", "user_id");`,
+		`PRIMARY KEY ("post_id", "tag_id")`,
+	)
+	if strings.Index(ddl, `CREATE TABLE IF NOT EXISTS "users"`) > strings.Index(ddl, `CREATE TABLE IF NOT EXISTS "posts"`) {
+		t.Errorf("users must be created before posts, which reference it:\n%s", ddl)
+	}
+
+	var post DataModel
+	for _, m := range models {
+		if m.Name == "Post" {
+			post = m
+		}
+	}
+	assertContainsAll(t, "pydantic model", renderPydanticModel(post),
+		"title: str = Field(max_length=120)",
+		"status: Optional[Literal[\"draft\", \"published\"]] = \"draft\"",
+		"user_id: int",
+	)
+	assertContainsAll(t, "zod schema", renderZodSchema(post),
+		"export const postSchema = z.object({",
+		"title: z.string().max(120),",
+		"status: z.enum(['draft', 'published']).default(\"draft\"),",
+	)
+}
+
+func TestRelationDeclaredFromBothSidesKeepsRequired(t *testing.T) {
+	post := DataModel{Name: "Post", Fields: []DataField{{Name: "title", Type: "string"}}}
+	user := DataModel{Name: "User", Fields: []DataField{{Name: "email", Type: "string"}}}
+	sides := map[string][2]ModelRelation{
+		"required belongs_to": {{Kind: "belongs_to", Model: "User", Required: true}, {Kind: "has_many", Model: "Post"}},
+		"required has_many":   {{Kind: "belongs_to", Model: "User"}, {Kind: "has_many", Model: "Post", Required: true}},
+	}
+	for name, rels := range sides {
+		for _, hasManyFirst := range []bool{false, true} {
+			p, u := post, user
+			p.Relations = []ModelRelation{rels[0]}
+			u.Relations = []ModelRelation{rels[1]}
+			models := []DataModel{p, u}
+			if hasManyFirst {
+				models = []DataModel{u, p}
+			}
+			if errs := ValidateAll(GenerateRequest{Language: "go", Framework: "gin", Architecture: "mvp", Database: "postgresql", Root: RootOptions{Mode: "new", Name: "demo"}, Custom: CustomOptions{Models: models}}); len(errs) != 0 {
+				t.Fatalf("%s: both sides should validate, got %v", name, errs)
+			}
+			what := fmt.Sprintf("%s (has_many first: %v)", name, hasManyFirst)
+			assertContainsAll(t, what+" ddl", renderSQLTablesTemplate("postgresql", models, false), `"user_id" INTEGER NOT NULL REFERENCES "users" ("id")`)
+			if schema := renderPrismaSchema("postgresql", models); strings.Contains(schema, "user_id Int?") {
+				t.Errorf("%s: prisma foreign key is optional:\n%s", what, schema)
+			}
+		}
+	}
+}
+
+func TestDeclaredTableNames(t *testing.T) {
+	models := richModels()
+	models[1].Table = "categories"
+	models[2].Table = "accounts"
+
+	assertContainsAll(t, "ddl", renderSQLTablesTemplate("postgresql", models, false),
+		`CREATE TABLE IF NOT EXISTS "categories" (`,
+		`"user_id" INTEGER NOT NULL REFERENCES "accounts" ("id")`,
+		`CREATE TABLE IF NOT EXISTS "categories_posts" (`,
+		`"tag_id" INTEGER NOT NULL REFERENCES "categories" ("id")`,
+	)
+	assertContainsAll(t, "sqlalchemy models", renderSQLAlchemyModels(models),
+		`__tablename__ = "categories"`,
+		`ForeignKey("accounts.id")`,
+	)
+	if got := renderGoORMModels(models); !strings.Contains(got, `return "categories"`) {
+		t.Errorf("gorm models do not use the declared table:\n%s", got)
+	}
+}
+
+func TestValidateModels(t *testing.T) {
+	models := richModels()
+	models[0].Fields = append(models[0].Fields,
+		DataField{Name: "kind", Type: "enum"},
+		DataField{Name: "rank", Type: "int", Enum: []string{"a"}},
+		DataField{Name: "score", Type: "float", Default: "high"},
+	)
+	models[0].Indexes = append(models[0].Indexes, ModelIndex{Fields: []string{"missing"}})
+	models[1].Relations = []ModelRelation{{Kind: "has_one", Model: "Post"}, {Kind: "belongs_to", Model: "Comment"}, {Kind: "belongs_to", Model: "Post"}}
+	models[1].Table = "Tag List"
+	models[2].Table = "posts"
+
+	v := &validator{}
+	validateModels(v, models)
+	got := map[string]string{}
+	for _, e := range v.errs {
+		got[e.Pointer] = e.Code
+	}
+	expected := map[string]string{
+		"/custom/models/0/fields/3/enum":      "ENUM_VALUES_REQUIRED",
+		"/custom/models/0/fields/4/enum":      "ENUM_TYPE_INVALID",
+		"/custom/models/0/fields/5/default":   "DEFAULT_INVALID",
+		"/custom/models/0/indexes/1/fields/0": "INDEX_FIELD_UNKNOWN",
+		"/custom/models/1/relations/0/kind":   "RELATION_KIND_INVALID",
+		"/custom/models/1/relations/1/model":  "RELATION_TARGET_UNKNOWN",
+		"/custom/models/1/relations/2":        "RELATION_DUPLICATE",
+		"/custom/models/1/table":              "TABLE_NAME_INVALID",
+		"/custom/models/2/table":              "TABLE_DUPLICATE",
+	}
+	for pointer, code := range expected {
+		if got[pointer] != code {
+			t.Errorf("pointer %s: got code %q, want %q", pointer, got[pointer], code)
+		}
+	}
+	if len(v.errs) != len(expected) {
+		t.Errorf("got %d errors, want %d: %+v", len(v.errs), len(expected), v.errs)
+	}
+}
@@ -8,6 +8,18 @@ import (
 
 type NodeGenerator struct{}
 
+func init() {
+	RegisterLanguage(LanguageSpec{
+		ID:            "node",
+		Frameworks:    []string{"express", "fastify"},
+		Architectures: standardArchitectures,
+		Infra:         standardInfra,
+		Features:      standardFeatures,
+		FileToggles:   standardFileToggles,
+		New:           func() Generator { return &NodeGenerator{} },
+	})
+}
+
 func (g *NodeGenerator) GenerateArchitecture(req *GenerateRequest, ctx *GenerationContext) error {
 	if req.Architecture == "microservices" {
 		for _, svc := range req.Services {
@@ -24,9 +36,6 @@ func (g *NodeGenerator) GenerateArchitecture(req *GenerateRequest, ctx *Generati
 			if isEnabled(req.FileToggles.HealthCheck) || req.Features.Health {
 				addFile(ctx.FileTree, path.Join(svcRoot, "src/routes/health.js"), "export default function health(req, res) { res.send({ status: 'ok' }); }\n")
 			}
-			if req.Features.JWTAuth {
-				addFile(ctx.FileTree, path.Join(svcRoot, "src/auth/jwt.js"), "export const jwtSecret = process.env.JWT_SECRET || 'changeme';\n")
-			}
 			if strings.EqualFold(req.ServiceCommunication, "grpc") {
 				g.addGRPCBoilerplate(ctx.FileTree, req, svcRoot)
 			}
@@ -44,9 +53,6 @@ func (g *NodeGenerator) GenerateArchitecture(req *GenerateRequest, ctx *Generati
 		if isEnabled(req.FileToggles.HealthCheck) || req.Features.Health {
 			addFile(ctx.FileTree, "src/routes/health.js", "export default function health(req, res) { res.send({ status: 'ok' }); }\n")
 		}
-		if req.Features.JWTAuth {
-			addFile(ctx.FileTree, "src/auth/jwt.js", "export const jwtSecret = process.env.JWT_SECRET || 'changeme';\n")
-		}
 		if strings.EqualFold(req.ServiceCommunication, "grpc") {
 			addFile(ctx.FileTree, "proto/README.md", "# Shared proto definitions\n\nPlace your protobuf contracts here.\n")
 			addFile(ctx.FileTree, "proto/common.proto", "syntax = \"proto3\";\npackage stacksprint;\n\nservice InternalService {\n  rpc Ping(PingRequest) returns (PingReply);\n}\n\nmessage PingRequest {\n  string source = 1;\n}\n\nmessage PingReply {\n  string message = 1;\n}\n")
@@ -84,13 +90,6 @@ func (g *NodeGenerator) GenerateModels(req *GenerateRequest, ctx *GenerationCont
 
 func (g *NodeGenerator) GenerateInfra(req *GenerateRequest, ctx *GenerationContext) error {
 	handleInfra := func(root string, port int) {
-		if req.Infra.Redis {
-			addFile(ctx.FileTree, path.Join(root, "src/cache/redis.js"), "export class RedisCache {\n  constructor(addr = process.env.REDIS_ADDR || 'redis:6379') {\n    this.addr = addr;\n  }\n\n  ping() {\n    return `redis configured at ${this.addr}`;\n  }\n}\n")
-		}
-		if req.Infra.Kafka {
-			addFile(ctx.FileTree, path.Join(root, "src/messaging/kafkaProducer.js"), "export class KafkaProducer {\n  constructor(brokers = process.env.KAFKA_BROKERS || 'kafka:9092') {\n    this.brokers = brokers;\n  }\n\n  publish(topic, payload) {\n    return `publish stub to ${topic} via ${this.brokers}: ${payload}`;\n  }\n}\n")
-			addFile(ctx.FileTree, path.Join(root, "src/messaging/kafkaConsumer.js"), "export class KafkaConsumer {\n  constructor(brokers = process.env.KAFKA_BROKERS || 'kafka:9092') {\n    this.brokers = brokers;\n  }\n\n  subscribe(topic) {\n    return `consumer stub subscribed to ${topic} via ${this.brokers}`;\n  }\n}\n")
-		}
 		if isEnabled(req.FileToggles.Env) {
 			svcName := ""
 			if root != "" {
@@ -115,6 +114,12 @@ func (g *NodeGenerator) GenerateInfra(req *GenerateRequest, ctx *GenerationConte
 	if isEnabled(req.FileToggles.Compose) {
 		addFile(ctx.FileTree, "docker-compose.yaml", buildCompose(*req))
 	}
+	if req.Features.Observability {
+		addObservabilityFiles(ctx.FileTree, *req)
+	}
+	if req.Features.Kubernetes {
+		addKubernetesFiles(ctx.FileTree, *req)
+	}
 	return nil
 }
 
@@ -123,7 +128,7 @@ func (g *NodeGenerator) GenerateDevTools(req *GenerateRequest, ctx *GenerationCo
 		addFile(ctx.FileTree, ".gitignore", "bin/\nobj/\n.env\n.DS_Store\nnode_modules/\nvendor/\n__pycache__/\n*.sqlite3\n")
 	}
 	if isEnabled(req.FileToggles.Readme) {
-		addFile(ctx.FileTree, "README.md", fmt.Sprintf("# StackSprint Generated Project\n\nLanguage: %s\nFramework: %s\nArchitecture: %s\nDatabase: %s\n\n## Run\n\n```bash\ndocker compose up --build\n```\n", req.Language, req.Framework, req.Architecture, req.Database))
+		addFile(ctx.FileTree, "README.md", fmt.Sprintf("# StackSprint Generated Project\n\nLanguage: %s\nFramework: %s\nArchitecture: %s\nDatabase: %s\n\n## Run\n\n```bash\ndocker compose up --build\n```\n", req.Language, req.Framework, req.Architecture, req.Database)+rbacReadmeSection(*req)+observabilityReadmeSection(*req)+cacheReadmeSection(*req)+messagingReadmeSection(*req)+kubernetesReadmeSection(*req))
 	}
 	if req.Features.GitHubActions {
 		addFile(ctx.FileTree, ".github/workflows/ci.yaml", "name: CI\n\non:\n  push:\n  pull_request:\n\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/checkout@v4\n      - uses: actions/setup-node@v4\n        with:\n          node-version: '22'\n      - run: npm test\n")
@@ -141,7 +146,14 @@ func (g *NodeGenerator) GenerateDevTools(req *GenerateRequest, ctx *GenerationCo
 		addFile(ctx.FileTree, "Makefile", b.String())
 	}
 	if req.Features.Swagger {
-		addFile(ctx.FileTree, "docs/openapi.yaml", "openapi: 3.0.3\ninfo:\n  title: StackSprint API\n  version: 1.0.0\npaths:\n  /health:\n    get:\n      responses:\n        '200':\n          description: OK\n")
+		addOpenAPISpecs(ctx.FileTree, *req, "/health", true)
+		if req.Architecture == "microservices" {
+			for _, svc := range req.Services {
+				addFile(ctx.FileTree, path.Join("services", svc.Name, "src/docs.js"), nodeDocsModule)
+			}
+		} else {
+			addFile(ctx.FileTree, "src/docs.js", nodeDocsModule)
+		}
 	}
 	return nil
 }
@@ -162,7 +174,10 @@ func (g *NodeGenerator) GetConfigWarnings(_ *GenerateRequest) []Warning {
 // -------------------------------------------------------------------------
 
 func (g *NodeGenerator) generateMonolithArch(req *GenerateRequest, ctx *GenerationContext, root string) error {
-	specs := nodeMonolithTemplateSpecs(*req)
+	manifest, err := ctx.Registry.Manifest(req.Language, req.Architecture)
+	if err != nil {
+		return err
+	}
 	data := map[string]any{
 		"Framework":    req.Framework,
 		"Architecture": req.Architecture,
@@ -172,45 +187,35 @@ func (g *NodeGenerator) generateMonolithArch(req *GenerateRequest, ctx *Generati
 		"UseORM":       req.UseORM,
 		"DBKind":       req.Database,
 		"Service":      "app",
+		"Auth":         newAuthData(*req),
+		"Messaging":    newMessagingData(*req, ""),
+		"Cache":        newCacheData(*req, ""),
 	}
-	if err := g.renderSpecs(ctx, specs, data, root); err != nil {
+	if err := g.renderSpecs(ctx, manifest.fileSpecs(*req), data, root); err != nil {
 		return err
 	}
 
-	if isEnabled(req.FileToggles.ExampleCRUD) {
-		var imports, routes strings.Builder
-		for _, model := range resolvedModels(req.Custom.Models) {
-			nameLow := strings.ToLower(model.Name)
-			if req.Architecture == "clean" {
-				imports.WriteString(fmt.Sprintf("import * as %sController from './controllers/%sController.js';\n", nameLow, nameLow))
-				routes.WriteString(fmt.Sprintf("app.get('/%ss', %sController.list%ssHandler);\napp.post('/%ss', %sController.create%sHandler);\n", nameLow, nameLow, model.Name, nameLow, nameLow, model.Name))
-			} else if req.Architecture == "hexagonal" {
-				imports.WriteString(fmt.Sprintf("import { list%ss, get%s, create%s } from './adapters/primary/http/%sController.js';\n", model.Name, model.Name, model.Name, nameLow))
-				routes.WriteString(fmt.Sprintf("app.get('/%ss', list%ss);\napp.get('/%ss/:id', get%s);\napp.post('/%ss', create%s);\n", nameLow, model.Name, nameLow, model.Name, nameLow, model.Name))
-			} else {
-				if req.Framework == "express" {
-					imports.WriteString(fmt.Sprintf("import %sRoutes from './routes/%ss.js';\n", nameLow, nameLow))
-					routes.WriteString(fmt.Sprintf("app.use('/%ss', %sRoutes);\n", nameLow, nameLow))
-				} else {
-					imports.WriteString(fmt.Sprintf("import %sRoutes from './routes/%ss.js';\n", nameLow, nameLow))
-					routes.WriteString(fmt.Sprintf("app.register(%sRoutes, { prefix: '/%ss' });\n", nameLow, nameLow))
-				}
-			}
-		}
-		if main, ok := ctx.FileTree.Files["src/index.js"]; ok {
+	if isEnabled(req.FileToggles.ExampleCRUD) || req.Features.Swagger || req.Features.JWTAuth || req.Features.Observability || usesMessaging(*req) || req.Infra.Redis {
+		imports, routes, shutdown := nodeEntrypointRoutes(req)
+		mainPath, found := manifest.routesTarget(ctx, *req, root)
+		if main, ok := ctx.FileTree.Files[mainPath]; found && ok {
 			var err error
-			main, err = InjectByMarker(main, "imports", imports.String())
+			main, err = InjectByMarker(main, "imports", imports)
 			if err != nil {
-				ctx.Warnings = append(ctx.Warnings, Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic imports", Reason: err.Error()})
+				ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic imports", Reason: err.Error(), Path: mainPath})
 			}
-			main, err = InjectByMarker(main, "routes", routes.String())
+			main, err = InjectByMarker(main, "routes", routes)
 			if err != nil {
-				ctx.Warnings = append(ctx.Warnings, Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic routes", Reason: err.Error()})
+				ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic routes", Reason: err.Error(), Path: mainPath})
 			}
-			ctx.FileTree.Files["src/index.js"] = main
+			main, err = InjectByMarker(main, "shutdown", shutdown)
+			if err != nil {
+				ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject shutdown hooks", Reason: err.Error(), Path: mainPath})
+			}
+			ctx.FileTree.Files[mainPath] = main
 		}
 	}
-	addFile(ctx.FileTree, "package.json", nodePackageJSON(req.Framework, req.Database, req.UseORM))
+	addFile(ctx.FileTree, "package.json", nodePackageJSON(req.Framework, req.Database, req.UseORM, req.Features.JWTAuth, req.Features.Observability, req.Infra))
 	if isEnabled(req.FileToggles.Config) {
 		addFile(ctx.FileTree, "src/config/index.js", "import dotenv from 'dotenv';\nimport { z } from 'zod';\n\ndotenv.config();\n\nconst envSchema = z.object({\n  PORT: z.string().transform(Number).default('8080'),\n  DATABASE_URL: z.string().url().optional(),\n  JWT_SECRET: z.string().min(8).default('default_dev_secret_replace_in_prod'),\n});\n\nconst parsed = envSchema.safeParse(process.env);\nif (!parsed.success) {\n  console.error('❌ Invalid environment variables:', parsed.error.format());\n  process.exit(1);\n}\n\nexport const config = {\n  port: parsed.data.PORT,\n  dbUrl: parsed.data.DATABASE_URL || '',\n  jwtSecret: parsed.data.JWT_SECRET,\n};\n")
 	}
@@ -229,7 +234,10 @@ func (g *NodeGenerator) generateMonolithArch(req *GenerateRequest, ctx *Generati
 }
 
 func (g *NodeGenerator) generateServiceArch(req *GenerateRequest, ctx *GenerationContext, svcRoot string, svc ServiceConfig) error {
-	specs := nodeMicroserviceTemplateSpecs(*req)
+	manifest, err := ctx.Registry.Manifest(req.Language, req.Architecture)
+	if err != nil {
+		return err
+	}
 	data := map[string]any{
 		"Framework":    req.Framework,
 		"Architecture": req.Architecture,
@@ -239,44 +247,34 @@ func (g *NodeGenerator) generateServiceArch(req *GenerateRequest, ctx *Generatio
 		"UseORM":       req.UseORM,
 		"DBKind":       req.Database,
 		"Service":      svc.Name,
+		"Auth":         newAuthData(*req),
+		"Messaging":    newMessagingData(*req, svc.Name),
+		"Cache":        newCacheData(*req, svc.Name),
 	}
-	if err := g.renderSpecs(ctx, specs, data, svcRoot); err != nil {
+	if err := g.renderSpecs(ctx, manifest.fileSpecs(*req), data, svcRoot); err != nil {
 		return err
 	}
-	if isEnabled(req.FileToggles.ExampleCRUD) {
-		var imports, routes strings.Builder
-		for _, model := range resolvedModels(req.Custom.Models) {
-			nameLow := strings.ToLower(model.Name)
-			if req.Architecture == "clean" {
-				imports.WriteString(fmt.Sprintf("import * as %sController from './controllers/%sController.js';\n", nameLow, nameLow))
-				routes.WriteString(fmt.Sprintf("app.get('/%ss', %sController.list%ssHandler);\napp.post('/%ss', %sController.create%sHandler);\n", nameLow, nameLow, model.Name, nameLow, nameLow, model.Name))
-			} else if req.Architecture == "hexagonal" {
-				imports.WriteString(fmt.Sprintf("import { list%ss, get%s, create%s } from './adapters/primary/http/%sController.js';\n", model.Name, model.Name, model.Name, nameLow))
-				routes.WriteString(fmt.Sprintf("app.get('/%ss', list%ss);\napp.get('/%ss/:id', get%s);\napp.post('/%ss', create%s);\n", nameLow, model.Name, nameLow, model.Name, nameLow, model.Name))
-			} else {
-				if req.Framework == "express" {
-					imports.WriteString(fmt.Sprintf("import %sRoutes from './routes/%ss.js';\n", nameLow, nameLow))
-					routes.WriteString(fmt.Sprintf("app.use('/%ss', %sRoutes);\n", nameLow, nameLow))
-				} else {
-					imports.WriteString(fmt.Sprintf("import %sRoutes from './routes/%ss.js';\n", nameLow, nameLow))
-					routes.WriteString(fmt.Sprintf("app.register(%sRoutes, { prefix: '/%ss' });\n", nameLow, nameLow))
-				}
-			}
-		}
-		if main, ok := ctx.FileTree.Files[path.Join(svcRoot, "src/index.js")]; ok {
+	if isEnabled(req.FileToggles.ExampleCRUD) || req.Features.Swagger || req.Features.JWTAuth || req.Features.Observability || usesMessaging(*req) || req.Infra.Redis {
+		imports, routes, shutdown := nodeEntrypointRoutes(req)
+		mainPath, found := manifest.routesTarget(ctx, *req, svcRoot)
+		if main, ok := ctx.FileTree.Files[mainPath]; found && ok {
 			var err error
-			main, err = InjectByMarker(main, "imports", imports.String())
+			main, err = InjectByMarker(main, "imports", imports)
+			if err != nil {
+				ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic imports for service " + svc.Name, Reason: err.Error(), Path: mainPath})
+			}
+			main, err = InjectByMarker(main, "routes", routes)
 			if err != nil {
-				ctx.Warnings = append(ctx.Warnings, Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic imports for service " + svc.Name, Reason: err.Error()})
+				ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic routes for service " + svc.Name, Reason: err.Error(), Path: mainPath})
 			}
-			main, err = InjectByMarker(main, "routes", routes.String())
+			main, err = InjectByMarker(main, "shutdown", shutdown)
 			if err != nil {
-				ctx.Warnings = append(ctx.Warnings, Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic routes for service " + svc.Name, Reason: err.Error()})
+				ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject shutdown hooks for service " + svc.Name, Reason: err.Error(), Path: mainPath})
 			}
-			ctx.FileTree.Files[path.Join(svcRoot, "src/index.js")] = main
+			ctx.FileTree.Files[mainPath] = main
 		}
 	}
-	addFile(ctx.FileTree, path.Join(svcRoot, "package.json"), nodePackageJSON(req.Framework, req.Database, req.UseORM))
+	addFile(ctx.FileTree, path.Join(svcRoot, "package.json"), nodePackageJSON(req.Framework, req.Database, req.UseORM, req.Features.JWTAuth, req.Features.Observability, req.Infra))
 
 	g.addNodeAutopilot(ctx.FileTree, req, svcRoot)
 	g.addNodeDBRetry(ctx.FileTree, req, svcRoot)
@@ -316,11 +314,20 @@ func (g *NodeGenerator) addNodeDBBoilerplate(tree *FileTree, req *GenerateReques
 		prefix += "/"
 	}
 	if req.UseORM {
-		addFile(tree, prefix+"prisma/schema.prisma", renderPrismaSchema(req.Database, req.Custom.Models))
+		schema := renderPrismaSchema(req.Database, req.Custom.Models)
+		if req.Features.JWTAuth {
+			schema += prismaAuthUserModel(req.Database)
+		}
+		addFile(tree, prefix+"prisma/schema.prisma", schema)
 		addFile(tree, prefix+"src/db/prismaClient.js", "import { PrismaClient } from '@prisma/client';\n\nexport const prisma = new PrismaClient();\n")
 		addFile(tree, prefix+"prisma/seed.js", renderNodeSeedScript(req.Custom.Models, true))
 		return
 	}
+	if req.Database == "sqlite" {
+		addFile(tree, prefix+"src/db/sqlClient.js", nodeSQLiteClient(req.Custom.Models))
+		addFile(tree, prefix+"scripts/seed.js", renderNodeSeedScript(req.Custom.Models, false))
+		return
+	}
 	if req.Database == "postgresql" {
 		addFile(tree, prefix+"src/db/sqlClient.js", "import pg from 'pg';\n\nconst { Pool } = pg;\nexport const db = new Pool({ connectionString: process.env.DATABASE_URL });\n")
 		addFile(tree, prefix+"scripts/seed.js", renderNodeSeedScript(req.Custom.Models, false))
@@ -330,13 +337,53 @@ func (g *NodeGenerator) addNodeDBBoilerplate(tree *FileTree, req *GenerateReques
 	addFile(tree, prefix+"scripts/seed.js", renderNodeSeedScript(req.Custom.Models, false))
 }
 
+// nodeSQLiteClient opens the better-sqlite3 database DATABASE_URL names.
+// There is no server to run db/init, so the client applies the schema itself;
+// every statement in it is IF NOT EXISTS.
+func nodeSQLiteClient(models []DataModel) string {
+	return "import Database from 'better-sqlite3';\nimport { mkdirSync } from 'node:fs';\nimport { dirname } from 'node:path';\n\n" +
+		"// The tables the models map to.\nconst schema = `" + sampleMigration("sqlite", models) + "`;\n\n" +
+		"const file = (process.env.DATABASE_URL || '" + sqliteURL + "').replace(/^file:/, '');\n" +
+		"mkdirSync(dirname(file), { recursive: true });\n\n" +
+		"export const db = new Database(file);\n" +
+		"db.pragma('journal_mode = WAL');\n" +
+		"db.pragma('foreign_keys = ON');\n" +
+		"db.exec(schema);\n\n" +
+		"/** Maps a value onto what better-sqlite3 binds: it rejects booleans, dates and undefined. */\n" +
+		"export function bindable(value) {\n" +
+		"  if (value === undefined) return null;\n" +
+		"  if (typeof value === 'boolean') return value ? 1 : 0;\n" +
+		"  if (value instanceof Date) return value.toISOString();\n" +
+		"  return value;\n" +
+		"}\n"
+}
+
+// prismaAuthUserModel backs src/auth/users.js; it maps to the same
+// auth_users table the other stacks create. SQLite has no VARCHAR native type.
+func prismaAuthUserModel(db string) string {
+	email, role := " @db.VarChar(255)", " @db.VarChar(64)"
+	if db == "sqlite" {
+		email, role = "", ""
+	}
+	return `model AuthUser {
+  id           Int      @id @default(autoincrement())
+  email        String   @unique` + email + `
+  passwordHash String   @map("password_hash")
+  role         String` + role + `
+  createdAt    DateTime @default(now()) @map("created_at")
+
+  @@map("auth_users")
+}
+`
+}
+
 func renderNodeSeedScript(models []DataModel, useORM bool) string {
 	var b strings.Builder
 	if useORM {
 		b.WriteString("import { PrismaClient } from '@prisma/client';\n")
 		b.WriteString("const prisma = new PrismaClient();\n\n")
 		b.WriteString("async function main() {\n")
-		for _, m := range resolvedModels(models) {
+		for _, m := range parentsFirst(resolvedModels(models)) {
 			low := strings.ToLower(m.Name)
 			sample := buildNodeSampleObject(m)
 			b.WriteString(fmt.Sprintf("  await prisma.%s.create({ data: %s });\n", low, sample))
@@ -347,7 +394,7 @@ func renderNodeSeedScript(models []DataModel, useORM bool) string {
 
 	b.WriteString("import { db } from '../src/db/sqlClient.js';\n\nasync function main() {\n")
 	for _, m := range resolvedModels(models) {
-		table := strings.ToLower(m.Name) + "s"
+		table := modelTable(m)
 		b.WriteString(fmt.Sprintf("  // Raw SQL seeding for %s (Implementation depends on the exact driver args)\n", table))
 		b.WriteString(fmt.Sprintf("  console.log('Seeding %s');\n", table))
 	}
@@ -373,12 +420,16 @@ func (g *NodeGenerator) buildNodeDomainClass(name string, model DataModel) strin
 func buildNodeSampleObject(model DataModel) string {
 	var b strings.Builder
 	b.WriteString("{")
-	for i, f := range model.Fields {
+	for i, f := range modelColumns(model) {
 		if i > 0 {
 			b.WriteString(", ")
 		}
-		fn := strings.ToLower(f.Name)
-		switch strings.ToLower(f.Type) {
+		fn := f.Name
+		if len(f.Enum) > 0 {
+			b.WriteString(fn + ": '" + f.Enum[0] + "'")
+			continue
+		}
+		switch f.Type {
 		case "int", "integer":
 			b.WriteString(fn + ": 1")
 		case "float", "float64", "double":
@@ -393,6 +444,355 @@ func buildNodeSampleObject(model DataModel) string {
 	return b.String()
 }
 
+// nodeDocsModule loads docs/openapi.yaml for the /docs routes.
+var nodeDocsModule = "import { readFileSync } from 'node:fs';\n\nexport const openapiSpec = readFileSync(new URL('../docs/openapi.yaml', import.meta.url), 'utf8');\n\nexport const docsPage = `" + swaggerUIPage + "`;\n"
+
+// nodeEntrypointRoutes returns the imports and route registrations the
+// entrypoint needs to serve /docs and every model's routes, and what its
+// shutdown function awaits once the server has closed.
+func nodeEntrypointRoutes(req *GenerateRequest) (string, string, string) {
+	var imports, routes, shutdown strings.Builder
+	if req.Features.Observability {
+		imports.WriteString("import { instrument } from './telemetry/metrics.js';\n")
+		routes.WriteString("instrument(app);\n")
+	}
+	if usesMessaging(*req) {
+		imports.WriteString("import { logEvent } from './messaging/events.js';\n")
+	}
+	if req.Infra.Kafka {
+		imports.WriteString("import { startKafka } from './messaging/kafka.js';\n")
+		routes.WriteString("const stopKafka = await startKafka(logEvent);\n")
+		shutdown.WriteString("  await stopKafka();\n")
+	}
+	if req.Infra.NATS {
+		imports.WriteString("import { startNats } from './messaging/nats.js';\n")
+		routes.WriteString("const stopNats = await startNats(logEvent);\n")
+		shutdown.WriteString("  await stopNats();\n")
+	}
+	if req.Infra.RabbitMQ {
+		imports.WriteString("import { startRabbitMQ } from './messaging/rabbitmq.js';\n")
+		routes.WriteString("const stopRabbitMQ = await startRabbitMQ(logEvent);\n")
+		shutdown.WriteString("  await stopRabbitMQ();\n")
+	}
+	if req.Infra.Redis {
+		imports.WriteString("import { redis, redisHealth } from './cache/redis.js';\n")
+		if req.Framework == "express" {
+			routes.WriteString("app.get('/health/redis', async (req, res) => {\n  const { code, body } = await redisHealth();\n  res.status(code).json(body);\n});\n")
+		} else {
+			routes.WriteString("app.get('/health/redis', async (request, reply) => {\n  const { code, body } = await redisHealth();\n  return reply.code(code).send(body);\n});\n")
+		}
+		shutdown.WriteString("  await redis.quit();\n")
+	}
+	if req.Features.Swagger {
+		imports.WriteString("import { docsPage, openapiSpec } from './docs.js';\n")
+		if req.Framework == "express" {
+			routes.WriteString("app.get('/docs', (req, res) => res.type('html').send(docsPage));\n")
+			routes.WriteString("app.get('/docs/openapi.yaml', (req, res) => res.type('application/yaml').send(openapiSpec));\n")
+		} else {
+			routes.WriteString("app.get('/docs', async (request, reply) => reply.type('text/html').send(docsPage));\n")
+			routes.WriteString("app.get('/docs/openapi.yaml', async (request, reply) => reply.type('application/yaml').send(openapiSpec));\n")
+		}
+	}
+	models := resolvedModels(req.Custom.Models)
+	if !isEnabled(req.FileToggles.ExampleCRUD) {
+		models = nil
+	}
+	if req.Features.JWTAuth {
+		imports.WriteString("import authRoutes from './auth/routes.js';\n")
+		if req.Framework == "express" {
+			routes.WriteString("app.use(authRoutes);\n")
+		} else {
+			routes.WriteString("app.register(authRoutes);\n")
+		}
+		if len(models) > 0 && (req.Architecture == "clean" || req.Architecture == "hexagonal") {
+			imports.WriteString("import { requireAuth } from './auth/middleware.js';\n")
+			if req.Features.RBAC {
+				imports.WriteString("import { authorize } from './auth/rbac.js';\n")
+			}
+		}
+	}
+	for _, model := range models {
+		nameLow := strings.ToLower(model.Name)
+		switch req.Architecture {
+		case "clean":
+			imports.WriteString(fmt.Sprintf("import * as %sController from './controllers/%sController.js';\n", nameLow, nameLow))
+			for _, r := range modelRoutes(model) {
+				routes.WriteString(fmt.Sprintf("app.%s('%s', %s%sController.%sHandler);\n", strings.ToLower(r.Method), colonPath(r.Path), nodeRouteGuard(req, routePermission(model, r)), nameLow, lowerFirst(r.OperationID)))
+			}
+		case "hexagonal":
+			var handlers []string
+			for _, r := range modelRoutes(model) {
+				handlers = append(handlers, lowerFirst(r.OperationID))
+				routes.WriteString(fmt.Sprintf("app.%s('%s', %s%s);\n", strings.ToLower(r.Method), colonPath(r.Path), nodeRouteGuard(req, routePermission(model, r)), lowerFirst(r.OperationID)))
+			}
+			imports.WriteString(fmt.Sprintf("import { %s } from './adapters/primary/http/%sController.js';\n", strings.Join(handlers, ", "), nameLow))
+		default:
+			imports.WriteString(fmt.Sprintf("import %sRoutes from './routes/%ss.js';\n", nameLow, nameLow))
+			if req.Framework == "express" {
+				routes.WriteString(fmt.Sprintf("app.use(%sRoutes);\n", nameLow))
+			} else {
+				routes.WriteString(fmt.Sprintf("app.register(%sRoutes);\n", nameLow))
+			}
+		}
+	}
+	return imports.String(), routes.String(), shutdown.String()
+}
+
+// nodeRouteGuard is what goes between a model route's path and its handler:
+// the requireAuth middleware (express) or preHandler option (fastify) when
+// JWT auth protects the CRUD routes, followed by authorize(permission) under
+// RBAC, otherwise nothing.
+func nodeRouteGuard(req *GenerateRequest, permission string) string {
+	switch {
+	case !req.Features.JWTAuth:
+		return ""
+	case req.Features.RBAC && req.Framework == "fastify":
+		return fmt.Sprintf("{ preHandler: [requireAuth, authorize('%s')] }, ", permission)
+	case req.Features.RBAC:
+		return fmt.Sprintf("requireAuth, authorize('%s'), ", permission)
+	case req.Framework == "fastify":
+		return "{ preHandler: requireAuth }, "
+	default:
+		return "requireAuth, "
+	}
+}
+
+// nodeUsecases names the clean-architecture use case behind each CRUD action.
+var nodeUsecases = []struct {
+	action, prefix, suffix, params, call string
+}{
+	{ActionList, "list", "s", "page", "findAll(page)"},
+	{ActionGet, "get", "", "id", "findById(id)"},
+	{ActionCreate, "create", "", "data", "create(data)"},
+	{ActionUpdate, "update", "", "id, data", "update(id, data)"},
+	{ActionDelete, "delete", "", "id", "remove(id)"},
+}
+
+// renderNodeRepository renders the class every architecture's handlers
+// persist a model through: Prisma with the ORM, the pg or mysql2 client on a
+// SQL database, otherwise a module-level array. Every variant resolves to
+// null (findById, update) or false (remove) when the id does not exist.
+// srcDir is the relative path from the class's file back to src/.
+func renderNodeRepository(req *GenerateRequest, model DataModel, class, srcDir string) string {
+	var b strings.Builder
+	switch {
+	case isSQLDB(req.Database) && req.UseORM:
+		client := "prisma." + lowerFirst(model.Name)
+		b.WriteString("import { prisma } from '" + srcDir + "/db/prismaClient.js';\n\n")
+		b.WriteString("export class " + class + " {\n" +
+			"  findAll({ limit, offset }) {\n" +
+			"    return " + client + ".findMany({ orderBy: { id: 'asc' }, take: limit, skip: offset });\n" +
+			"  }\n\n" +
+			"  findById(id) {\n" +
+			"    return " + client + ".findUnique({ where: { id } });\n" +
+			"  }\n\n" +
+			"  create(data) {\n" +
+			"    return " + client + ".create({ data });\n" +
+			"  }\n\n" +
+			"  async update(id, data) {\n" +
+			"    if (!(await this.findById(id))) return null;\n" +
+			"    return " + client + ".update({ where: { id }, data });\n" +
+			"  }\n\n" +
+			"  async remove(id) {\n" +
+			"    const { count } = await " + client + ".deleteMany({ where: { id } });\n" +
+			"    return count > 0;\n" +
+			"  }\n}\n")
+	case req.Database == "sqlite":
+		// better-sqlite3 is synchronous; the methods stay async so callers
+		// await every store alike.
+		st := newCRUDStatements(model, req.Database, questionBind)
+		values := func(from string) string {
+			args := make([]string, 0, len(st.Columns))
+			for _, c := range st.Columns {
+				args = append(args, from+"."+c)
+			}
+			if len(args) == 0 {
+				return ""
+			}
+			return "[" + strings.Join(args, ", ") + "].map(bindable)"
+		}
+		updateArgs := values("next")
+		if updateArgs != "" {
+			updateArgs += ", "
+		}
+		b.WriteString("import { db, bindable } from '" + srcDir + "/db/sqlClient.js';\n\n")
+		b.WriteString("export class " + class + " {\n" +
+			"  async findAll({ limit, offset }) {\n" +
+			"    return db.prepare('" + st.List + "').all(limit, offset);\n" +
+			"  }\n\n" +
+			"  async findById(id) {\n" +
+			"    return db.prepare('" + st.Get + "').get(id) ?? null;\n" +
+			"  }\n\n" +
+			"  async create(data) {\n" +
+			"    const { lastInsertRowid } = db.prepare('" + st.Insert + "').run(" + values("data") + ");\n" +
+			"    return this.findById(Number(lastInsertRowid));\n" +
+			"  }\n\n" +
+			"  async update(id, data) {\n" +
+			"    const current = await this.findById(id);\n" +
+			"    if (!current) return null;\n" +
+			"    const next = { ...current, ...data };\n" +
+			"    db.prepare('" + st.Update + "').run(" + updateArgs + "id);\n" +
+			"    return this.findById(id);\n" +
+			"  }\n\n" +
+			"  async remove(id) {\n" +
+			"    const { changes } = db.prepare('" + st.Delete + "').run(id);\n" +
+			"    return changes > 0;\n" +
+			"  }\n}\n")
+	case isSQLDB(req.Database):
+		bind := questionBind
+		if req.Database == "postgresql" {
+			bind = dollarBind
+		}
+		st := newCRUDStatements(model, req.Database, bind)
+		values := func(from string) string {
+			args := make([]string, 0, len(st.Columns))
+			for _, c := range st.Columns {
+				args = append(args, from+"."+c+" ?? null")
+			}
+			return strings.Join(args, ", ")
+		}
+		updateArgs := values("next")
+		if updateArgs != "" {
+			updateArgs += ", "
+		}
+		// pg resolves to a result with rows and rowCount, mysql2 to a
+		// [rows or result header, fields] pair.
+		rows, result := "const { rows }", "const result"
+		inserted, affected := "rows[0].id", "result.rowCount"
+		if req.Database == "mysql" {
+			rows, result = "const [rows]", "const [result]"
+			inserted, affected = "result.insertId", "result.affectedRows"
+		}
+		insert := "    " + rows + " = await db.query('" + st.Insert + "', [" + values("data") + "]);\n"
+		if !st.Returning {
+			insert = "    " + result + " = await db.query('" + st.Insert + "', [" + values("data") + "]);\n"
+		}
+		b.WriteString("import { db } from '" + srcDir + "/db/sqlClient.js';\n\n")
+		b.WriteString("export class " + class + " {\n" +
+			"  async findAll({ limit, offset }) {\n" +
+			"    " + rows + " = await db.query('" + st.List + "', [limit, offset]);\n" +
+			"    return rows;\n" +
+			"  }\n\n" +
+			"  async findById(id) {\n" +
+			"    " + rows + " = await db.query('" + st.Get + "', [id]);\n" +
+			"    return rows[0] ?? null;\n" +
+			"  }\n\n" +
+			"  async create(data) {\n" +
+			insert +
+			"    return this.findById(" + inserted + ");\n" +
+			"  }\n\n" +
+			"  async update(id, data) {\n" +
+			"    const current = await this.findById(id);\n" +
+			"    if (!current) return null;\n" +
+			"    const next = { ...current, ...data };\n" +
+			"    await db.query('" + st.Update + "', [" + updateArgs + "id]);\n" +
+			"    return this.findById(id);\n" +
+			"  }\n\n" +
+			"  async remove(id) {\n" +
+			"    " + result + " = await db.query('" + st.Delete + "', [id]);\n" +
+			"    return " + affected + " > 0;\n" +
+			"  }\n}\n")
+	default:
+		b.WriteString("// Rows live in memory; pick a SQL database to persist them.\n" +
+			"const rows = [];\nlet nextId = 1;\n\n")
+		b.WriteString("export class " + class + " {\n" +
+			"  async findAll({ limit, offset }) {\n" +
+			"    return rows.slice(offset, offset + limit);\n" +
+			"  }\n\n" +
+			"  async findById(id) {\n" +
+			"    return rows.find((row) => row.id === id) ?? null;\n" +
+			"  }\n\n" +
+			"  async create(data) {\n" +
+			"    const row = { id: nextId++, ...data };\n" +
+			"    rows.push(row);\n" +
+			"    return row;\n" +
+			"  }\n\n" +
+			"  async update(id, data) {\n" +
+			"    const row = await this.findById(id);\n" +
+			"    if (!row) return null;\n" +
+			"    return Object.assign(row, data, { id });\n" +
+			"  }\n\n" +
+			"  async remove(id) {\n" +
+			"    const i = rows.findIndex((row) => row.id === id);\n" +
+			"    if (i < 0) return false;\n" +
+			"    rows.splice(i, 1);\n" +
+			"    return true;\n" +
+			"  }\n}\n")
+	}
+	return b.String()
+}
+
+// nodeHandlerBody renders the statements of one route's handler for express
+// (req, res) or fastify (request, reply). calls names the function each CRUD
+// action awaits; list routes need parsePage and body routes schema in scope.
+func nodeHandlerBody(framework, schema string, r modelRoute, calls map[string]string) string {
+	request := "req"
+	fail := func(status int, body string) string {
+		return fmt.Sprintf("return res.status(%d).json({ error: %s });", status, body)
+	}
+	reply := func(status int, body string) string {
+		if status == 200 {
+			return "res.json(" + body + ");"
+		}
+		return fmt.Sprintf("res.status(%d).json(%s);", status, body)
+	}
+	noContent := "res.status(204).end();"
+	if framework == "fastify" {
+		request = "request"
+		fail = func(status int, body string) string {
+			return fmt.Sprintf("return reply.code(%d).send({ error: %s });", status, body)
+		}
+		reply = func(status int, body string) string {
+			if status == 200 {
+				return "return " + body + ";"
+			}
+			return fmt.Sprintf("return reply.code(%d).send(%s);", status, body)
+		}
+		noContent = "return reply.code(204).send();"
+	}
+	notFound := fail(404, "'not found'")
+
+	var b strings.Builder
+	line := func(s string) { b.WriteString("  " + s + "\n") }
+	if itemRoute(r.Action) {
+		line("const id = Number(" + request + ".params." + r.Param + ");")
+		line("if (!Number.isInteger(id)) " + fail(400, "'invalid id'"))
+	}
+	switch r.Action {
+	case ActionList:
+		line("const page = parsePage(" + request + ".query);")
+		line(reply(200, "{ ...page, data: await "+calls[ActionList]+"(page) }"))
+	case ActionGet:
+		line("const row = await " + calls[ActionGet] + "(id);")
+		line("if (!row) " + notFound)
+		line(reply(200, "row"))
+	case ActionCreate:
+		line("const parsed = " + schema + ".safeParse(" + request + ".body);")
+		line("if (!parsed.success) " + fail(400, "parsed.error.flatten()"))
+		line(reply(201, "await "+calls[ActionCreate]+"(parsed.data)"))
+	case ActionUpdate:
+		line("const parsed = " + schema + ".partial().safeParse(" + request + ".body);")
+		line("if (!parsed.success) " + fail(400, "parsed.error.flatten()"))
+		line("const row = await " + calls[ActionUpdate] + "(id, parsed.data);")
+		line("if (!row) " + notFound)
+		line(reply(200, "row"))
+	case ActionDelete:
+		line("if (!(await " + calls[ActionDelete] + "(id))) " + notFound)
+		line(noContent)
+	default:
+		line(fail(501, "'"+r.OperationID+" is not implemented'"))
+	}
+	return b.String()
+}
+
+// nodeHandlerParams is the handler signature for the framework.
+func nodeHandlerParams(framework string) string {
+	if framework == "fastify" {
+		return "(request, reply)"
+	}
+	return "(req, res)"
+}
+
 func (g *NodeGenerator) renderNodeDynamicModel(tree *FileTree, req *GenerateRequest, model DataModel, arch, root string) {
 	prefix := root
 	if prefix != "" {
@@ -400,80 +800,137 @@ func (g *NodeGenerator) renderNodeDynamicModel(tree *FileTree, req *GenerateRequ
 	}
 	name := model.Name
 	nameLow := strings.ToLower(name)
-	sample := buildNodeSampleObject(model)
+	schema := lowerFirst(name) + "Schema"
+	addFile(tree, prefix+"src/schemas/"+schema+".js", renderZodSchema(model))
+	routes := modelRoutes(model)
+	actions := map[string]bool{}
+	for _, r := range routes {
+		actions[r.Action] = true
+	}
+	params := nodeHandlerParams(req.Framework)
+	// imports is what every handler module needs besides its own layer.
+	imports := func(srcDir string) string {
+		out := ""
+		if actions[ActionList] {
+			out += "import { parsePage } from '" + srcDir + "/utils/pagination.js';\n"
+		}
+		return out + "import { " + schema + " } from '" + srcDir + "/schemas/" + schema + ".js';\n"
+	}
 
 	switch arch {
 	case "clean":
 		addFile(tree, prefix+"src/domain/"+nameLow+".js", g.buildNodeDomainClass(name, model))
-		addFile(tree, prefix+"src/usecases/list"+name+"s.js",
-			"import { "+name+"Repository } from '../repositories/"+nameLow+"Repository.js';\n\n"+
-				"export async function list"+name+"s() {\n  return new "+name+"Repository().findAll();\n}\n")
+		var usecaseImports, handlers strings.Builder
+		calls := map[string]string{}
+		for _, u := range nodeUsecases {
+			fn := u.prefix + name + u.suffix
+			calls[u.action] = fn
+			if !actions[u.action] {
+				continue
+			}
+			repo, repoImport := nodeRepository(req, model, name+"Repository", "..")
+			addFile(tree, prefix+"src/usecases/"+fn+".js",
+				"import { "+name+"Repository } from '../repositories/"+nameLow+"Repository.js';\n"+repoImport+"\n"+
+					"const repo = "+repo+";\n\n"+
+					"export async function "+fn+"("+u.params+") {\n  return repo."+u.call+";\n}\n")
+			usecaseImports.WriteString("import { " + fn + " } from '../usecases/" + fn + ".js';\n")
+		}
+		for _, r := range routes {
+			handlers.WriteString("\nexport async function " + lowerFirst(r.OperationID) + "Handler" + params + " {\n" +
+				nodeHandlerBody(req.Framework, schema, r, calls) + "}\n")
+		}
 		addFile(tree, prefix+"src/controllers/"+nameLow+"Controller.js",
-			"import { list"+name+"s } from '../usecases/list"+name+"s.js';\n\n"+
-				"export async function list"+name+"sHandler(req, res) { res.json(await list"+name+"s()); }\n"+
-				"export async function create"+name+"Handler(req, res) { res.status(201).json({ ...req.body, id: Date.now() }); }\n")
+			usecaseImports.String()+imports("..")+handlers.String())
 		addFile(tree, prefix+"src/repositories/"+nameLow+"Repository.js",
-			"export class "+name+"Repository {\n"+
-				"  async findAll() { return ["+sample+"]; }\n"+
-				"  async findById(id) { return { id: Number(id) }; }\n"+
-				"  async create(data) { return { id: Date.now(), ...data }; }\n}\n")
+			renderNodeRepository(req, model, name+"Repository", ".."))
 
 	case "hexagonal":
 		addFile(tree, prefix+"src/core/ports/"+nameLow+"RepositoryPort.js",
 			"/** @interface "+name+"RepositoryPort\n"+
-				" *  findAll():Promise<"+name+"[]>\n"+
+				" *  findAll(page:{limit:number, offset:number}):Promise<"+name+"[]>\n"+
 				" *  findById(id:number):Promise<"+name+"|null>\n"+
-				" *  create(data):Promise<"+name+">\n */\n")
+				" *  create(data):Promise<"+name+">\n"+
+				" *  update(id:number, data):Promise<"+name+"|null>\n"+
+				" *  remove(id:number):Promise<boolean>\n */\n")
 		addFile(tree, prefix+"src/core/services/"+nameLow+"Service.js",
 			"export class "+name+"Service {\n"+
 				"  constructor(repo) { this.repo = repo; }\n"+
-				"  listAll() { return this.repo.findAll(); }\n"+
+				"  listAll(page) { return this.repo.findAll(page); }\n"+
 				"  getById(id) { return this.repo.findById(id); }\n"+
-				"  create(data) { return this.repo.create(data); }\n}\n")
+				"  create(data) { return this.repo.create(data); }\n"+
+				"  update(id, data) { return this.repo.update(id, data); }\n"+
+				"  remove(id) { return this.repo.remove(id); }\n}\n")
+		calls := map[string]string{
+			ActionList: "svc.listAll", ActionGet: "svc.getById", ActionCreate: "svc.create",
+			ActionUpdate: "svc.update", ActionDelete: "svc.remove",
+		}
+		var handlers strings.Builder
+		for _, r := range routes {
+			handlers.WriteString("\nexport const " + lowerFirst(r.OperationID) + " = async " + params + " => {\n" +
+				nodeHandlerBody(req.Framework, schema, r, calls) + "};\n")
+		}
+		repo, repoImport := nodeRepository(req, model, name+"RepositoryAdapter", "../../..")
 		addFile(tree, prefix+"src/adapters/primary/http/"+nameLow+"Controller.js",
 			"import { "+name+"Service } from '../../../core/services/"+nameLow+"Service.js';\n"+
-				"import { "+name+"RepositoryAdapter } from '../../secondary/database/"+nameLow+"RepositoryAdapter.js';\n\n"+
-				"const svc = new "+name+"Service(new "+name+"RepositoryAdapter());\n\n"+
-				"export const list"+name+"s = async (req, res) => res.json(await svc.listAll());\n"+
-				"export const get"+name+" = async (req, res) => res.json(await svc.getById(req.params.id));\n"+
-				"export const create"+name+" = async (req, res) => res.status(201).json(await svc.create(req.body));\n")
+				"import { "+name+"RepositoryAdapter } from '../../secondary/database/"+nameLow+"RepositoryAdapter.js';\n"+
+				repoImport+imports("../../..")+"\n"+
+				"const svc = new "+name+"Service("+repo+");\n"+
+				handlers.String())
 		addFile(tree, prefix+"src/adapters/secondary/database/"+nameLow+"RepositoryAdapter.js",
-			"export class "+name+"RepositoryAdapter {\n"+
-				"  async findAll() { return ["+sample+"]; }\n"+
-				"  async findById(id) { return { id: Number(id) }; }\n"+
-				"  async create(data) { return { id: Date.now(), ...data }; }\n}\n")
+			renderNodeRepository(req, model, name+"RepositoryAdapter", "../../.."))
 
 	default:
+		calls := map[string]string{
+			ActionList: "repo.findAll", ActionGet: "repo.findById", ActionCreate: "repo.create",
+			ActionUpdate: "repo.update", ActionDelete: "repo.remove",
+		}
+		addFile(tree, prefix+"src/repositories/"+nameLow+"Repository.js",
+			renderNodeRepository(req, model, name+"Repository", ".."))
+		var handlers, register strings.Builder
+		for _, r := range routes {
+			fn := lowerFirst(r.OperationID)
+			guard := nodeRouteGuard(req, routePermission(model, r))
+			if req.Framework == "fastify" {
+				register.WriteString("  fastify." + strings.ToLower(r.Method) + "('" + colonPath(r.Path) + "', " + guard + fn + ");\n")
+			} else {
+				register.WriteString("router." + strings.ToLower(r.Method) + "('" + colonPath(r.Path) + "', " + guard + fn + ");\n")
+			}
+			handlers.WriteString("\nasync function " + fn + params + " {\n" +
+				nodeHandlerBody(req.Framework, schema, r, calls) + "}\n")
+		}
+		repo, repoImport := nodeRepository(req, model, name+"Repository", "..")
+		head := "import { " + name + "Repository } from '../repositories/" + nameLow + "Repository.js';\n" + repoImport + imports("..")
+		if req.Features.JWTAuth {
+			head += "import { requireAuth } from '../auth/middleware.js';\n"
+		}
+		if req.Features.RBAC {
+			head += "import { authorize } from '../auth/rbac.js';\n"
+		}
 		if req.Framework == "fastify" {
 			addFile(tree, prefix+"src/routes/"+nameLow+"s.js",
-				"export default async function (fastify, opts) {\n"+
-					"  fastify.get('/', async (request, reply) => {\n"+
-					"    const limit = Math.min(Number(request.query.limit) || 20, 100);\n"+
-					"    const offset = Number(request.query.offset) || 0;\n"+
-					"    return { limit, offset, data: ["+sample+"] };\n  });\n\n"+
-					"  fastify.get('/:id', async (request, reply) => ({ id: request.params.id }));\n"+
-					"  fastify.post('/', async (request, reply) => {\n"+
-					"    reply.code(201);\n"+
-					"    return { id: Date.now(), ...request.body };\n  });\n"+
-					"  fastify.put('/:id', async (request, reply) => ({ id: request.params.id, ...request.body }));\n"+
-					"  fastify.delete('/:id', async (request, reply) => ({ deleted: request.params.id }));\n"+
-					"}\n")
+				head+"\nconst repo = "+repo+";\n\n"+
+					"export default async function (fastify, opts) {\n"+register.String()+"}\n"+handlers.String())
 		} else {
 			addFile(tree, prefix+"src/routes/"+nameLow+"s.js",
-				"import { Router } from 'express';\nconst router = Router();\n\n"+
-					"router.get('/', (req, res) => {\n"+
-					"  const limit = Math.min(Number(req.query.limit) || 20, 100);\n"+
-					"  const offset = Number(req.query.offset) || 0;\n"+
-					"  res.json({ limit, offset, data: ["+sample+"] });\n});\n\n"+
-					"router.get('/:id', (req, res) => res.json({ id: req.params.id }));\n"+
-					"router.post('/', (req, res) => res.status(201).json({ id: Date.now(), ...req.body }));\n"+
-					"router.put('/:id', (req, res) => res.json({ id: req.params.id, ...req.body }));\n"+
-					"router.delete('/:id', (req, res) => res.json({ deleted: req.params.id }));\n\n"+
+				"import { Router } from 'express';\n"+head+"\n"+
+					"const repo = "+repo+";\n"+
+					"const router = Router();\n\n"+register.String()+handlers.String()+"\n"+
 					"export default router;\n")
 		}
 	}
 }
 
+// nodeRepository returns the expression that builds a model's repository of
+// class, read through the cache when the models are cached, and the import
+// that needs from srcDir besides class itself.
+func nodeRepository(req *GenerateRequest, model DataModel, class, srcDir string) (string, string) {
+	if !cachesModels(*req) {
+		return "new " + class + "()", ""
+	}
+	return fmt.Sprintf("new CachedRepository(new %s(), '%s')", class, strings.ToLower(model.Name)),
+		"import { CachedRepository } from '" + srcDir + "/cache/cachedRepository.js';\n"
+}
+
 func (g *NodeGenerator) addNodeAutopilot(tree *FileTree, req *GenerateRequest, root string) {
 	prefix := root
 	if prefix != "" {
@@ -526,64 +983,58 @@ func (g *NodeGenerator) addNodeDBRetry(tree *FileTree, req *GenerateRequest, roo
 			"}\n")
 }
 
-func nodeMonolithTemplateSpecs(req GenerateRequest) []templateSpec {
-	withCRUD := isEnabled(req.FileToggles.ExampleCRUD)
-	switch req.Architecture {
-	case "clean":
-		base := []templateSpec{
-			{Template: "node/clean/src/index.tmpl", Output: "src/index.js"},
-		}
-		if withCRUD {
-			return base
-		}
-		return append(base, []templateSpec{
-			{Template: "node/clean/src/domain/ping.tmpl", Output: "src/domain/ping.js"},
-			{Template: "node/clean/src/usecases/pingUsecase.tmpl", Output: "src/usecases/pingUsecase.js"},
-			{Template: "node/clean/src/controllers/pingController.tmpl", Output: "src/controllers/pingController.js"},
-			{Template: "node/clean/src/repositories/pingRepository.tmpl", Output: "src/repositories/pingRepository.js"},
-		}...)
-	case "hexagonal":
-		base := []templateSpec{
-			{Template: "node/hexagonal/src/index.tmpl", Output: "src/index.js"},
-		}
-		if withCRUD {
-			return base
-		}
-		return append(base, []templateSpec{
-			{Template: "node/hexagonal/src/core/ports/pingPort.tmpl", Output: "src/core/ports/pingPort.js"},
-			{Template: "node/hexagonal/src/core/services/pingService.tmpl", Output: "src/core/services/pingService.js"},
-			{Template: "node/hexagonal/src/adapters/primary/http/pingController.tmpl", Output: "src/adapters/primary/http/pingController.js"},
-			{Template: "node/hexagonal/src/adapters/secondary/database/pingAdapter.tmpl", Output: "src/adapters/secondary/database/pingAdapter.js"},
-		}...)
-	default:
-		return []templateSpec{{Template: fmt.Sprintf("node/%s/main.tmpl", archTemplateName(req.Architecture)), Output: "src/index.js"}}
-	}
-}
-
-func nodeMicroserviceTemplateSpecs(_ GenerateRequest) []templateSpec {
-	return []templateSpec{{Template: "node/microservice/main.tmpl", Output: "src/index.js"}}
-}
-
-func nodePackageJSON(framework string, db string, useORM bool) string {
+func nodePackageJSON(framework string, db string, useORM bool, jwtAuth bool, observability bool, infra InfraOptions) string {
 	dep := framework
 	extra := ""
+	start := "node src/index.js"
+	if jwtAuth {
+		extra += ",\n    \"bcryptjs\": \"^2.4.3\",\n    \"jsonwebtoken\": \"^9.0.2\""
+	}
+	if observability {
+		extra += ",\n    \"@opentelemetry/api\": \"^1.9.0\",\n    \"@opentelemetry/auto-instrumentations-node\": \"^0.55.0\",\n    \"@opentelemetry/exporter-trace-otlp-grpc\": \"^0.57.0\",\n    \"@opentelemetry/instrumentation\": \"^0.57.0\",\n    \"@opentelemetry/sdk-node\": \"^0.57.0\",\n    \"prom-client\": \"^15.1.3\""
+		if useORM && isSQLDB(db) {
+			extra += ",\n    \"@prisma/instrumentation\": \"^6.2.1\""
+		}
+		start = "node --import ./src/telemetry/instrumentation.js src/index.js"
+	}
+	if infra.Redis {
+		extra += ",\n    \"ioredis\": \"^5.4.2\""
+	}
+	if infra.Kafka {
+		extra += ",\n    \"kafkajs\": \"^2.2.4\""
+	}
+	if infra.NATS {
+		extra += ",\n    \"nats\": \"^2.29.1\""
+	}
+	if infra.RabbitMQ {
+		extra += ",\n    \"amqplib\": \"^0.10.5\""
+	}
 	if db == "postgresql" {
 		if useORM {
-			extra = ",\n    \"@prisma/client\": \"^6.2.1\""
+			extra += ",\n    \"@prisma/client\": \"^6.2.1\""
 		} else {
-			extra = ",\n    \"pg\": \"^8.13.3\""
+			extra += ",\n    \"pg\": \"^8.13.3\""
 		}
 	}
 	if db == "mysql" {
 		if useORM {
-			extra = ",\n    \"@prisma/client\": \"^6.2.1\""
+			extra += ",\n    \"@prisma/client\": \"^6.2.1\""
+		} else {
+			extra += ",\n    \"mysql2\": \"^3.12.0\""
+		}
+	}
+	if db == "sqlite" {
+		if useORM {
+			extra += ",\n    \"@prisma/client\": \"^6.2.1\""
+			// No db/init runs for SQLite; db push creates the tables from the schema.
+			start = "prisma db push && " + start
 		} else {
-			extra = ",\n    \"mysql2\": \"^3.12.0\""
+			extra += ",\n    \"better-sqlite3\": \"^11.7.0\""
 		}
 	}
 	devExtra := ""
 	seedCmd := "node scripts/seed.js"
-	if useORM && (db == "postgresql" || db == "mysql") {
+	if useORM && isSQLDB(db) {
 		devExtra = ",\n  \"devDependencies\": {\n    \"prisma\": \"^6.2.1\"\n  }"
 		seedCmd = "node prisma/seed.js"
 	}
@@ -593,8 +1044,8 @@ func nodePackageJSON(framework string, db string, useORM bool) string {
   "private": true,
   "type": "module",
   "scripts": {
-    "start": "node src/index.js",
-    "dev": "node src/index.js",
+    "start": "%s",
+    "dev": "%s",
     "test": "node --test",
     "seed": "%s"
   },
@@ -604,5 +1055,5 @@ func nodePackageJSON(framework string, db string, useORM bool) string {
     "zod": "^3.23.8"%s
   }%s
 }
-`, seedCmd, dep, extra, devExtra)
+`, start, start, seedCmd, dep, extra, devExtra)
 }
@@ -0,0 +1,311 @@
+package generator
+
+// observability.go — the compose monitoring stack behind features.observability.
+//
+// Like shared_infra.go, this file is language-agnostic: it must never look at
+// req.Language or req.Framework. The apps themselves export traces over OTLP
+// to otel-collector, which forwards them to Jaeger, and serve Prometheus
+// metrics on /metrics; the language generators write that instrumentation.
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// observabilityServices are the compose services addObservabilityServices
+// adds. They mount the config files under observability/, so the
+// Kubernetes manifests leave them out.
+var observabilityServices = map[string]bool{
+	"otel-collector": true,
+	"jaeger":         true,
+	"prometheus":     true,
+	"grafana":        true,
+}
+
+// addObservabilityServices adds the collector, Jaeger, Prometheus and
+// Grafana to spec and starts every app service after the collector.
+func addObservabilityServices(spec *ComposeSpec) {
+	dependAppsOn(spec, "otel-collector", "service_started")
+
+	jaegerEnv := &ComposeEnvironment{}
+	jaegerEnv.Set("COLLECTOR_OTLP_ENABLED", "true")
+	spec.Services["jaeger"] = ComposeService{
+		Image:       "jaegertracing/all-in-one:1.64.0",
+		Ports:       []string{"16686:16686"},
+		Environment: jaegerEnv,
+	}
+	spec.Services["otel-collector"] = ComposeService{
+		Image:     "otel/opentelemetry-collector-contrib:0.116.1",
+		Command:   "--config=/etc/otelcol-contrib/config.yaml",
+		Ports:     []string{"4317:4317", "4318:4318"},
+		Volumes:   []string{"./observability/otel-collector.yaml:/etc/otelcol-contrib/config.yaml:ro"},
+		DependsOn: map[string]ComposeDep{"jaeger": {Condition: "service_started"}},
+	}
+	spec.Services["prometheus"] = ComposeService{
+		Image:   "prom/prometheus:v3.1.0",
+		Ports:   []string{"9090:9090"},
+		Volumes: []string{"./observability/prometheus.yml:/etc/prometheus/prometheus.yml:ro"},
+	}
+	grafanaEnv := &ComposeEnvironment{}
+	grafanaEnv.Set("GF_AUTH_ANONYMOUS_ENABLED", "true")
+	grafanaEnv.Set("GF_AUTH_ANONYMOUS_ORG_ROLE", "Admin")
+	spec.Services["grafana"] = ComposeService{
+		Image:       "grafana/grafana:11.4.0",
+		Ports:       []string{"3000:3000"},
+		Environment: grafanaEnv,
+		Volumes: []string{
+			"./observability/grafana/provisioning:/etc/grafana/provisioning:ro",
+			"./observability/grafana/dashboards:/var/lib/grafana/dashboards:ro",
+		},
+		DependsOn: map[string]ComposeDep{"prometheus": {Condition: "service_started"}},
+	}
+}
+
+// =========================================================================
+// Collector, Prometheus and Grafana configuration
+// =========================================================================
+
+// OTelCollectorConfig receives OTLP from the apps and exports traces to Jaeger.
+type OTelCollectorConfig struct {
+	Receivers  OTelReceivers           `yaml:"receivers"`
+	Processors map[string]struct{}     `yaml:"processors"`
+	Exporters  map[string]OTelExporter `yaml:"exporters"`
+	Service    OTelService             `yaml:"service"`
+}
+
+type OTelReceivers struct {
+	OTLP OTelOTLPReceiver `yaml:"otlp"`
+}
+
+type OTelOTLPReceiver struct {
+	Protocols map[string]OTelEndpoint `yaml:"protocols"`
+}
+
+type OTelEndpoint struct {
+	Endpoint string `yaml:"endpoint"`
+}
+
+type OTelExporter struct {
+	Endpoint string   `yaml:"endpoint"`
+	TLS      *OTelTLS `yaml:"tls,omitempty"`
+}
+
+type OTelTLS struct {
+	Insecure bool `yaml:"insecure"`
+}
+
+type OTelService struct {
+	Pipelines map[string]OTelPipeline `yaml:"pipelines"`
+}
+
+type OTelPipeline struct {
+	Receivers  []string `yaml:"receivers"`
+	Processors []string `yaml:"processors"`
+	Exporters  []string `yaml:"exporters"`
+}
+
+func newOTelCollectorConfig() OTelCollectorConfig {
+	return OTelCollectorConfig{
+		Receivers: OTelReceivers{OTLP: OTelOTLPReceiver{Protocols: map[string]OTelEndpoint{
+			"grpc": {Endpoint: "0.0.0.0:4317"},
+			"http": {Endpoint: "0.0.0.0:4318"},
+		}}},
+		Processors: map[string]struct{}{"batch": {}},
+		Exporters: map[string]OTelExporter{
+			"otlp/jaeger": {Endpoint: "jaeger:4317", TLS: &OTelTLS{Insecure: true}},
+		},
+		Service: OTelService{Pipelines: map[string]OTelPipeline{
+			"traces": {Receivers: []string{"otlp"}, Processors: []string{"batch"}, Exporters: []string{"otlp/jaeger"}},
+		}},
+	}
+}
+
+// PrometheusConfig scrapes /metrics on every app service.
+type PrometheusConfig struct {
+	Global        PrometheusGlobal   `yaml:"global"`
+	ScrapeConfigs []PrometheusScrape `yaml:"scrape_configs"`
+}
+
+type PrometheusGlobal struct {
+	ScrapeInterval string `yaml:"scrape_interval"`
+}
+
+type PrometheusScrape struct {
+	JobName       string              `yaml:"job_name"`
+	MetricsPath   string              `yaml:"metrics_path"`
+	StaticConfigs []PrometheusTargets `yaml:"static_configs"`
+}
+
+type PrometheusTargets struct {
+	Targets []string `yaml:"targets"`
+}
+
+// newPrometheusConfig has one job per app service, named like its compose
+// service so the dashboard can split by job.
+func newPrometheusConfig(req GenerateRequest) PrometheusConfig {
+	cfg := PrometheusConfig{Global: PrometheusGlobal{ScrapeInterval: "15s"}}
+	job := func(name string, port int) {
+		cfg.ScrapeConfigs = append(cfg.ScrapeConfigs, PrometheusScrape{
+			JobName:       name,
+			MetricsPath:   "/metrics",
+			StaticConfigs: []PrometheusTargets{{Targets: []string{name + ":" + strconv.Itoa(port)}}},
+		})
+	}
+	if req.Architecture == "microservices" {
+		for _, svc := range req.Services {
+			job(svc.Name, svc.Port)
+		}
+	} else {
+		job("app", 8080)
+	}
+	return cfg
+}
+
+// GrafanaDatasources provisions Prometheus and Jaeger.
+type GrafanaDatasources struct {
+	APIVersion  int                 `yaml:"apiVersion"`
+	Datasources []GrafanaDatasource `yaml:"datasources"`
+}
+
+type GrafanaDatasource struct {
+	Name      string `yaml:"name"`
+	Type      string `yaml:"type"`
+	UID       string `yaml:"uid"`
+	Access    string `yaml:"access"`
+	URL       string `yaml:"url"`
+	IsDefault bool   `yaml:"isDefault,omitempty"`
+}
+
+// GrafanaDashboardProviders loads the dashboards mounted from
+// observability/grafana/dashboards.
+type GrafanaDashboardProviders struct {
+	APIVersion int                        `yaml:"apiVersion"`
+	Providers  []GrafanaDashboardProvider `yaml:"providers"`
+}
+
+type GrafanaDashboardProvider struct {
+	Name    string            `yaml:"name"`
+	Type    string            `yaml:"type"`
+	Options map[string]string `yaml:"options"`
+}
+
+// GrafanaDashboard is the subset of Grafana's dashboard JSON model the
+// generated dashboard uses.
+type GrafanaDashboard struct {
+	UID           string           `json:"uid"`
+	Title         string           `json:"title"`
+	SchemaVersion int              `json:"schemaVersion"`
+	Refresh       string           `json:"refresh"`
+	Time          GrafanaTimeRange `json:"time"`
+	Panels        []GrafanaPanel   `json:"panels"`
+}
+
+type GrafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type GrafanaPanel struct {
+	ID          int                  `json:"id"`
+	Type        string               `json:"type"`
+	Title       string               `json:"title"`
+	Datasource  GrafanaDatasourceRef `json:"datasource"`
+	GridPos     GrafanaGridPos       `json:"gridPos"`
+	FieldConfig GrafanaFieldConfig   `json:"fieldConfig"`
+	Targets     []GrafanaTarget      `json:"targets"`
+}
+
+type GrafanaDatasourceRef struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type GrafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type GrafanaFieldConfig struct {
+	Defaults GrafanaFieldDefaults `json:"defaults"`
+}
+
+type GrafanaFieldDefaults struct {
+	Unit string `json:"unit"`
+}
+
+type GrafanaTarget struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+}
+
+// newGrafanaDashboard charts the http_requests_total and
+// http_request_duration_seconds series every generated app exports.
+func newGrafanaDashboard() GrafanaDashboard {
+	prometheus := GrafanaDatasourceRef{Type: "prometheus", UID: "prometheus"}
+	panel := func(id int, title, unit, expr, legend string, x, y, w int) GrafanaPanel {
+		return GrafanaPanel{
+			ID:          id,
+			Type:        "timeseries",
+			Title:       title,
+			Datasource:  prometheus,
+			GridPos:     GrafanaGridPos{H: 8, W: w, X: x, Y: y},
+			FieldConfig: GrafanaFieldConfig{Defaults: GrafanaFieldDefaults{Unit: unit}},
+			Targets:     []GrafanaTarget{{RefID: "A", Expr: expr, LegendFormat: legend}},
+		}
+	}
+	return GrafanaDashboard{
+		UID:           "stacksprint-http",
+		Title:         "HTTP services",
+		SchemaVersion: 39,
+		Refresh:       "10s",
+		Time:          GrafanaTimeRange{From: "now-30m", To: "now"},
+		Panels: []GrafanaPanel{
+			panel(1, "Requests per second", "reqps", "sum by (job, route) (rate(http_requests_total[1m]))", "{{job}} {{route}}", 0, 0, 24),
+			panel(2, "p95 latency", "s", "histogram_quantile(0.95, sum by (job, le) (rate(http_request_duration_seconds_bucket[5m])))", "{{job}}", 0, 8, 12),
+			panel(3, "5xx ratio", "percentunit", `sum by (job) (rate(http_requests_total{status=~"5.."}[5m])) / sum by (job) (rate(http_requests_total[5m]))`, "{{job}}", 12, 8, 12),
+		},
+	}
+}
+
+// addObservabilityFiles writes the config the compose services mount.
+func addObservabilityFiles(tree *FileTree, req GenerateRequest) {
+	addFile(tree, "observability/otel-collector.yaml", renderYAMLDocuments(newOTelCollectorConfig()))
+	addFile(tree, "observability/prometheus.yml", renderYAMLDocuments(newPrometheusConfig(req)))
+	addFile(tree, "observability/grafana/provisioning/datasources/datasources.yaml", renderYAMLDocuments(GrafanaDatasources{
+		APIVersion: 1,
+		Datasources: []GrafanaDatasource{
+			{Name: "Prometheus", Type: "prometheus", UID: "prometheus", Access: "proxy", URL: "http://prometheus:9090", IsDefault: true},
+			{Name: "Jaeger", Type: "jaeger", UID: "jaeger", Access: "proxy", URL: "http://jaeger:16686"},
+		},
+	}))
+	addFile(tree, "observability/grafana/provisioning/dashboards/dashboards.yaml", renderYAMLDocuments(GrafanaDashboardProviders{
+		APIVersion: 1,
+		Providers: []GrafanaDashboardProvider{
+			{Name: "stacksprint", Type: "file", Options: map[string]string{"path": "/var/lib/grafana/dashboards"}},
+		},
+	}))
+	dashboard, err := json.MarshalIndent(newGrafanaDashboard(), "", "  ")
+	if err != nil {
+		return
+	}
+	addFile(tree, "observability/grafana/dashboards/http.json", string(dashboard)+"\n")
+}
+
+// observabilityReadmeSection lists where each part of the stack is served.
+func observabilityReadmeSection(req GenerateRequest) string {
+	if !req.Features.Observability {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n## Observability\n\n")
+	b.WriteString("Every app exports OpenTelemetry traces for its HTTP handlers and database calls to `otel-collector`, tagging each request span with its `X-Request-ID`, and serves Prometheus metrics on `/metrics`.\n\n")
+	b.WriteString("- Jaeger: http://localhost:16686\n- Prometheus: http://localhost:9090\n- Grafana: http://localhost:3000 (the \"HTTP services\" dashboard is provisioned)\n")
+	if req.Features.Kubernetes {
+		b.WriteString("\nThe Kubernetes manifests leave this stack out; point `OTEL_EXPORTER_OTLP_ENDPOINT` at your cluster's collector.\n")
+	}
+	return b.String()
+}
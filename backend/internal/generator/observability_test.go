@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestObservabilityComposeStack(t *testing.T) {
+	req := GenerateRequest{
+		Architecture: "microservices",
+		Database:     "postgresql",
+		Services:     []ServiceConfig{{Name: "users", Port: 8081}, {Name: "orders", Port: 8082}},
+		Features:     FeatureOptions{Observability: true, Kubernetes: true},
+	}
+	spec := newComposeSpec(req)
+	for name := range observabilityServices {
+		if _, ok := spec.Services[name]; !ok {
+			t.Errorf("compose is missing %s", name)
+		}
+	}
+	if _, ok := spec.Services["users"].DependsOn["otel-collector"]; !ok {
+		t.Errorf("users should start after otel-collector: %+v", spec.Services["users"].DependsOn)
+	}
+	assertContainsAll(t, ".env", buildEnv(req, "users", 8081), "OTEL_EXPORTER_OTLP_ENDPOINT=http://otel-collector:4317\n", "OTEL_SERVICE_NAME=users\n")
+
+	cfg := newPrometheusConfig(req)
+	if len(cfg.ScrapeConfigs) != 2 || cfg.ScrapeConfigs[1].StaticConfigs[0].Targets[0] != "orders:8082" {
+		t.Errorf("prometheus should scrape every service: %+v", cfg.ScrapeConfigs)
+	}
+
+	for _, c := range newKubernetesSpec(req).Components {
+		if observabilityServices[c.Name] {
+			t.Errorf("kubernetes should leave out %s", c.Name)
+		}
+	}
+}
+
+func TestGenerateWiresObservability(t *testing.T) {
+	registry, err := NewTemplateRegistry("../../../templates")
+	if err != nil {
+		t.Fatalf("failed to init registry: %v", err)
+	}
+	engine := NewEngine(registry)
+	cases := []struct {
+		name  string
+		req   GenerateRequest
+		files map[string][]string
+	}{
+		{
+			name: "gin",
+			req:  GenerateRequest{Language: "go", Framework: "gin", Architecture: "mvp", Database: "postgresql"},
+			files: map[string][]string{
+				"cmd/server/main.go":              {"shutdownTelemetry, err := telemetry.Setup()", "r.Use(middleware.RequestID(), telemetry.Middleware())", `r.GET("/metrics", telemetry.MetricsHandler())`},
+				"internal/telemetry/http.go":      {`attribute.String("http.request_id", requestID)`, `c.GetString("requestID")`, "http_request_duration_seconds"},
+				"internal/db/connection.go":       {`otelsql.Open("pgx"`},
+				"go.mod":                          {"go.opentelemetry.io/otel/sdk v1.34.0", "github.com/XSAM/otelsql"},
+				"internal/telemetry/telemetry.go": {`attribute.String("service.name", "app")`},
+			},
+		},
+		{
+			name: "fiber",
+			req:  GenerateRequest{Language: "go", Framework: "fiber", Architecture: "clean", Database: "mysql", UseORM: true},
+			files: map[string][]string{
+				"cmd/server/main.go":         {"app.Use(middleware.RequestID(), telemetry.Middleware())"},
+				"internal/telemetry/http.go": {"adaptor.HTTPHandler(promhttp.Handler())", "utils.CopyString(requestID)"},
+				"internal/db/connection.go":  {"conn.Use(tracing.NewPlugin(tracing.WithoutMetrics()))"},
+			},
+		},
+		{
+			name: "express",
+			req:  GenerateRequest{Language: "node", Framework: "express", Architecture: "mvp", Database: "postgresql"},
+			files: map[string][]string{
+				"src/index.js":                     {"import { instrument } from './telemetry/metrics.js';", "instrument(app);"},
+				"src/telemetry/metrics.js":         {"app.use(requestId,", "setAttribute('http.request_id', req.requestId)"},
+				"src/telemetry/instrumentation.js": {"getNodeAutoInstrumentations("},
+				"package.json":                     {`"start": "node --import ./src/telemetry/instrumentation.js src/index.js"`, `"prom-client"`},
+			},
+		},
+		{
+			name: "fastapi",
+			req:  GenerateRequest{Language: "python", Framework: "fastapi", Architecture: "mvp", Database: "mysql"},
+			files: map[string][]string{
+				"app/main.py":      {"from app.telemetry import setup as setup_telemetry", "setup_telemetry(app)"},
+				"app/telemetry.py": {"PyMySQLInstrumentor().instrument()", "app.add_middleware(RequestIDMiddleware)", "app.add_route('/metrics', metrics"},
+				"requirements.txt": {"opentelemetry-instrumentation-fastapi==", "opentelemetry-instrumentation-pymysql=="},
+			},
+		},
+		{
+			name: "django",
+			req:  GenerateRequest{Language: "python", Framework: "django", Architecture: "mvp", Database: "postgresql"},
+			files: map[string][]string{
+				"api/apps.py":        {"from .telemetry import setup"},
+				"api/telemetry.py":   {"DjangoInstrumentor().instrument(", "PsycopgInstrumentor().instrument()", "class MetricsMiddleware"},
+				"config/settings.py": {"MIDDLEWARE = ['api.middleware.RequestIDMiddleware', 'api.telemetry.MetricsMiddleware']"},
+				"config/urls.py":     {"path('metrics', metrics)"},
+				"requirements.txt":   {"opentelemetry-instrumentation-django=="},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req.Features.Observability = true
+			out, err := engine.GenerateProject(context.Background(), tc.req)
+			if err != nil {
+				t.Fatalf("GenerateProject() error = %v", err)
+			}
+			for _, w := range out.Response.Warnings {
+				if w.Code == "INJECTION_MARKER_MISSING" {
+					t.Errorf("unexpected warning %+v", w)
+				}
+			}
+			for file, want := range tc.files {
+				assertContainsAll(t, file, out.Tree.Files[file], want...)
+			}
+			for _, f := range []string{"observability/otel-collector.yaml", "observability/prometheus.yml", "observability/grafana/provisioning/datasources/datasources.yaml", "observability/grafana/provisioning/dashboards/dashboards.yaml"} {
+				var doc map[string]any
+				if err := yaml.Unmarshal([]byte(out.Tree.Files[f]), &doc); err != nil || len(doc) == 0 {
+					t.Errorf("%s does not parse: %v", f, err)
+				}
+			}
+			var dashboard GrafanaDashboard
+			if err := json.Unmarshal([]byte(out.Tree.Files["observability/grafana/dashboards/http.json"]), &dashboard); err != nil || len(dashboard.Panels) == 0 {
+				t.Errorf("dashboard does not parse: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,708 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ImportOpenAPIModels turns an OpenAPI 3 document (YAML or JSON) into data
+// models with routes. Each operation belongs to the schema its success
+// response returns (or its request body takes), unwrapping arrays and
+// {data: [...]} envelopes, else to the model its path names; those schemas
+// and the schemas they reference become models. Properties map to fields,
+// enums, defaults and relations; operations become routes keeping their
+// operation id, method and path. Anything it cannot use is reported as a
+// warning naming the line. Only a document that is not OpenAPI 3 is an error.
+func ImportOpenAPIModels(doc string) (ModelImport, error) {
+	spec, err := parseOpenAPI(doc)
+	if err != nil {
+		return ModelImport{}, err
+	}
+	imp := &openAPIImporter{schemas: map[string]*openAPISchema{}, lines: map[string]int{}}
+	for _, s := range spec.Components.Schemas {
+		if _, dup := imp.schemas[s.Name]; !dup {
+			imp.order = append(imp.order, s.Name)
+		}
+		imp.schemas[s.Name] = s.Schema
+		imp.lines[s.Name] = s.Line
+	}
+	models := imp.models(spec.Paths)
+	if models == nil {
+		models = []DataModel{}
+	}
+	if imp.warnings == nil {
+		imp.warnings = []Warning{}
+	}
+	return ModelImport{Models: models, Warnings: imp.warnings}, nil
+}
+
+func parseOpenAPI(doc string) (*openAPIDocument, error) {
+	var spec openAPIDocument
+	if err := yaml.Unmarshal([]byte(doc), &spec); err != nil {
+		return nil, fmt.Errorf("document is not valid YAML or JSON: %w", err)
+	}
+	switch {
+	case strings.HasPrefix(spec.OpenAPI, "3."):
+		return &spec, nil
+	case spec.Swagger != "":
+		return nil, errors.New("Swagger 2.0 documents are not supported; convert the document to OpenAPI 3 first")
+	}
+	return nil, errors.New("document has no openapi: 3.x version field")
+}
+
+// -------------------------------------------------------------------------
+// Document
+// -------------------------------------------------------------------------
+
+type openAPIDocument struct {
+	OpenAPI    string       `yaml:"openapi"`
+	Swagger    string       `yaml:"swagger"`
+	Paths      openAPIPaths `yaml:"paths"`
+	Components struct {
+		Schemas openAPISchemas `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+// openAPIPaths flattens the paths object into its operations, in document
+// order.
+type openAPIPaths []openAPIOperation
+
+type openAPIOperation struct {
+	Path        string                 `yaml:"-"`
+	Method      string                 `yaml:"-"`
+	Line        int                    `yaml:"-"`
+	OperationID string                 `yaml:"operationId"`
+	RequestBody *openAPIBody           `yaml:"requestBody"`
+	Responses   map[string]openAPIBody `yaml:"responses"`
+}
+
+var openAPIMethods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+func (p *openAPIPaths) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: paths must be a mapping", n.Line)
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		item := n.Content[i+1]
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			method := strings.ToUpper(item.Content[j].Value)
+			if !slices.Contains(openAPIMethods, method) {
+				continue
+			}
+			var op openAPIOperation
+			if err := item.Content[j+1].Decode(&op); err != nil {
+				return err
+			}
+			op.Path, op.Method, op.Line = n.Content[i].Value, method, item.Content[j].Line
+			*p = append(*p, op)
+		}
+	}
+	return nil
+}
+
+type openAPIBody struct {
+	Content map[string]struct {
+		Schema *openAPISchema `yaml:"schema"`
+	} `yaml:"content"`
+}
+
+type openAPISchema struct {
+	Ref        string           `yaml:"$ref"`
+	Type       openAPIType      `yaml:"type"`
+	Format     string           `yaml:"format"`
+	Enum       []any            `yaml:"enum"`
+	Default    any              `yaml:"default"`
+	MaxLength  int              `yaml:"maxLength"`
+	Nullable   bool             `yaml:"nullable"`
+	Required   []string         `yaml:"required"`
+	Properties openAPISchemas   `yaml:"properties"`
+	Items      *openAPISchema   `yaml:"items"`
+	AllOf      []*openAPISchema `yaml:"allOf"`
+}
+
+// openAPIType is a schema type: a string in 3.0, a string or a list such as
+// [string, "null"] in 3.1.
+type openAPIType []string
+
+func (t *openAPIType) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		*t = openAPIType{n.Value}
+		return nil
+	}
+	var list []string
+	if err := n.Decode(&list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+func (t openAPIType) name() string {
+	for _, s := range t {
+		if s != "null" {
+			return s
+		}
+	}
+	return ""
+}
+
+// openAPISchemas is a name -> schema mapping kept in document order.
+type openAPISchemas []openAPINamedSchema
+
+type openAPINamedSchema struct {
+	Name   string
+	Line   int
+	Schema *openAPISchema
+}
+
+func (s *openAPISchemas) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping of schemas", n.Line)
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		schema := &openAPISchema{}
+		if err := n.Content[i+1].Decode(schema); err != nil {
+			return err
+		}
+		*s = append(*s, openAPINamedSchema{Name: n.Content[i].Value, Line: n.Content[i].Line, Schema: schema})
+	}
+	return nil
+}
+
+// -------------------------------------------------------------------------
+// Schemas to models
+// -------------------------------------------------------------------------
+
+type openAPIImporter struct {
+	schemas  map[string]*openAPISchema
+	lines    map[string]int
+	order    []string
+	warnings []Warning
+}
+
+func (imp *openAPIImporter) warn(code, severity string, line int, reason, format string, args ...any) {
+	imp.warnings = append(imp.warnings, Warning{
+		Code:     code,
+		Severity: severity,
+		Message:  fmt.Sprintf("line %d: ", line) + fmt.Sprintf(format, args...),
+		Reason:   reason,
+	})
+}
+
+// envelopeProperties hold the items of a list or single-item envelope such
+// as {data: [Post], total: 3}; envelopeMeta are the pagination properties
+// allowed beside them.
+var (
+	envelopeProperties = []string{"data", "items", "results", "records", "content"}
+	envelopeMeta       = []string{"total", "totalcount", "count", "page", "pages", "pagesize", "perpage", "limit", "offset", "next", "previous", "prev", "cursor", "nextcursor", "hasmore", "meta", "links"}
+)
+
+func (imp *openAPIImporter) models(paths openAPIPaths) []DataModel {
+	type operation struct {
+		openAPIOperation
+		model string
+	}
+	var ops []operation
+	selected := map[string]bool{}
+	for _, o := range paths {
+		if !slices.Contains(routeMethods, o.Method) {
+			imp.warn("OPENAPI_OPERATION_SKIPPED", "info", o.Line, "Only GET, POST, PUT, PATCH and DELETE operations get handlers.", "skipped %s %s", o.Method, o.Path)
+			continue
+		}
+		model := imp.operationModel(o)
+		if model != "" {
+			selected[model] = true
+		}
+		ops = append(ops, operation{o, model})
+	}
+	if len(paths) == 0 {
+		for _, name := range imp.order {
+			if imp.isEntity(name) {
+				selected[name] = true
+			}
+		}
+	}
+	for i, o := range ops {
+		if o.model == "" {
+			ops[i].model = imp.pathModel(o.Path, selected)
+		}
+		if ops[i].model == "" {
+			imp.warn("OPENAPI_OPERATION_UNMAPPED", "warn", o.Line, "Operations are assigned to the schema they return or accept, or to the model their path names.", "%s %s matches no schema; skipped", o.Method, o.Path)
+			continue
+		}
+		selected[ops[i].model] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, name := range imp.order {
+			if !selected[name] {
+				continue
+			}
+			for _, target := range imp.references(name) {
+				if !selected[target] {
+					selected[target] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	var models []DataModel
+	index := map[string]int{}
+	owner := map[string]string{}
+	for _, name := range imp.order {
+		if !selected[name] {
+			if imp.isEntity(name) {
+				imp.warn("OPENAPI_SCHEMA_SKIPPED", "info", imp.lines[name], "Only schemas operations work on, and the schemas they reference, become models.", "schema %s skipped", name)
+			}
+			continue
+		}
+		model := imp.model(name, selected)
+		if prev, dup := owner[toPascal(model.Name)]; dup {
+			imp.warn("OPENAPI_MODEL_DUPLICATE", "warn", imp.lines[name], "Each model needs a distinct name.", "schema %s maps to model %s, already taken by schema %s", name, model.Name, prev)
+			continue
+		}
+		owner[toPascal(model.Name)] = name
+		index[name] = len(models)
+		models = append(models, model)
+	}
+
+	operationIDs := map[string]bool{}
+	for _, o := range ops {
+		i, ok := index[o.model]
+		if !ok {
+			continue
+		}
+		if bad := invalidPathParams(o.Path); len(bad) > 0 {
+			imp.warn("OPENAPI_PATH_UNSUPPORTED", "warn", o.Line, "Path parameters become handler arguments and must be identifiers.", "%s %s: path parameter %q is not an identifier; skipped", o.Method, o.Path, bad[0])
+			continue
+		}
+		id := imp.operationID(o.openAPIOperation)
+		if operationIDs[strings.ToLower(id)] {
+			imp.warn("OPENAPI_OPERATION_DUPLICATE", "warn", o.Line, "Operation ids name the generated handlers.", "%s %s: operationId %q is already used; skipped", o.Method, o.Path, id)
+			continue
+		}
+		operationIDs[strings.ToLower(id)] = true
+		models[i].Routes = append(models[i].Routes, ModelRoute{OperationID: id, Method: o.Method, Path: o.Path})
+	}
+	return models
+}
+
+func (imp *openAPIImporter) model(name string, selected map[string]bool) DataModel {
+	flat := imp.flatten(imp.schemas[name])
+	model := DataModel{Name: openAPIModelName(name)}
+	required := map[string]bool{}
+	for _, r := range flat.Required {
+		required[r] = true
+	}
+	var fields []DataField
+	var foreignKeys []string
+	seen := map[string]bool{}
+	for _, p := range flat.Properties {
+		col, ok := importedFieldName(p.Name)
+		if !ok {
+			imp.warn("OPENAPI_PROPERTY_UNSUPPORTED", "warn", p.Line, "Field names need an ASCII letter first and ASCII letters, digits or underscores after it.", "%s.%s has no usable field name; skipped", name, p.Name)
+			continue
+		}
+		if seen[col] || isIDColumn(col) {
+			continue
+		}
+		if col != openAPIColumnName(p.Name) {
+			imp.warn("OPENAPI_PROPERTY_RENAMED", "info", p.Line, "Field names are used as identifiers in the generated Go, JavaScript, Python and SQL code.", "%s.%s imported as %s", name, p.Name, col)
+		}
+		seen[col] = true
+		ps := p.Schema
+		isRequired := required[p.Name] && !ps.Nullable && !slices.Contains(ps.Type, "null")
+		if target, ok := imp.refTarget(ps); ok {
+			if selected[target] {
+				if target == name {
+					imp.warn("OPENAPI_PROPERTY_UNSUPPORTED", "warn", p.Line, "Relations from a model to itself are not supported.", "%s.%s references its own schema; skipped", name, p.Name)
+					continue
+				}
+				targetModel := openAPIModelName(target)
+				model.Relations = append(model.Relations, ModelRelation{Kind: RelationBelongsTo, Model: targetModel, Required: isRequired})
+				foreignKeys = append(foreignKeys, foreignKeyColumn(targetModel))
+				continue
+			}
+			// A named scalar schema, typically a shared enum.
+			ps = imp.flatten(ps)
+		}
+		switch {
+		case ps.Type.name() == "array":
+			target, ok := imp.refTarget(ps.Items)
+			if !ok || !selected[target] {
+				imp.warn("OPENAPI_PROPERTY_UNSUPPORTED", "warn", p.Line, "Only arrays of other model schemas map to relations.", "%s.%s is an array of scalars or inline objects; skipped", name, p.Name)
+				continue
+			}
+			if target == name {
+				imp.warn("OPENAPI_PROPERTY_UNSUPPORTED", "warn", p.Line, "Relations from a model to itself are not supported.", "%s.%s references its own schema; skipped", name, p.Name)
+				continue
+			}
+			switch imp.backReference(target, name) {
+			case "array":
+				model.Relations = append(model.Relations, ModelRelation{Kind: RelationManyToMany, Model: openAPIModelName(target)})
+			case "":
+				model.Relations = append(model.Relations, ModelRelation{Kind: RelationHasMany, Model: openAPIModelName(target)})
+			}
+			// A single back reference is imported as belongs_to on the target.
+		case ps.Type.name() == "object" || len(ps.Properties) > 0:
+			imp.warn("OPENAPI_PROPERTY_UNSUPPORTED", "warn", p.Line, "Nested objects need their own schema to become a related model.", "%s.%s is an inline object; skipped", name, p.Name)
+		default:
+			if f, ok := imp.field(name, p, ps, col, isRequired); ok {
+				fields = append(fields, f)
+			}
+		}
+	}
+	for _, f := range fields {
+		implied := false
+		for _, fk := range foreignKeys {
+			implied = implied || sameColumn(f.Name, fk)
+		}
+		if !implied {
+			model.Fields = append(model.Fields, f)
+		}
+	}
+	return model
+}
+
+func (imp *openAPIImporter) field(schema string, p openAPINamedSchema, ps *openAPISchema, col string, required bool) (DataField, bool) {
+	f := DataField{Name: col, Required: required}
+	switch ps.Type.name() {
+	case "integer":
+		f.Type = "int"
+	case "number":
+		f.Type = "float"
+	case "boolean":
+		f.Type = "bool"
+	case "string", "":
+		f.Type = "string"
+		if ps.Format == "date-time" || ps.Format == "date" {
+			f.Type = "datetime"
+		} else {
+			f.MaxLength = ps.MaxLength
+		}
+	default:
+		imp.warn("OPENAPI_TYPE_UNSUPPORTED", "warn", p.Line, "Fields are strings, integers, numbers, booleans or dates.", "%s.%s has unsupported type %s; skipped", schema, p.Name, ps.Type.name())
+		return f, false
+	}
+	if len(ps.Enum) > 0 {
+		values := make([]string, 0, len(ps.Enum))
+		valid := f.Type == "string"
+		for _, v := range ps.Enum {
+			if v == nil {
+				continue
+			}
+			value := fmt.Sprint(v)
+			valid = valid && enumValueRegex.MatchString(value) && !slices.Contains(values, value)
+			values = append(values, value)
+		}
+		if valid {
+			f.Type, f.Enum = "enum", values
+		} else {
+			imp.warn("OPENAPI_ENUM_UNSUPPORTED", "warn", p.Line, "Enums are string fields whose values are identifiers.", "%s.%s: enum %v imported as a plain %s", schema, p.Name, values, f.Type)
+		}
+	}
+	if ps.Default != nil {
+		def := fmt.Sprint(ps.Default)
+		if isDateTimeField(f) || checkDefault(f, def) != nil {
+			imp.warn("OPENAPI_DEFAULT_UNSUPPORTED", "warn", p.Line, "The default was dropped.", "%s.%s: default %q is not a literal of the field type", schema, p.Name, def)
+		} else {
+			f.Default = def
+		}
+	}
+	return f, true
+}
+
+// operationModel is the schema an operation works on: the first success
+// response naming one, else the request body.
+func (imp *openAPIImporter) operationModel(o openAPIOperation) string {
+	codes := make([]string, 0, len(o.Responses))
+	for code := range o.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if model := imp.bodyModel(o.Responses[code]); model != "" {
+			return model
+		}
+	}
+	if o.RequestBody != nil {
+		return imp.bodyModel(*o.RequestBody)
+	}
+	return ""
+}
+
+func (imp *openAPIImporter) bodyModel(b openAPIBody) string {
+	if media, ok := b.Content["application/json"]; ok {
+		return imp.schemaModel(media.Schema, 0)
+	}
+	types := make([]string, 0, len(b.Content))
+	for t := range b.Content {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		if model := imp.schemaModel(b.Content[t].Schema, 0); model != "" {
+			return model
+		}
+	}
+	return ""
+}
+
+// schemaModel resolves a body schema to the entity it carries: the
+// referenced schema itself, or the items of an array or envelope.
+func (imp *openAPIImporter) schemaModel(s *openAPISchema, depth int) string {
+	if s == nil || depth > 3 {
+		return ""
+	}
+	if name, ok := imp.refTarget(s); ok {
+		flat := imp.flatten(imp.schemas[name])
+		if inner := imp.envelope(flat); inner != "" {
+			return inner
+		}
+		if len(flat.Properties) > 0 {
+			return name
+		}
+		return imp.schemaModel(flat.Items, depth+1)
+	}
+	if s.Items != nil {
+		return imp.schemaModel(s.Items, depth+1)
+	}
+	return imp.envelope(imp.flatten(s))
+}
+
+// envelope returns the schema an envelope such as {data: [Post], total: 3}
+// or {data: Post} wraps, or "" when s is not an envelope.
+func (imp *openAPIImporter) envelope(s *openAPISchema) string {
+	inner := ""
+	for _, p := range s.Properties {
+		key := strings.ToLower(strings.ReplaceAll(p.Name, "_", ""))
+		if slices.Contains(envelopeMeta, key) {
+			continue
+		}
+		if !slices.Contains(envelopeProperties, key) || inner != "" {
+			return ""
+		}
+		item := p.Schema
+		if item.Type.name() == "array" {
+			item = item.Items
+		}
+		target, ok := imp.refTarget(item)
+		if !ok || len(imp.flatten(imp.schemas[target]).Properties) == 0 {
+			return ""
+		}
+		inner = target
+	}
+	return inner
+}
+
+func (imp *openAPIImporter) isEntity(name string) bool {
+	flat := imp.flatten(imp.schemas[name])
+	return len(flat.Properties) > 0 && imp.envelope(flat) == ""
+}
+
+// pathModel is the model named by the last static path segment that names
+// one: /users/{id}/posts -> Post, /posts/{id}/publish -> Post.
+func (imp *openAPIImporter) pathModel(p string, selected map[string]bool) string {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		seg := segments[i]
+		if seg == "" || strings.Contains(seg, "{") {
+			continue
+		}
+		want := singularModelName(strings.ToLower(seg))
+		for _, pass := range []bool{true, false} {
+			for _, name := range imp.order {
+				if selected[name] == pass && sameColumn(openAPIModelName(name), want) && imp.isEntity(name) {
+					return name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// references lists the entity schemas name's properties point at.
+func (imp *openAPIImporter) references(name string) []string {
+	var out []string
+	for _, p := range imp.flatten(imp.schemas[name]).Properties {
+		target, ok := imp.refTarget(p.Schema)
+		if !ok && p.Schema.Items != nil {
+			target, ok = imp.refTarget(p.Schema.Items)
+		}
+		if ok && imp.isEntity(target) {
+			out = append(out, target)
+		}
+	}
+	return out
+}
+
+// backReference reports how schema from points back at schema to: "array",
+// "single" or "".
+func (imp *openAPIImporter) backReference(from, to string) string {
+	for _, p := range imp.flatten(imp.schemas[from]).Properties {
+		if target, ok := imp.refTarget(p.Schema); ok && target == to {
+			return "single"
+		}
+		if target, ok := imp.refTarget(p.Schema.Items); ok && target == to && p.Schema.Type.name() == "array" {
+			return "array"
+		}
+	}
+	return ""
+}
+
+// refTarget is the component schema s references, directly or through the
+// single-element allOf used to annotate a reference.
+func (imp *openAPIImporter) refTarget(s *openAPISchema) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	ref := s.Ref
+	if ref == "" && len(s.AllOf) == 1 {
+		ref = s.AllOf[0].Ref
+	}
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok {
+		return "", false
+	}
+	_, ok = imp.schemas[name]
+	return name, ok
+}
+
+// flatten resolves references and merges allOf parts into one schema.
+func (imp *openAPIImporter) flatten(s *openAPISchema) *openAPISchema {
+	return imp.flattenSeen(s, map[string]bool{})
+}
+
+func (imp *openAPIImporter) flattenSeen(s *openAPISchema, seen map[string]bool) *openAPISchema {
+	if s == nil {
+		return &openAPISchema{}
+	}
+	if s.Ref != "" {
+		name, ok := imp.refTarget(s)
+		if !ok || seen[name] {
+			return &openAPISchema{}
+		}
+		seen[name] = true
+		return imp.flattenSeen(imp.schemas[name], seen)
+	}
+	if len(s.AllOf) == 0 {
+		return s
+	}
+	out := *s
+	out.AllOf = nil
+	out.Properties = append(openAPISchemas(nil), s.Properties...)
+	out.Required = append([]string(nil), s.Required...)
+	for _, part := range s.AllOf {
+		flat := imp.flattenSeen(part, seen)
+		out.Properties = append(out.Properties, flat.Properties...)
+		out.Required = append(out.Required, flat.Required...)
+		// Keywords beside allOf win over the parts'.
+		if len(out.Type) == 0 {
+			out.Type = flat.Type
+		}
+		if len(out.Enum) == 0 {
+			out.Enum = flat.Enum
+		}
+		if out.Format == "" {
+			out.Format = flat.Format
+		}
+		if out.Default == nil {
+			out.Default = flat.Default
+		}
+		if out.MaxLength == 0 {
+			out.MaxLength = flat.MaxLength
+		}
+		if out.Items == nil {
+			out.Items = flat.Items
+		}
+	}
+	return &out
+}
+
+// operationID returns the operation's id as a handler-safe identifier,
+// deriving one from the method and path when it has none.
+func (imp *openAPIImporter) operationID(o openAPIOperation) string {
+	id := strings.TrimSpace(o.OperationID)
+	if id == "" {
+		derived := camelIdentifier(strings.ToLower(o.Method) + " " + pathParamRegex.ReplaceAllString(o.Path, "by $1"))
+		imp.warn("OPENAPI_OPERATION_ID_GENERATED", "info", o.Line, "Operation ids name the generated handlers.", "%s %s has no operationId; using %s", o.Method, o.Path, derived)
+		return derived
+	}
+	if !operationIDRegex.MatchString(id) {
+		renamed := camelIdentifier(id)
+		imp.warn("OPENAPI_OPERATION_ID_RENAMED", "info", o.Line, "Operation ids name the generated handlers and must be identifiers.", "operationId %q renamed to %s", id, renamed)
+		return renamed
+	}
+	return id
+}
+
+func invalidPathParams(p string) []string {
+	var bad []string
+	for _, param := range pathParams(p) {
+		if !enumValueRegex.MatchString(param) {
+			bad = append(bad, param)
+		}
+	}
+	return bad
+}
+
+// camelIdentifier joins the alphanumeric words of s in camelCase:
+// "posts.list" -> postsList, "get /posts/by id" -> getPostsById.
+func camelIdentifier(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+	var b strings.Builder
+	for i, w := range words {
+		if i == 0 {
+			b.WriteString(lowerFirst(w))
+		} else {
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+	}
+	out := b.String()
+	if out == "" || !unicode.IsLetter(rune(out[0])) {
+		out = "op" + strings.ToUpper(out[:min(len(out), 1)]) + out[min(len(out), 1):]
+	}
+	return out
+}
+
+// openAPIColumnName snake-cases a property name, keeping acronyms together:
+// createdAt -> created_at, avatarURL -> avatar_url.
+func openAPIColumnName(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	for i, r := range rs {
+		switch {
+		case !(unicode.IsLetter(r) || unicode.IsDigit(r)) || r > unicode.MaxASCII:
+			b.WriteByte('_')
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := rs[i-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return strings.Trim(b.String(), "_")
+}
+
+// openAPIModelName turns a schema name into a model name: OrderItem stays,
+// order-item and HTTPLog become OrderItem and HttpLog.
+func openAPIModelName(schema string) string {
+	return toPascal(openAPIColumnName(schema))
+}
@@ -0,0 +1,341 @@
+package generator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// blogOpenAPI covers envelopes, allOf enums, $ref relations, a missing and an
+// invalid operationId, a custom action and schemas no operation uses.
+const blogOpenAPI = `openapi: 3.0.3
+info: {title: Blog, version: "1"}
+paths:
+  /users/{userId}/posts:
+    get:
+      operationId: listUserPosts
+      responses:
+        '200':
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/PostPage'}
+    post:
+      operationId: posts.create
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/NewPost'}
+      responses:
+        '201':
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Post'}
+  /posts/{postId}:
+    get:
+      operationId: getPost
+      responses:
+        '200': {content: {application/json: {schema: {$ref: '#/components/schemas/Post'}}}}
+    patch:
+      operationId: updatePost
+      requestBody: {content: {application/json: {schema: {$ref: '#/components/schemas/NewPost'}}}}
+      responses:
+        '200': {content: {application/json: {schema: {$ref: '#/components/schemas/Post'}}}}
+    delete:
+      responses:
+        '204': {description: gone}
+  /posts/{postId}/publish:
+    post:
+      operationId: publishPost
+      responses:
+        '202': {description: ok}
+  /health:
+    get:
+      operationId: health
+      responses: {'200': {description: ok}}
+components:
+  schemas:
+    Error:
+      type: object
+      properties: {message: {type: string}}
+    PostStatus:
+      type: string
+      enum: [draft, published]
+    User:
+      type: object
+      required: [email]
+      properties:
+        id: {type: integer}
+        email: {type: string, maxLength: 120}
+        avatarURL: {type: string, nullable: true}
+    Post:
+      type: object
+      required: [title, author]
+      properties:
+        id: {type: integer, readOnly: true}
+        title: {type: string, maxLength: 200}
+        status:
+          allOf: [{$ref: '#/components/schemas/PostStatus'}]
+          default: draft
+        views: {type: integer, default: 0}
+        createdAt: {type: string, format: date-time}
+        author: {$ref: '#/components/schemas/User'}
+        userId: {type: integer}
+        tags: {type: array, items: {$ref: '#/components/schemas/Tag'}}
+        labels: {type: array, items: {type: string}}
+    NewPost:
+      type: object
+      properties: {title: {type: string}}
+    PostPage:
+      type: object
+      properties:
+        data: {type: array, items: {$ref: '#/components/schemas/Post'}}
+        total: {type: integer}
+    Tag:
+      type: object
+      properties:
+        label: {type: string}
+        posts: {type: array, items: {$ref: '#/components/schemas/Post'}}
+`
+
+func TestImportOpenAPIModels(t *testing.T) {
+	got, err := ImportOpenAPIModels(blogOpenAPI)
+	if err != nil {
+		t.Fatalf("ImportOpenAPIModels() error = %v", err)
+	}
+
+	want := []DataModel{
+		{Name: "User", Fields: []DataField{
+			{Name: "email", Type: "string", Required: true, MaxLength: 120},
+			{Name: "avatar_url", Type: "string"},
+		}},
+		{
+			Name: "Post",
+			Fields: []DataField{
+				{Name: "title", Type: "string", Required: true, MaxLength: 200},
+				{Name: "status", Type: "enum", Default: "draft", Enum: []string{"draft", "published"}},
+				{Name: "views", Type: "int", Default: "0"},
+				{Name: "created_at", Type: "datetime"},
+			},
+			Relations: []ModelRelation{{Kind: RelationBelongsTo, Model: "User", Required: true}, {Kind: RelationManyToMany, Model: "Tag"}},
+			Routes: []ModelRoute{
+				{OperationID: "listUserPosts", Method: "GET", Path: "/users/{userId}/posts"},
+				{OperationID: "postsCreate", Method: "POST", Path: "/users/{userId}/posts"},
+				{OperationID: "getPost", Method: "GET", Path: "/posts/{postId}"},
+				{OperationID: "updatePost", Method: "PATCH", Path: "/posts/{postId}"},
+				{OperationID: "deletePostsByPostId", Method: "DELETE", Path: "/posts/{postId}"},
+				{OperationID: "publishPost", Method: "POST", Path: "/posts/{postId}/publish"},
+			},
+		},
+		{Name: "Tag", Fields: []DataField{{Name: "label", Type: "string"}}, Relations: []ModelRelation{{Kind: RelationManyToMany, Model: "Post"}}},
+	}
+	if !reflect.DeepEqual(got.Models, want) {
+		t.Errorf("ImportOpenAPIModels() models =\n%+v\nwant\n%+v", got.Models, want)
+	}
+
+	var warnings []string
+	for _, w := range got.Warnings {
+		warnings = append(warnings, w.Code+" "+w.Message[:strings.Index(w.Message, ":")])
+	}
+	wantWarnings := []string{
+		"OPENAPI_OPERATION_UNMAPPED line 42",
+		"OPENAPI_SCHEMA_SKIPPED line 47",
+		"OPENAPI_PROPERTY_UNSUPPORTED line 74",
+		"OPENAPI_SCHEMA_SKIPPED line 75",
+		"OPENAPI_OPERATION_ID_RENAMED line 12",
+		"OPENAPI_OPERATION_ID_GENERATED line 33",
+	}
+	if !reflect.DeepEqual(warnings, wantWarnings) {
+		t.Errorf("warnings = %q, want %q", warnings, wantWarnings)
+	}
+	if v := ValidateAll(GenerateRequest{Language: "go", Framework: "gin", Architecture: "mvp", Database: "postgresql", Root: RootOptions{Mode: "new", Name: "x"}, Custom: CustomOptions{Models: got.Models}}); len(v) != 0 {
+		t.Errorf("imported models do not validate: %v", v)
+	}
+}
+
+func TestImportOpenAPIModelsPropertyNames(t *testing.T) {
+	doc := `openapi: 3.0.3
+info: {title: x, version: "1"}
+paths: {}
+components:
+  schemas:
+    Lesson:
+      type: object
+      properties:
+        class: {type: string}
+        firstName: {type: string}
+        2nd: {type: integer}
+        ñame: {type: string}
+`
+	got, err := ImportOpenAPIModels(doc)
+	if err != nil {
+		t.Fatalf("ImportOpenAPIModels() error = %v", err)
+	}
+	want := []DataModel{{Name: "Lesson", Fields: []DataField{{Name: "class_", Type: "string"}, {Name: "first_name", Type: "string"}}}}
+	if !reflect.DeepEqual(got.Models, want) {
+		t.Errorf("ImportOpenAPIModels() models =\n%+v\nwant\n%+v", got.Models, want)
+	}
+	var warnings []string
+	for _, w := range got.Warnings {
+		warnings = append(warnings, w.Code+" "+w.Message)
+	}
+	wantWarnings := []string{
+		"OPENAPI_PROPERTY_RENAMED line 9: Lesson.class imported as class_",
+		"OPENAPI_PROPERTY_UNSUPPORTED line 11: Lesson.2nd has no usable field name; skipped",
+		"OPENAPI_PROPERTY_UNSUPPORTED line 12: Lesson.ñame has no usable field name; skipped",
+	}
+	if !reflect.DeepEqual(warnings, wantWarnings) {
+		t.Errorf("warnings =\n%s\nwant\n%s", strings.Join(warnings, "\n"), strings.Join(wantWarnings, "\n"))
+	}
+}
+
+func TestImportOpenAPIModelsRejectsOtherDocuments(t *testing.T) {
+	for name, doc := range map[string]string{
+		"swagger 2":   "swagger: '2.0'\npaths: {}\n",
+		"no version":  "paths: {}\n",
+		"not yaml":    "openapi: [3.0\n",
+		"openapi 2.x": "openapi: 2.0.0\n",
+	} {
+		if _, err := ImportOpenAPIModels(doc); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	v := ValidateAll(GenerateRequest{Language: "go", Framework: "gin", Architecture: "mvp", Database: "none", Root: RootOptions{Mode: "new", Name: "x"}, Custom: CustomOptions{OpenAPI: "swagger: '2.0'\n"}})
+	if len(v) != 1 || v[0].Code != "OPENAPI_INVALID" || v[0].Pointer != "/custom/openapi" {
+		t.Errorf("ValidateAll() = %+v", v)
+	}
+}
+
+func TestApplyRuleEngineImportsModelsFromOpenAPI(t *testing.T) {
+	req := GenerateRequest{Custom: CustomOptions{
+		Models:  []DataModel{{Name: "Post", Fields: []DataField{{Name: "body", Type: "string"}}}},
+		OpenAPI: blogOpenAPI,
+	}}
+	got, decisions, warnings := ApplyRuleEngine(req)
+	if got.Custom.OpenAPI != "" || len(got.Custom.Models) != 3 {
+		t.Fatalf("models = %+v", got.Custom.Models)
+	}
+	post := got.Custom.Models[0]
+	if post.Fields[0].Name != "body" || len(post.Routes) != 6 {
+		t.Errorf("declared Post should keep its fields and take the imported routes: %+v", post)
+	}
+	if len(decisions) != 1 || decisions[0].Code != "MODELS_IMPORTED_FROM_OPENAPI" || decisions[0].Description != "Imported 3 model(s) and 6 route(s) from custom.openapi." {
+		t.Errorf("decisions = %+v", decisions)
+	}
+	shadowed := 0
+	for _, w := range warnings {
+		if w.Code == "OPENAPI_MODEL_SHADOWED" {
+			shadowed++
+		}
+	}
+	if shadowed != 1 {
+		t.Errorf("warnings = %+v", warnings)
+	}
+}
+
+func TestModelRoutesInferActions(t *testing.T) {
+	m := DataModel{Name: "Post", Routes: []ModelRoute{
+		{OperationID: "a", Method: "get", Path: "/users/{userId}/posts"},
+		{OperationID: "b", Method: "POST", Path: "/posts"},
+		{OperationID: "c", Method: "GET", Path: "/posts/{postId}"},
+		{OperationID: "d", Method: "PATCH", Path: "/posts/{postId}"},
+		{OperationID: "e", Method: "DELETE", Path: "/posts/{postId}"},
+		{OperationID: "f", Method: "POST", Path: "/posts/{postId}/publish"},
+		{OperationID: "g", Method: "POST", Path: "/posts/{postId}"},
+		{OperationID: "h", Method: "GET", Path: "/feed", Action: "List"},
+	}}
+	var got []string
+	for _, r := range modelRoutes(m) {
+		got = append(got, r.Method+" "+r.Action+" "+r.Param)
+	}
+	want := []string{"GET list ", "POST create ", "GET get postId", "PATCH update postId", "DELETE delete postId", "POST custom ", "POST custom postId", "GET list "}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("modelRoutes() = %q, want %q", got, want)
+	}
+	if defaults := modelRoutes(DataModel{Name: "Tag"}); len(defaults) != 5 || defaults[1].OperationID != "getTag" || defaults[1].Path != "/tags/{id}" {
+		t.Errorf("default routes = %+v", defaults)
+	}
+}
+
+func TestValidateRoutes(t *testing.T) {
+	models := []DataModel{
+		{Name: "Post", Routes: []ModelRoute{
+			{OperationID: "1post", Method: "GET", Path: "/posts"},
+			{OperationID: "listPosts", Method: "TRACE", Path: "/posts"},
+			{OperationID: "getPost", Method: "GET", Path: "posts/{id}"},
+			{OperationID: "getPost", Method: "GET", Path: "/posts/{post-id}"},
+			{OperationID: "removePost", Method: "DELETE", Path: "/posts", Action: "delete"},
+			{OperationID: "archivePost", Method: "POST", Path: "/posts/{id}", Action: "archive"},
+			{OperationID: "latestPost", Method: "GET", Path: "/tags/{tagId}"},
+		}},
+		{Name: "Tag"},
+	}
+	v := &validator{}
+	validateRoutes(v, models)
+	got := map[string]string{}
+	for _, e := range v.errs {
+		got[e.Pointer] = e.Code
+	}
+	expected := map[string]string{
+		"/custom/models/0/routes/0/operation_id": "ROUTE_OPERATION_INVALID",
+		"/custom/models/0/routes/1/method":       "ROUTE_METHOD_INVALID",
+		"/custom/models/0/routes/2/path":         "ROUTE_PATH_INVALID",
+		"/custom/models/0/routes/3/operation_id": "ROUTE_OPERATION_DUPLICATE",
+		"/custom/models/0/routes/3/path":         "ROUTE_PATH_INVALID",
+		"/custom/models/0/routes/4/action":       "ROUTE_ACTION_INVALID",
+		"/custom/models/0/routes/5/action":       "ROUTE_ACTION_INVALID",
+		"/custom/models/0/routes/6":              "ROUTE_DUPLICATE",
+	}
+	for pointer, code := range expected {
+		if got[pointer] != code {
+			t.Errorf("pointer %s: got code %q, want %q", pointer, got[pointer], code)
+		}
+	}
+	if len(v.errs) != len(expected) {
+		t.Errorf("got %d errors, want %d: %+v", len(v.errs), len(expected), v.errs)
+	}
+}
+
+func TestGenerateServesModelRoutes(t *testing.T) {
+	registry, err := NewTemplateRegistry("../../../templates")
+	if err != nil {
+		t.Fatalf("failed to init registry: %v", err)
+	}
+	engine := NewEngine(registry)
+	routes := []ModelRoute{
+		{OperationID: "listPosts", Method: "GET", Path: "/posts"},
+		{OperationID: "publishPost", Method: "POST", Path: "/posts/{postId}/publish"},
+	}
+
+	cases := []struct {
+		language, framework, architecture string
+		files                             map[string][]string
+	}{
+		{"go", "gin", "mvp", map[string][]string{
+			"cmd/server/main.go":                {`r.GET("/posts", handlers.ListPosts)`, `r.POST("/posts/:postId/publish", handlers.PublishPost)`},
+			"internal/handlers/post_handler.go": {"func PublishPost(c *gin.Context)", `"publishPost is not implemented"`},
+		}},
+		{"node", "express", "mvp", map[string][]string{
+			"src/routes/posts.js": {"router.get('/posts', listPosts);", "router.post('/posts/:postId/publish', publishPost);"},
+		}},
+		{"python", "fastapi", "mvp", map[string][]string{
+			"app/routes/posts.py": {"@router.get('/posts')\ndef list_posts(", "@router.post('/posts/{postId}/publish')\ndef publish_post(postId: str):"},
+		}},
+	}
+	for _, tc := range cases {
+		project, err := engine.GenerateProject(context.Background(), GenerateRequest{
+			Language:     tc.language,
+			Framework:    tc.framework,
+			Architecture: tc.architecture,
+			Database:     "none",
+			FileToggles:  FileToggleOptions{ExampleCRUD: ptr(true)},
+			Custom:       CustomOptions{Models: []DataModel{{Name: "Post", Fields: []DataField{{Name: "title", Type: "string"}}, Routes: routes}}},
+		})
+		if err != nil {
+			t.Fatalf("%s: GenerateProject() error = %v", tc.language, err)
+		}
+		for path, want := range tc.files {
+			assertContainsAll(t, tc.language+" "+path, project.Tree.Files[path], want...)
+		}
+	}
+}
@@ -0,0 +1,388 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// =========================================================================
+// OpenAPISpec — structured OpenAPI 3 specification model.
+//
+// docs/openapi.yaml is produced by populating this model from the resolved
+// models and routes and marshaling via yaml.v3, like ComposeSpec.
+// =========================================================================
+
+// OpenAPISpec is the top-level document written to docs/openapi.yaml.
+type OpenAPISpec struct {
+	OpenAPI    string                      `yaml:"openapi"`
+	Info       OpenAPIInfo                 `yaml:"info"`
+	Servers    []OpenAPIServer             `yaml:"servers,omitempty"`
+	Security   []map[string][]string       `yaml:"security,omitempty"`
+	Paths      map[string]*OpenAPIPathItem `yaml:"paths"`
+	Components *OpenAPIComponents          `yaml:"components,omitempty"`
+}
+
+type OpenAPIInfo struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+type OpenAPIServer struct {
+	URL string `yaml:"url"`
+}
+
+// OpenAPIPathItem holds the operations on one path, in the order Swagger UI
+// lists them.
+type OpenAPIPathItem struct {
+	Get    *OpenAPIOperation `yaml:"get,omitempty"`
+	Post   *OpenAPIOperation `yaml:"post,omitempty"`
+	Put    *OpenAPIOperation `yaml:"put,omitempty"`
+	Patch  *OpenAPIOperation `yaml:"patch,omitempty"`
+	Delete *OpenAPIOperation `yaml:"delete,omitempty"`
+}
+
+type OpenAPIOperation struct {
+	OperationID string                     `yaml:"operationId"`
+	Tags        []string                   `yaml:"tags,omitempty"`
+	Parameters  []OpenAPIParameter         `yaml:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `yaml:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `yaml:"responses"`
+	// Security overrides the document's requirement; an empty list makes the
+	// operation public.
+	Security *[]map[string][]string `yaml:"security,omitempty"`
+}
+
+type OpenAPIParameter struct {
+	Name     string               `yaml:"name"`
+	In       string               `yaml:"in"`
+	Required bool                 `yaml:"required"`
+	Schema   *OpenAPISchemaObject `yaml:"schema"`
+}
+
+type OpenAPIRequestBody struct {
+	Required bool                        `yaml:"required"`
+	Content  map[string]OpenAPIMediaType `yaml:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                      `yaml:"description"`
+	Content     map[string]OpenAPIMediaType `yaml:"content,omitempty"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchemaObject `yaml:"schema"`
+}
+
+// OpenAPISchemaObject is the subset of the Schema Object the generated models
+// need.
+type OpenAPISchemaObject struct {
+	Ref        string               `yaml:"$ref,omitempty"`
+	Type       string               `yaml:"type,omitempty"`
+	Format     string               `yaml:"format,omitempty"`
+	Enum       []string             `yaml:"enum,omitempty"`
+	Default    any                  `yaml:"default,omitempty"`
+	MaxLength  int                  `yaml:"maxLength,omitempty"`
+	ReadOnly   bool                 `yaml:"readOnly,omitempty"`
+	Required   []string             `yaml:"required,omitempty"`
+	Properties OpenAPIProperties    `yaml:"properties,omitempty"`
+	Items      *OpenAPISchemaObject `yaml:"items,omitempty"`
+}
+
+// OpenAPIProperties keeps properties in field order; a map would sort them.
+type OpenAPIProperties []OpenAPIProperty
+
+type OpenAPIProperty struct {
+	Name   string
+	Schema *OpenAPISchemaObject
+}
+
+func (p OpenAPIProperties) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, prop := range p {
+		value, err := toYAMLNode(prop.Schema)
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: prop.Name}, value)
+	}
+	return node, nil
+}
+
+type OpenAPIComponents struct {
+	Schemas         map[string]*OpenAPISchemaObject  `yaml:"schemas,omitempty"`
+	SecuritySchemes map[string]OpenAPISecurityScheme `yaml:"securitySchemes,omitempty"`
+}
+
+type OpenAPISecurityScheme struct {
+	Type         string `yaml:"type"`
+	Scheme       string `yaml:"scheme"`
+	BearerFormat string `yaml:"bearerFormat,omitempty"`
+}
+
+// openAPITarget describes what one generated app serves.
+type openAPITarget struct {
+	Title      string
+	Port       int
+	HealthPath string
+	Models     bool // the app serves the model routes
+}
+
+// addOpenAPISpecs writes docs/openapi.yaml for the project, or one per
+// service root for microservices.
+func addOpenAPISpecs(tree *FileTree, req GenerateRequest, healthPath string, models bool) {
+	title := req.Root.Name
+	if title == "" {
+		title = "StackSprint API"
+	}
+	models = models && isEnabled(req.FileToggles.ExampleCRUD)
+	if req.Architecture == "microservices" {
+		for _, svc := range req.Services {
+			spec := buildOpenAPISpec(req, openAPITarget{Title: title + " " + svc.Name, Port: svc.Port, HealthPath: healthPath, Models: models})
+			addFile(tree, path.Join("services", svc.Name, "docs/openapi.yaml"), renderOpenAPISpec(spec))
+		}
+		return
+	}
+	spec := buildOpenAPISpec(req, openAPITarget{Title: title, Port: 8080, HealthPath: healthPath, Models: models})
+	addFile(tree, "docs/openapi.yaml", renderOpenAPISpec(spec))
+}
+
+// buildOpenAPISpec documents the health check and, when the target serves
+// them, every model's routes with a schema per model.
+func buildOpenAPISpec(req GenerateRequest, target openAPITarget) OpenAPISpec {
+	spec := OpenAPISpec{
+		OpenAPI: "3.0.3",
+		Info:    OpenAPIInfo{Title: target.Title, Version: "1.0.0"},
+		Servers: []OpenAPIServer{{URL: fmt.Sprintf("http://localhost:%d", target.Port)}},
+		Paths:   map[string]*OpenAPIPathItem{},
+	}
+	health := &OpenAPIOperation{
+		OperationID: "health",
+		Responses:   map[string]OpenAPIResponse{"200": {Description: "Service is up"}},
+	}
+	spec.Paths[target.HealthPath] = &OpenAPIPathItem{Get: health}
+
+	components := &OpenAPIComponents{}
+	if target.Models {
+		components.Schemas = map[string]*OpenAPISchemaObject{}
+		for _, m := range resolvedModels(req.Custom.Models) {
+			components.Schemas[m.Name] = openAPIModelSchema(m)
+			for _, r := range modelRoutes(m) {
+				item := spec.Paths[r.Path]
+				if item == nil {
+					item = &OpenAPIPathItem{}
+					spec.Paths[r.Path] = item
+				}
+				item.set(r.Method, openAPIRouteOperation(m, r))
+			}
+		}
+	}
+	if req.Features.JWTAuth {
+		components.SecuritySchemes = map[string]OpenAPISecurityScheme{
+			"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		}
+		spec.Security = []map[string][]string{{"bearerAuth": {}}}
+		public := []map[string][]string{}
+		health.Security = &public
+		addOpenAPIAuthPaths(spec.Paths, &public)
+	}
+	if len(components.Schemas) > 0 || len(components.SecuritySchemes) > 0 {
+		spec.Components = components
+	}
+	return spec
+}
+
+// addOpenAPIAuthPaths documents the public register, login and refresh
+// endpoints the JWT auth module serves.
+func addOpenAPIAuthPaths(paths map[string]*OpenAPIPathItem, public *[]map[string][]string) {
+	str := func(format string) *OpenAPISchemaObject { return &OpenAPISchemaObject{Type: "string", Format: format} }
+	body := func(schema *OpenAPISchemaObject) *OpenAPIRequestBody {
+		return &OpenAPIRequestBody{Required: true, Content: map[string]OpenAPIMediaType{"application/json": {Schema: schema}}}
+	}
+	credentials := &OpenAPISchemaObject{Type: "object", Required: []string{"email", "password"}, Properties: OpenAPIProperties{
+		{Name: "email", Schema: &OpenAPISchemaObject{Type: "string", Format: "email", MaxLength: 255}},
+		{Name: "password", Schema: str("password")},
+	}}
+	refresh := &OpenAPISchemaObject{Type: "object", Required: []string{"refresh_token"}, Properties: OpenAPIProperties{
+		{Name: "refresh_token", Schema: str("")},
+	}}
+	tokens := OpenAPIResponse{Description: "Token pair", Content: map[string]OpenAPIMediaType{"application/json": {Schema: &OpenAPISchemaObject{
+		Type: "object",
+		Properties: OpenAPIProperties{
+			{Name: "access_token", Schema: str("")},
+			{Name: "refresh_token", Schema: str("")},
+			{Name: "token_type", Schema: str("")},
+			{Name: "expires_in", Schema: &OpenAPISchemaObject{Type: "integer"}},
+		},
+	}}}}
+	account := &OpenAPISchemaObject{Type: "object", Properties: OpenAPIProperties{
+		{Name: "id", Schema: &OpenAPISchemaObject{Type: "integer"}},
+		{Name: "email", Schema: str("email")},
+	}}
+	paths["/auth/register"] = &OpenAPIPathItem{Post: &OpenAPIOperation{
+		OperationID: "authRegister", Tags: []string{"auth"}, RequestBody: body(credentials), Security: public,
+		Responses: map[string]OpenAPIResponse{
+			"201": {Description: "Registered account", Content: map[string]OpenAPIMediaType{"application/json": {Schema: account}}},
+			"400": {Description: "Invalid credentials"},
+			"409": {Description: "Email already registered"},
+		},
+	}}
+	paths["/auth/login"] = &OpenAPIPathItem{Post: &OpenAPIOperation{
+		OperationID: "authLogin", Tags: []string{"auth"}, RequestBody: body(credentials), Security: public,
+		Responses: map[string]OpenAPIResponse{"200": tokens, "401": {Description: "Invalid email or password"}},
+	}}
+	paths["/auth/refresh"] = &OpenAPIPathItem{Post: &OpenAPIOperation{
+		OperationID: "authRefresh", Tags: []string{"auth"}, RequestBody: body(refresh), Security: public,
+		Responses: map[string]OpenAPIResponse{"200": tokens, "401": {Description: "Invalid refresh token"}},
+	}}
+}
+
+func (p *OpenAPIPathItem) set(method string, op *OpenAPIOperation) {
+	switch method {
+	case "GET":
+		p.Get = op
+	case "POST":
+		p.Post = op
+	case "PUT":
+		p.Put = op
+	case "PATCH":
+		p.Patch = op
+	case "DELETE":
+		p.Delete = op
+	}
+}
+
+func openAPIRouteOperation(m DataModel, r modelRoute) *OpenAPIOperation {
+	ref := &OpenAPISchemaObject{Ref: "#/components/schemas/" + m.Name}
+	entity := func(description string) OpenAPIResponse {
+		return OpenAPIResponse{Description: description, Content: map[string]OpenAPIMediaType{"application/json": {Schema: ref}}}
+	}
+	op := &OpenAPIOperation{OperationID: r.OperationID, Tags: []string{m.Name}, Responses: map[string]OpenAPIResponse{}}
+	for _, p := range r.Params {
+		schema := &OpenAPISchemaObject{Type: "string"}
+		if p == r.Param {
+			schema = &OpenAPISchemaObject{Type: "integer"}
+		}
+		op.Parameters = append(op.Parameters, OpenAPIParameter{Name: p, In: "path", Required: true, Schema: schema})
+	}
+	if r.Action == ActionCreate || r.Action == ActionUpdate {
+		op.RequestBody = &OpenAPIRequestBody{Required: true, Content: map[string]OpenAPIMediaType{"application/json": {Schema: ref}}}
+		op.Responses["400"] = OpenAPIResponse{Description: "Invalid " + m.Name}
+	}
+	if itemRoute(r.Action) {
+		op.Responses["404"] = OpenAPIResponse{Description: m.Name + " not found"}
+	}
+	switch r.Action {
+	case ActionList:
+		for _, p := range []string{"limit", "offset"} {
+			op.Parameters = append(op.Parameters, OpenAPIParameter{Name: p, In: "query", Schema: &OpenAPISchemaObject{Type: "integer"}})
+		}
+		page := &OpenAPISchemaObject{Type: "object", Properties: OpenAPIProperties{
+			{Name: "data", Schema: &OpenAPISchemaObject{Type: "array", Items: ref}},
+			{Name: "limit", Schema: &OpenAPISchemaObject{Type: "integer"}},
+			{Name: "offset", Schema: &OpenAPISchemaObject{Type: "integer"}},
+		}}
+		op.Responses["200"] = OpenAPIResponse{Description: m.Name + " page", Content: map[string]OpenAPIMediaType{
+			"application/json": {Schema: page},
+		}}
+	case ActionGet:
+		op.Responses["200"] = entity(m.Name)
+	case ActionCreate:
+		op.Responses["201"] = entity("Created " + m.Name)
+	case ActionUpdate:
+		op.Responses["200"] = entity("Updated " + m.Name)
+	case ActionDelete:
+		op.Responses["204"] = OpenAPIResponse{Description: "Deleted"}
+	default:
+		op.Responses["501"] = OpenAPIResponse{Description: "Not implemented"}
+	}
+	return op
+}
+
+// openAPIModelSchema describes a model as stored: a read-only id, then every
+// column including belongs_to foreign keys.
+func openAPIModelSchema(m DataModel) *OpenAPISchemaObject {
+	schema := &OpenAPISchemaObject{
+		Type:       "object",
+		Properties: OpenAPIProperties{{Name: "id", Schema: &OpenAPISchemaObject{Type: "integer", ReadOnly: true}}},
+	}
+	for _, c := range storedColumns(m) {
+		prop := &OpenAPISchemaObject{}
+		switch {
+		case len(c.Enum) > 0:
+			prop.Type = "string"
+			prop.Enum = c.Enum
+		case c.Type == "int" || c.Type == "integer":
+			prop.Type = "integer"
+		case c.Type == "float" || c.Type == "float64" || c.Type == "double":
+			prop.Type = "number"
+		case c.Type == "bool" || c.Type == "boolean":
+			prop.Type = "boolean"
+		case isDateTimeField(c.DataField):
+			prop.Type = "string"
+			prop.Format = "date-time"
+		default:
+			prop.Type = "string"
+			prop.MaxLength = c.MaxLength
+		}
+		if c.Default != "" && !isDateTimeField(c.DataField) {
+			prop.Default = openAPIDefault(prop.Type, c.Default)
+		}
+		if c.Required {
+			schema.Required = append(schema.Required, c.Name)
+		}
+		schema.Properties = append(schema.Properties, OpenAPIProperty{Name: c.Name, Schema: prop})
+	}
+	return schema
+}
+
+// openAPIDefault types a default for its schema, so 0 is written as a
+// number rather than the string "0".
+func openAPIDefault(typ, value string) any {
+	switch typ {
+	case "integer":
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+func renderOpenAPISpec(spec OpenAPISpec) string {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(spec); err != nil {
+		return "# openapi generation error: " + err.Error() + "\n"
+	}
+	_ = enc.Close()
+	return buf.String()
+}
+
+// swaggerUIPage renders /docs/openapi.yaml with Swagger UI. It holds no
+// backticks, so every generator can embed it in a raw or template string.
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>API docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>SwaggerUIBundle({ url: '/docs/openapi.yaml', dom_id: '#swagger-ui' });</script>
+</body>
+</html>
+`
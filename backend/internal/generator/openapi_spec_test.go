@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuildOpenAPISpecRoundTrips(t *testing.T) {
+	models := richModels()
+	models[0].Routes = []ModelRoute{
+		{OperationID: "listPosts", Method: "GET", Path: "/posts"},
+		{OperationID: "getPost", Method: "GET", Path: "/posts/{id}"},
+		{OperationID: "publishPost", Method: "POST", Path: "/posts/{postId}/publish"},
+	}
+	req := GenerateRequest{
+		Features:    FeatureOptions{JWTAuth: true},
+		FileToggles: FileToggleOptions{ExampleCRUD: ptr(true)},
+		Custom:      CustomOptions{Models: models},
+	}
+	doc := renderOpenAPISpec(buildOpenAPISpec(req, openAPITarget{Title: "blog", Port: 8081, HealthPath: "/health", Models: true}))
+
+	assertContainsAll(t, "spec", doc,
+		"  - url: http://localhost:8081\n",
+		"security:\n  - bearerAuth: []\n",
+		"      operationId: health\n      responses:\n        \"200\":\n          description: Service is up\n      security: []\n",
+		"        views:\n          type: integer\n          default: 0\n",
+		"    bearerAuth:\n      type: http\n      scheme: bearer\n      bearerFormat: JWT\n",
+		"        - name: limit\n          in: query\n          required: false\n",
+		"          description: Post page\n",
+		"  /auth/login:\n    post:\n      operationId: authLogin\n",
+		"          description: Email already registered\n      security: []\n",
+	)
+	// Properties keep field order instead of being sorted.
+	if strings.Index(doc, "        title:") > strings.Index(doc, "        status:") {
+		t.Errorf("properties are not in field order:\n%s", doc)
+	}
+
+	imported, err := ImportOpenAPIModels(doc)
+	if err != nil {
+		t.Fatalf("generated spec does not import: %v", err)
+	}
+	routes := map[string][]ModelRoute{}
+	for _, m := range imported.Models {
+		routes[m.Name] = m.Routes
+	}
+	if !reflect.DeepEqual(routes["Post"], models[0].Routes) {
+		t.Errorf("Post routes = %+v, want %+v", routes["Post"], models[0].Routes)
+	}
+	if got := routes["Tag"]; len(got) != 5 || got[0].OperationID != "listTags" || got[3].Path != "/tags/{id}" {
+		t.Errorf("Tag routes = %+v", got)
+	}
+}
+
+func TestBuildOpenAPISpecWithoutModels(t *testing.T) {
+	req := GenerateRequest{Custom: CustomOptions{Models: richModels()}}
+	var spec map[string]any
+	if err := yaml.Unmarshal([]byte(renderOpenAPISpec(buildOpenAPISpec(req, openAPITarget{Title: "x", Port: 8080, HealthPath: "/api/health"}))), &spec); err != nil {
+		t.Fatal(err)
+	}
+	paths, _ := spec["paths"].(map[string]any)
+	if len(paths) != 1 || paths["/api/health"] == nil || spec["components"] != nil || spec["security"] != nil {
+		t.Errorf("spec = %+v", spec)
+	}
+}
+
+func TestGenerateServesOpenAPIDocs(t *testing.T) {
+	registry, err := NewTemplateRegistry("../../../templates")
+	if err != nil {
+		t.Fatalf("failed to init registry: %v", err)
+	}
+	engine := NewEngine(registry)
+
+	cases := []struct {
+		language, framework, architecture string
+		files                             map[string][]string
+	}{
+		{"go", "gin", "mvp", map[string][]string{
+			"docs/openapi.yaml":  {"operationId: listPosts"},
+			"docs/docs.go":       {"//go:embed openapi.yaml\nvar Spec []byte"},
+			"cmd/server/main.go": {`"example.com/blog/docs"`, `r.GET("/docs/openapi.yaml"`},
+		}},
+		{"node", "fastify", "microservices", map[string][]string{
+			"services/users/docs/openapi.yaml": {"url: http://localhost:8081", "operationId: listPosts"},
+			"services/users/src/docs.js":       {"new URL('../docs/openapi.yaml', import.meta.url)"},
+			"services/users/src/index.js":      {"app.get('/docs', async (request, reply) => reply.type('text/html').send(docsPage));"},
+		}},
+		{"python", "fastapi", "clean", map[string][]string{
+			"docs/openapi.yaml": {"operationId: listPosts"},
+			"app/main.py":       {"from app.docs import openapi_spec\n", "app.openapi = openapi_spec\n"},
+			"requirements.txt":  {"PyYAML=="},
+		}},
+		{"python", "django", "mvp", map[string][]string{
+			"docs/openapi.yaml": {"/api/health:"},
+			"config/urls.py":    {"path('docs', docs_page)"},
+			"api/docs.py":       {"def openapi_spec(request):"},
+		}},
+	}
+	for _, tc := range cases {
+		project, err := engine.GenerateProject(context.Background(), GenerateRequest{
+			Language:     tc.language,
+			Framework:    tc.framework,
+			Architecture: tc.architecture,
+			Database:     "none",
+			Services:     []ServiceConfig{{Name: "users", Port: 8081}, {Name: "orders", Port: 8082}},
+			Features:     FeatureOptions{Swagger: true},
+			FileToggles:  FileToggleOptions{ExampleCRUD: ptr(true)},
+			Root:         RootOptions{Mode: "new", Name: "blog", Module: "example.com/blog"},
+			Custom:       CustomOptions{Models: []DataModel{{Name: "Post", Fields: []DataField{{Name: "title", Type: "string"}}}}},
+		})
+		if err != nil {
+			t.Fatalf("%s/%s: GenerateProject() error = %v", tc.language, tc.framework, err)
+		}
+		for path, want := range tc.files {
+			assertContainsAll(t, tc.language+" "+path, project.Tree.Files[path], want...)
+		}
+	}
+}
@@ -3,11 +3,29 @@ package generator
 import (
 	"fmt"
 	"path"
+	"strconv"
 	"strings"
 )
 
 type PythonGenerator struct{}
 
+func init() {
+	RegisterLanguage(LanguageSpec{
+		ID:            "python",
+		Frameworks:    []string{"fastapi", "django"},
+		Architectures: standardArchitectures,
+		Infra:         standardInfra,
+		Features:      standardFeatures,
+		FileToggles:   standardFileToggles,
+		// Django brings its own settings, logging, error pages, test runner
+		// and URL conf, so the FastAPI-shaped files are not generated for it.
+		FrameworkGaps: map[string][]string{
+			"django": {"logger", "global_error_handler", "sample_test", "config_loader", "base_route"},
+		},
+		New: func() Generator { return &PythonGenerator{} },
+	})
+}
+
 func (g *PythonGenerator) GenerateArchitecture(req *GenerateRequest, ctx *GenerationContext) error {
 	if req.Architecture == "microservices" {
 		for _, svc := range req.Services {
@@ -21,11 +39,16 @@ func (g *PythonGenerator) GenerateArchitecture(req *GenerateRequest, ctx *Genera
 
 					if main, ok := ctx.FileTree.Files[path.Join(svcRoot, "app/main.py")]; ok {
 						if !strings.Contains(main, "from app.routes.base import router as base_router") {
+							mainPath := path.Join(svcRoot, "app/main.py")
 							var err error
 							main, err = InjectByMarker(main, "imports", "from app.routes.base import router as base_router\n")
 							if err == nil {
-								main, _ = InjectByMarker(main, "routes", "app.include_router(base_router, prefix=\"/api/v1\")\n")
-								ctx.FileTree.Files[path.Join(svcRoot, "app/main.py")] = main
+								main, err = InjectByMarker(main, "routes", "app.include_router(base_router, prefix=\"/api/v1\")\n")
+							}
+							if err != nil {
+								ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject base router for service " + svc.Name, Reason: err.Error(), Path: mainPath})
+							} else {
+								ctx.FileTree.Files[mainPath] = main
 							}
 						}
 					}
@@ -42,11 +65,6 @@ func (g *PythonGenerator) GenerateArchitecture(req *GenerateRequest, ctx *Genera
 					addFile(ctx.FileTree, path.Join(svcRoot, "app/routes/health.py"), "from fastapi import APIRouter\n\nrouter = APIRouter()\n\n@router.get('/health')\ndef health():\n    return {'status': 'ok'}\n")
 				}
 			}
-			if req.Features.JWTAuth {
-				if req.Framework != "django" {
-					addFile(ctx.FileTree, path.Join(svcRoot, "app/auth/jwt.py"), "import os\n\nJWT_SECRET = os.getenv('JWT_SECRET', 'changeme')\n")
-				}
-			}
 			if strings.EqualFold(req.ServiceCommunication, "grpc") {
 				g.addGRPCBoilerplate(ctx.FileTree, req, svcRoot)
 			}
@@ -65,11 +83,6 @@ func (g *PythonGenerator) GenerateArchitecture(req *GenerateRequest, ctx *Genera
 				addFile(ctx.FileTree, "app/routes/health.py", "from fastapi import APIRouter\n\nrouter = APIRouter()\n\n@router.get('/health')\ndef health():\n    return {'status': 'ok'}\n")
 			}
 		}
-		if req.Features.JWTAuth {
-			if req.Framework != "django" {
-				addFile(ctx.FileTree, "app/auth/jwt.py", "import os\n\nJWT_SECRET = os.getenv('JWT_SECRET', 'changeme')\n")
-			}
-		}
 		if strings.EqualFold(req.ServiceCommunication, "grpc") {
 			addFile(ctx.FileTree, "proto/README.md", "# Shared proto definitions\n\nPlace your protobuf contracts here.\n")
 			addFile(ctx.FileTree, "proto/common.proto", "syntax = \"proto3\";\npackage stacksprint;\n\nservice InternalService {\n  rpc Ping(PingRequest) returns (PingReply);\n}\n\nmessage PingRequest {\n  string source = 1;\n}\n\nmessage PingReply {\n  string message = 1;\n}\n")
@@ -86,7 +99,9 @@ func (g *PythonGenerator) GenerateModels(req *GenerateRequest, ctx *GenerationCo
 			if req.Database != "none" {
 				g.addPythonDBBoilerplate(ctx.FileTree, req, svcRoot)
 			}
-			if isEnabled(req.FileToggles.ExampleCRUD) && req.Framework != "django" {
+			if req.Framework == "django" && djangoServesModels(*req) {
+				addDjangoModelFiles(ctx.FileTree, req, svcRoot)
+			} else if isEnabled(req.FileToggles.ExampleCRUD) && req.Framework != "django" {
 				for _, model := range resolvedModels(req.Custom.Models) {
 					g.renderPythonDynamicModel(ctx.FileTree, req, model, req.Architecture, svcRoot)
 				}
@@ -96,7 +111,9 @@ func (g *PythonGenerator) GenerateModels(req *GenerateRequest, ctx *GenerationCo
 		if req.Database != "none" {
 			g.addPythonDBBoilerplate(ctx.FileTree, req, "")
 		}
-		if isEnabled(req.FileToggles.ExampleCRUD) && req.Framework != "django" {
+		if req.Framework == "django" && djangoServesModels(*req) {
+			addDjangoModelFiles(ctx.FileTree, req, "")
+		} else if isEnabled(req.FileToggles.ExampleCRUD) && req.Framework != "django" {
 			for _, model := range resolvedModels(req.Custom.Models) {
 				g.renderPythonDynamicModel(ctx.FileTree, req, model, req.Architecture, "")
 			}
@@ -107,13 +124,7 @@ func (g *PythonGenerator) GenerateModels(req *GenerateRequest, ctx *GenerationCo
 
 func (g *PythonGenerator) GenerateInfra(req *GenerateRequest, ctx *GenerationContext) error {
 	handleInfra := func(root string, port int) {
-		if req.Infra.Redis {
-			addFile(ctx.FileTree, path.Join(root, "app/cache/redis_cache.py"), "import os\n\nclass RedisCache:\n    def __init__(self, addr: str | None = None):\n        self.addr = addr or os.getenv('REDIS_ADDR', 'redis:6379')\n\n    def ping(self) -> str:\n        return f'redis configured at {self.addr}'\n")
-		}
-		if req.Infra.Kafka {
-			addFile(ctx.FileTree, path.Join(root, "app/messaging/kafka_producer.py"), "import os\n\nclass KafkaProducer:\n    def __init__(self, brokers: str | None = None):\n        self.brokers = brokers or os.getenv('KAFKA_BROKERS', 'kafka:9092')\n\n    def publish(self, topic: str, payload: str) -> str:\n        return f'publish stub to {topic} via {self.brokers}: {payload}'\n")
-			addFile(ctx.FileTree, path.Join(root, "app/messaging/kafka_consumer.py"), "import os\n\nclass KafkaConsumer:\n    def __init__(self, brokers: str | None = None):\n        self.brokers = brokers or os.getenv('KAFKA_BROKERS', 'kafka:9092')\n\n    def subscribe(self, topic: str) -> str:\n        return f'consumer stub subscribed to {topic} via {self.brokers}'\n")
-		}
+
 		if isEnabled(req.FileToggles.Env) {
 			svcName := ""
 			if root != "" {
@@ -138,6 +149,12 @@ func (g *PythonGenerator) GenerateInfra(req *GenerateRequest, ctx *GenerationCon
 	if isEnabled(req.FileToggles.Compose) {
 		addFile(ctx.FileTree, "docker-compose.yaml", buildCompose(*req))
 	}
+	if req.Features.Observability {
+		addObservabilityFiles(ctx.FileTree, *req)
+	}
+	if req.Features.Kubernetes {
+		addKubernetesFiles(ctx.FileTree, *req)
+	}
 	return nil
 }
 
@@ -146,7 +163,7 @@ func (g *PythonGenerator) GenerateDevTools(req *GenerateRequest, ctx *Generation
 		addFile(ctx.FileTree, ".gitignore", "venv/\n__pycache__/\n*.pyc\n.env\n.DS_Store\n*.sqlite3\n.coverage\n")
 	}
 	if isEnabled(req.FileToggles.Readme) {
-		addFile(ctx.FileTree, "README.md", fmt.Sprintf("# StackSprint Generated Project\n\nLanguage: %s\nFramework: %s\nArchitecture: %s\nDatabase: %s\n\n## Run\n\n```bash\ndocker compose up --build\n```\n", req.Language, req.Framework, req.Architecture, req.Database))
+		addFile(ctx.FileTree, "README.md", fmt.Sprintf("# StackSprint Generated Project\n\nLanguage: %s\nFramework: %s\nArchitecture: %s\nDatabase: %s\n\n## Run\n\n```bash\ndocker compose up --build\n```\n", req.Language, req.Framework, req.Architecture, req.Database)+rbacReadmeSection(*req)+observabilityReadmeSection(*req)+cacheReadmeSection(*req)+messagingReadmeSection(*req)+djangoMessagingReadme(*req)+kubernetesReadmeSection(*req))
 	}
 	if req.Features.GitHubActions {
 		addFile(ctx.FileTree, ".github/workflows/ci.yaml", "name: CI\n\non:\n  push:\n  pull_request:\n\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/checkout@v4\n      - uses: actions/setup-python@v5\n        with:\n          python-version: '3.11'\n      - run: pip install pytest fastapi && pytest\n")
@@ -155,7 +172,8 @@ func (g *PythonGenerator) GenerateDevTools(req *GenerateRequest, ctx *Generation
 		var b strings.Builder
 		b.WriteString("up:\n\tdocker compose up --build\n\ndown:\n\tdocker compose down -v\n\ntest:\n\tpytest\n")
 		if req.Database != "none" && req.Framework != "django" {
-			if req.UseORM {
+			// SQLite projects create their tables on start, without Alembic.
+			if req.UseORM && req.Database != "sqlite" {
 				b.WriteString("\nmigrate-up:\n\t@echo \"Running Alembic migrations\"\n\talembic upgrade head\n")
 			}
 			b.WriteString("\nseed:\n\t@echo \"Running Python Seeder\"\n\tpython scripts/seed.py\n")
@@ -165,12 +183,349 @@ func (g *PythonGenerator) GenerateDevTools(req *GenerateRequest, ctx *Generation
 		addFile(ctx.FileTree, "Makefile", b.String())
 	}
 	if req.Features.Swagger {
-		// FastAPI has built-in swagger. We add openapi.yaml for completeness if requested.
-		addFile(ctx.FileTree, "docs/openapi.yaml", "openapi: 3.0.3\ninfo:\n  title: StackSprint API\n  version: 1.0.0\npaths:\n  /health:\n    get:\n      responses:\n        '200':\n          description: OK\n")
+		// FastAPI serves the spec through its own /docs; Django gets two plain
+		// views and mounts the model routes beside its /api app.
+		docsModule, docsPath := pythonDocsModule, "app/docs.py"
+		if req.Framework == "django" {
+			addOpenAPISpecs(ctx.FileTree, *req, "/api/health", true)
+			docsModule, docsPath = djangoDocsModule, "api/docs.py"
+		} else {
+			addOpenAPISpecs(ctx.FileTree, *req, "/health", true)
+		}
+		if req.Architecture == "microservices" {
+			for _, svc := range req.Services {
+				addFile(ctx.FileTree, path.Join("services", svc.Name, docsPath), docsModule)
+			}
+		} else {
+			addFile(ctx.FileTree, docsPath, docsModule)
+		}
 	}
 	return nil
 }
 
+// pythonDocsModule replaces the schema FastAPI derives from the routes with
+// docs/openapi.yaml, so /docs and /openapi.json show the generated spec.
+const pythonDocsModule = `from functools import lru_cache
+from pathlib import Path
+
+import yaml
+
+SPEC_PATH = Path(__file__).resolve().parent.parent / 'docs' / 'openapi.yaml'
+
+
+@lru_cache
+def openapi_spec() -> dict:
+    return yaml.safe_load(SPEC_PATH.read_text())
+`
+
+// djangoDocsModule serves docs/openapi.yaml at /docs/openapi.yaml and
+// renders it with Swagger UI at /docs.
+const djangoDocsModule = `from pathlib import Path
+
+from django.http import HttpResponse
+
+SPEC_PATH = Path(__file__).resolve().parent.parent / 'docs' / 'openapi.yaml'
+
+PAGE = """` + swaggerUIPage + `"""
+
+
+def docs_page(request):
+    return HttpResponse(PAGE)
+
+
+def openapi_spec(request):
+    return HttpResponse(SPEC_PATH.read_text(), content_type='application/yaml')
+`
+
+// djangoAuthModule authenticates the model views with access tokens and
+// serves /auth/register, /auth/login and /auth/refresh. Accounts are
+// django.contrib.auth users keyed by email, so migrate creates their table.
+const djangoAuthModule = `import os
+from datetime import datetime, timedelta, timezone
+
+import jwt
+from django.contrib.auth.models import User
+from rest_framework import exceptions, serializers
+from rest_framework.authentication import BaseAuthentication, get_authorization_header
+from rest_framework.decorators import api_view, authentication_classes, permission_classes
+from rest_framework.response import Response
+
+ACCESS_TTL = timedelta(minutes=15)
+REFRESH_TTL = timedelta(days=7)
+
+JWT_SECRET = os.getenv('JWT_SECRET', 'default_dev_secret_replace_in_prod')
+
+
+def _sign(user_id: int, typ: str, ttl: timedelta) -> str:
+    now = datetime.now(timezone.utc)
+    claims = {'sub': str(user_id), 'typ': typ, 'iat': now, 'exp': now + ttl}
+    return jwt.encode(claims, JWT_SECRET, algorithm='HS256')
+
+
+def issue_tokens(user_id: int) -> dict:
+    return {
+        'access_token': _sign(user_id, 'access', ACCESS_TTL),
+        'refresh_token': _sign(user_id, 'refresh', REFRESH_TTL),
+        'token_type': 'Bearer',
+        'expires_in': int(ACCESS_TTL.total_seconds()),
+    }
+
+
+def verify_token(token: str, typ: str) -> int | None:
+    """Returns the id of the user a valid token of type typ was issued to, or None."""
+    try:
+        claims = jwt.decode(token, JWT_SECRET, algorithms=['HS256'])
+    except jwt.PyJWTError:
+        return None
+    if claims.get('typ') != typ or not str(claims.get('sub', '')).isdigit():
+        return None
+    return int(claims['sub'])
+
+
+class JWTAuthentication(BaseAuthentication):
+    """Authenticates requests carrying an access token as a Bearer credential."""
+
+    def authenticate(self, request):
+        header = get_authorization_header(request).split()
+        if not header or header[0].lower() != b'bearer':
+            return None
+        user_id = verify_token(header[1].decode(), 'access') if len(header) == 2 else None
+        user = User.objects.filter(pk=user_id, is_active=True).first() if user_id else None
+        if user is None:
+            raise exceptions.AuthenticationFailed('missing or invalid access token')
+        return user, None
+
+    def authenticate_header(self, request):
+        return 'Bearer'
+
+
+class CredentialsSerializer(serializers.Serializer):
+    email = serializers.EmailField(max_length=255)
+    password = serializers.CharField(min_length=8, max_length=128, trim_whitespace=False)
+
+
+@api_view(['POST'])
+@authentication_classes([])
+@permission_classes([])
+def register(request):
+    body = CredentialsSerializer(data=request.data)
+    body.is_valid(raise_exception=True)
+    email = body.validated_data['email'].lower()
+    if User.objects.filter(username=email).exists():
+        return Response({'detail': 'email already registered'}, status=409)
+    user = User.objects.create_user(username=email, email=email, password=body.validated_data['password'])
+    return Response({'id': user.id, 'email': user.email}, status=201)
+
+
+@api_view(['POST'])
+@authentication_classes([])
+@permission_classes([])
+def login(request):
+    # Unknown emails and wrong passwords get the same answer.
+    email = str(request.data.get('email', '')).strip().lower()
+    user = User.objects.filter(username=email, is_active=True).first()
+    if user is None or not user.check_password(str(request.data.get('password', ''))):
+        return Response({'detail': 'invalid email or password'}, status=401)
+    return Response(issue_tokens(user.id))
+
+
+@api_view(['POST'])
+@authentication_classes([])
+@permission_classes([])
+def refresh(request):
+    user_id = verify_token(str(request.data.get('refresh_token', '')), 'refresh')
+    if user_id is None or not User.objects.filter(pk=user_id, is_active=True).exists():
+        return Response({'detail': 'invalid token'}, status=401)
+    return Response(issue_tokens(user_id))
+`
+
+const djangoAuthURLs = `from django.urls import path
+
+from . import auth
+
+urlpatterns = [
+    path('auth/register', auth.register),
+    path('auth/login', auth.login),
+    path('auth/refresh', auth.refresh),
+]
+`
+
+// djangoAuth is the auth module for req: under RBAC, new accounts join the
+// default role's group and the register response names it.
+func djangoAuth(req GenerateRequest) string {
+	if !req.Features.RBAC {
+		return djangoAuthModule
+	}
+	return strings.NewReplacer(
+		"from rest_framework.response import Response\n",
+		"from rest_framework.response import Response\n\nfrom .rbac import assign_default_role, role_of\n",
+		"    return Response({'id': user.id, 'email': user.email}, status=201)\n",
+		"    assign_default_role(user)\n    return Response({'id': user.id, 'email': user.email, 'role': role_of(user)}, status=201)\n",
+	).Replace(djangoAuthModule)
+}
+
+// djangoRBACModule holds the policy table and the DRF permission the model
+// views check it with. A user's role is the Django group they belong to.
+func djangoRBACModule(req GenerateRequest) string {
+	var policy strings.Builder
+	for _, role := range req.RBAC.Roles {
+		quoted := make([]string, 0, len(role.Permissions))
+		for _, p := range role.Permissions {
+			quoted = append(quoted, "'"+p+"'")
+		}
+		policy.WriteString("    '" + role.Name + "': [" + strings.Join(quoted, ", ") + "],\n")
+	}
+	return `from django.contrib.auth.models import Group
+from rest_framework.permissions import BasePermission
+
+DEFAULT_ROLE = '` + req.RBAC.DefaultRole + `'
+
+# Maps each role to the permissions it holds. A permission is
+# '<resource>:<action>', where either half may be '*', or '*' for everything.
+POLICY: dict[str, list[str]] = {
+` + policy.String() + `}
+
+
+def role_of(user) -> str | None:
+    """Returns the name of the first policy group the user belongs to."""
+    return user.groups.filter(name__in=POLICY).values_list('name', flat=True).first()
+
+
+def assign_default_role(user) -> None:
+    group, _ = Group.objects.get_or_create(name=DEFAULT_ROLE)
+    user.groups.add(group)
+
+
+def can(role: str | None, permission: str) -> bool:
+    """Reports whether role holds permission."""
+    resource, _, action = permission.partition(':')
+    return any(p in ('*', permission, f'{resource}:*', f'*:{action}') for p in POLICY.get(role, []))
+
+
+def requires(permissions: dict[str, str]):
+    """Returns a permission class admitting authenticated users whose role
+    holds the permission permissions maps the request method to."""
+
+    class RolePermission(BasePermission):
+        message = 'forbidden'
+
+        def has_permission(self, request, view):
+            user = request.user
+            return bool(user and user.is_authenticated) and can(role_of(user), permissions.get(request.method, ''))
+    return RolePermission
+`
+}
+
+// djangoAppConfig is the api app's config; with observability on its ready
+// hook starts tracing before Django builds the middleware chain, which the
+// instrumentor adds its own middleware to.
+func djangoAppConfig(req GenerateRequest) string {
+	config := "from django.apps import AppConfig\n\nclass ApiConfig(AppConfig):\n    default_auto_field = 'django.db.models.BigAutoField'\n    name = 'api'\n"
+	if req.Features.Observability {
+		config += "\n    def ready(self):\n        from .telemetry import setup\n        setup()\n"
+	}
+	return config
+}
+
+// djangoTelemetryModule exports service's request and query spans and keeps
+// the Prometheus series the other stacks serve on /metrics.
+func djangoTelemetryModule(req GenerateRequest, service string) string {
+	instrumentor := "from opentelemetry.instrumentation.sqlite3 import SQLite3Instrumentor"
+	switch req.Database {
+	case "postgresql":
+		instrumentor = "from opentelemetry.instrumentation.psycopg import PsycopgInstrumentor"
+	case "mysql":
+		instrumentor = "from opentelemetry.instrumentation.mysqlclient import MySQLClientInstrumentor"
+	}
+	name := instrumentor[strings.LastIndex(instrumentor, " ")+1:]
+	return strings.NewReplacer(
+		"{{instrumentor_import}}", instrumentor,
+		"{{instrumentor}}", name,
+		"{{service}}", service,
+	).Replace(djangoTelemetry)
+}
+
+const djangoTelemetry = `import os
+import time
+
+from django.http import HttpResponse
+from opentelemetry import trace
+from opentelemetry.exporter.otlp.proto.grpc.trace_exporter import OTLPSpanExporter
+from opentelemetry.instrumentation.django import DjangoInstrumentor
+{{instrumentor_import}}
+from opentelemetry.sdk.resources import Resource
+from opentelemetry.sdk.trace import TracerProvider
+from opentelemetry.sdk.trace.export import BatchSpanProcessor
+from prometheus_client import CONTENT_TYPE_LATEST, Counter, Histogram, generate_latest
+
+REQUESTS = Counter('http_requests_total', 'HTTP requests by method, route and status.', ['method', 'route', 'status'])
+DURATION = Histogram('http_request_duration_seconds', 'HTTP request latency by method, route and status.', ['method', 'route', 'status'])
+
+
+def setup():
+    """Exports spans for every request and query to OTEL_EXPORTER_OTLP_ENDPOINT."""
+    provider = TracerProvider(resource=Resource.create({'service.name': os.getenv('OTEL_SERVICE_NAME', '{{service}}')}))
+    provider.add_span_processor(BatchSpanProcessor(OTLPSpanExporter()))
+    trace.set_tracer_provider(provider)
+    DjangoInstrumentor().instrument(excluded_urls='health,metrics')
+    {{instrumentor}}().instrument()
+
+
+class MetricsMiddleware:
+    """Tags the request span with the X-Request-ID RequestIDMiddleware set
+    and records the request in the Prometheus series."""
+
+    def __init__(self, get_response):
+        self.get_response = get_response
+
+    def __call__(self, request):
+        trace.get_current_span().set_attribute('http.request_id', getattr(request, 'request_id', ''))
+        start = time.perf_counter()
+        response = self.get_response(request)
+        match = request.resolver_match
+        labels = (request.method, '/' + match.route if match else 'unmatched', str(response.status_code))
+        REQUESTS.labels(*labels).inc()
+        DURATION.labels(*labels).observe(time.perf_counter() - start)
+        return response
+
+
+def metrics(request):
+    return HttpResponse(generate_latest(), content_type=CONTENT_TYPE_LATEST)
+`
+
+// djangoRootURLs mounts the api app, the auth and model routes at the paths
+// the other stacks serve them on, the docs views with Swagger on, /metrics
+// with observability on and /health/redis with Redis on.
+func djangoRootURLs(req GenerateRequest) string {
+	models := ""
+	if req.Features.JWTAuth {
+		models += "    path('', include('api.auth_urls')),\n"
+	}
+	if djangoServesModels(req) {
+		models += "    path('', include('api.model_urls')),\n"
+	}
+	imports := ""
+	if req.Features.Swagger {
+		imports += "from api.docs import docs_page, openapi_spec\n"
+		models += "    path('docs', docs_page),\n    path('docs/openapi.yaml', openapi_spec),\n"
+	}
+	if req.Features.Observability {
+		imports += "from api.telemetry import metrics\n"
+		models += "    path('metrics', metrics),\n"
+	}
+	if req.Infra.Redis {
+		imports += "from api.cache.views import redis_health\n"
+		models += "    path('health/redis', redis_health),\n"
+	}
+	if imports != "" {
+		imports += "\n"
+	}
+	if models == "" {
+		return "from django.urls import include, path\n\nurlpatterns = [path('api/', include('api.urls')),]\n"
+	}
+	return "from django.urls import include, path\n\n" + imports + "urlpatterns = [\n    path('api/', include('api.urls')),\n" + models + "]\n"
+}
+
 // GetInitCommand returns the bash init command for Python projects.
 func (g *PythonGenerator) GetInitCommand(_ *GenerateRequest) string {
 	return "python -m venv venv\nsource venv/bin/activate\npip install -r requirements.txt\n"
@@ -195,7 +550,10 @@ func (g *PythonGenerator) GetConfigWarnings(req *GenerateRequest) []Warning {
 // -------------------------------------------------------------------------
 
 func (g *PythonGenerator) generateMonolithArch(req *GenerateRequest, ctx *GenerationContext, root string) error {
-	specs := pythonMonolithTemplateSpecs(*req)
+	manifest, err := ctx.Registry.Manifest(req.Language, req.Architecture)
+	if err != nil {
+		return err
+	}
 	data := map[string]any{
 		"Framework":    req.Framework,
 		"Architecture": req.Architecture,
@@ -205,6 +563,9 @@ func (g *PythonGenerator) generateMonolithArch(req *GenerateRequest, ctx *Genera
 		"UseORM":       req.UseORM,
 		"DBKind":       req.Database,
 		"Service":      "app",
+		"Auth":         newAuthData(*req),
+		"Messaging":    newMessagingData(*req, ""),
+		"Cache":        newCacheData(*req, ""),
 	}
 
 	if req.Framework == "django" {
@@ -213,43 +574,47 @@ func (g *PythonGenerator) generateMonolithArch(req *GenerateRequest, ctx *Genera
 			return err
 		}
 		addDjangoFiles(ctx.FileTree, *req, main)
+		if req.Infra.Redis {
+			if err := addDjangoCache(ctx, data, root); err != nil {
+				return err
+			}
+		}
+		if usesMessaging(*req) {
+			if err := addDjangoMessaging(ctx, *req, data, root); err != nil {
+				return err
+			}
+		}
 	} else {
-		if err := g.renderSpecs(ctx, specs, data, root); err != nil {
+		if err := g.renderSpecs(ctx, manifest.fileSpecs(*req), data, root); err != nil {
 			return err
 		}
-		if isEnabled(req.FileToggles.ExampleCRUD) {
-			var imports, routes strings.Builder
-			for _, model := range resolvedModels(req.Custom.Models) {
-				nameLow := toSnake(model.Name)
-				if req.Architecture == "clean" {
-					imports.WriteString(fmt.Sprintf("from app.delivery.http.%s_controller import router as %s_router\n", nameLow, nameLow))
-					routes.WriteString(fmt.Sprintf("app.include_router(%s_router)\n", nameLow))
-				} else if req.Architecture == "hexagonal" {
-					imports.WriteString(fmt.Sprintf("from app.adapters.primary.http.%s_controller import %s_router\n", nameLow, nameLow))
-					routes.WriteString(fmt.Sprintf("app.include_router(%s_router)\n", nameLow))
-				} else {
-					imports.WriteString(fmt.Sprintf("from app.routes.%ss import router as %ss_router\n", nameLow, nameLow))
-					routes.WriteString(fmt.Sprintf("app.include_router(%ss_router)\n", nameLow))
-				}
-			}
+		if isEnabled(req.FileToggles.ExampleCRUD) || req.Features.Swagger || req.Features.JWTAuth || req.Features.Observability || usesMessaging(*req) || req.Infra.Redis {
+			imports, routes, lifespan := pythonEntrypointRoutes(req)
 
-			if main, ok := ctx.FileTree.Files["app/main.py"]; ok {
+			mainPath, found := manifest.routesTarget(ctx, *req, root)
+			if main, ok := ctx.FileTree.Files[mainPath]; found && ok {
 				var err error
-				main, err = InjectByMarker(main, "imports", imports.String())
+				main, err = InjectByMarker(main, "imports", imports)
 				if err != nil {
-					ctx.Warnings = append(ctx.Warnings, Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic imports", Reason: err.Error()})
+					ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic imports", Reason: err.Error(), Path: mainPath})
 				}
-				main, err = InjectByMarker(main, "routes", routes.String())
+				main, err = InjectByMarker(main, "routes", routes)
 				if err != nil {
-					ctx.Warnings = append(ctx.Warnings, Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic routes", Reason: err.Error()})
+					ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic routes", Reason: err.Error(), Path: mainPath})
+				}
+				for _, marker := range []string{"startup", "shutdown"} {
+					main, err = InjectByMarker(main, marker, lifespan[marker])
+					if err != nil {
+						ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject lifespan hooks", Reason: err.Error(), Path: mainPath})
+					}
 				}
-				ctx.FileTree.Files["app/main.py"] = main
+				ctx.FileTree.Files[mainPath] = main
 			}
 		}
 	}
 
 	if req.Framework != "django" {
-		addFile(ctx.FileTree, "requirements.txt", pythonRequirements(req.Framework, req.Database, req.UseORM))
+		addFile(ctx.FileTree, "requirements.txt", pythonRequirements(req.Framework, req.Database, req.UseORM)+pythonAuthRequirements(req)+pythonDocsRequirements(req)+pythonObservabilityRequirements(req)+pythonCacheRequirements(req)+pythonMessagingRequirements(req))
 	}
 	if isEnabled(req.FileToggles.Config) && req.Framework != "django" {
 		addFile(ctx.FileTree, "app/config/settings.py", "from pydantic_settings import BaseSettings\n\nclass Settings(BaseSettings):\n    port: int = 8080\n    database_url: str = \"\"\n    jwt_secret: str = \"default_dev_secret_replace_in_prod\"\n\n    class Config:\n        env_file = \".env\"\n\nsettings = Settings()\n")
@@ -270,7 +635,10 @@ func (g *PythonGenerator) generateMonolithArch(req *GenerateRequest, ctx *Genera
 }
 
 func (g *PythonGenerator) generateServiceArch(req *GenerateRequest, ctx *GenerationContext, svcRoot string, svc ServiceConfig) error {
-	specs := pythonMicroserviceTemplateSpecs(*req)
+	manifest, err := ctx.Registry.Manifest(req.Language, req.Architecture)
+	if err != nil {
+		return err
+	}
 	data := map[string]any{
 		"Framework":    req.Framework,
 		"Architecture": req.Architecture,
@@ -280,6 +648,9 @@ func (g *PythonGenerator) generateServiceArch(req *GenerateRequest, ctx *Generat
 		"UseORM":       req.UseORM,
 		"DBKind":       req.Database,
 		"Service":      svc.Name,
+		"Auth":         newAuthData(*req),
+		"Messaging":    newMessagingData(*req, svc.Name),
+		"Cache":        newCacheData(*req, svc.Name),
 	}
 
 	if req.Framework == "django" {
@@ -288,40 +659,44 @@ func (g *PythonGenerator) generateServiceArch(req *GenerateRequest, ctx *Generat
 			return err
 		}
 		addDjangoFilesAtRoot(ctx.FileTree, *req, main, svcRoot)
+		if req.Infra.Redis {
+			if err := addDjangoCache(ctx, data, svcRoot); err != nil {
+				return err
+			}
+		}
+		if usesMessaging(*req) {
+			if err := addDjangoMessaging(ctx, *req, data, svcRoot); err != nil {
+				return err
+			}
+		}
 	} else {
-		if err := g.renderSpecs(ctx, specs, data, svcRoot); err != nil {
+		if err := g.renderSpecs(ctx, manifest.fileSpecs(*req), data, svcRoot); err != nil {
 			return err
 		}
-		if isEnabled(req.FileToggles.ExampleCRUD) {
-			var imports, routes strings.Builder
-			for _, model := range resolvedModels(req.Custom.Models) {
-				nameLow := toSnake(model.Name)
-				if req.Architecture == "clean" {
-					imports.WriteString(fmt.Sprintf("from app.delivery.http.%s_controller import router as %s_router\n", nameLow, nameLow))
-					routes.WriteString(fmt.Sprintf("app.include_router(%s_router)\n", nameLow))
-				} else if req.Architecture == "hexagonal" {
-					imports.WriteString(fmt.Sprintf("from app.adapters.primary.http.%s_controller import %s_router\n", nameLow, nameLow))
-					routes.WriteString(fmt.Sprintf("app.include_router(%s_router)\n", nameLow))
-				} else {
-					imports.WriteString(fmt.Sprintf("from app.routes.%ss import router as %ss_router\n", nameLow, nameLow))
-					routes.WriteString(fmt.Sprintf("app.include_router(%ss_router)\n", nameLow))
-				}
-			}
+		if isEnabled(req.FileToggles.ExampleCRUD) || req.Features.Swagger || req.Features.JWTAuth || req.Features.Observability || usesMessaging(*req) || req.Infra.Redis {
+			imports, routes, lifespan := pythonEntrypointRoutes(req)
 
-			if main, ok := ctx.FileTree.Files[path.Join(svcRoot, "app/main.py")]; ok {
+			mainPath, found := manifest.routesTarget(ctx, *req, svcRoot)
+			if main, ok := ctx.FileTree.Files[mainPath]; found && ok {
 				var err error
-				main, err = InjectByMarker(main, "imports", imports.String())
+				main, err = InjectByMarker(main, "imports", imports)
 				if err != nil {
-					ctx.Warnings = append(ctx.Warnings, Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic imports for service " + svc.Name, Reason: err.Error()})
+					ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic imports for service " + svc.Name, Reason: err.Error(), Path: mainPath})
 				}
-				main, err = InjectByMarker(main, "routes", routes.String())
+				main, err = InjectByMarker(main, "routes", routes)
 				if err != nil {
-					ctx.Warnings = append(ctx.Warnings, Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic routes for service " + svc.Name, Reason: err.Error()})
+					ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject dynamic routes for service " + svc.Name, Reason: err.Error(), Path: mainPath})
+				}
+				for _, marker := range []string{"startup", "shutdown"} {
+					main, err = InjectByMarker(main, marker, lifespan[marker])
+					if err != nil {
+						ctx.AddWarning(Warning{Code: "INJECTION_MARKER_MISSING", Severity: "error", Message: "Failed to inject lifespan hooks for service " + svc.Name, Reason: err.Error(), Path: mainPath})
+					}
 				}
-				ctx.FileTree.Files[path.Join(svcRoot, "app/main.py")] = main
+				ctx.FileTree.Files[mainPath] = main
 			}
 		}
-		addFile(ctx.FileTree, path.Join(svcRoot, "requirements.txt"), pythonRequirements(req.Framework, req.Database, req.UseORM))
+		addFile(ctx.FileTree, path.Join(svcRoot, "requirements.txt"), pythonRequirements(req.Framework, req.Database, req.UseORM)+pythonAuthRequirements(req)+pythonDocsRequirements(req)+pythonObservabilityRequirements(req)+pythonCacheRequirements(req)+pythonMessagingRequirements(req))
 	}
 
 	g.addPythonAutopilot(ctx.FileTree, req, svcRoot)
@@ -329,6 +704,147 @@ func (g *PythonGenerator) generateServiceArch(req *GenerateRequest, ctx *Generat
 	return nil
 }
 
+// pythonEntrypointRoutes returns the imports and statements the FastAPI
+// entrypoint needs to serve the generated spec and every model's router, and
+// the statements its lifespan runs at the startup and shutdown markers.
+func pythonEntrypointRoutes(req *GenerateRequest) (string, string, map[string]string) {
+	var imports, routes strings.Builder
+	lifespan := map[string]string{}
+	if usesMessaging(*req) {
+		imports.WriteString("from app.messaging.events import log_event\n")
+	}
+	if req.Infra.Kafka {
+		imports.WriteString("from app.messaging.kafka import start_kafka\n")
+		lifespan["startup"] += "    stop_kafka = await start_kafka(log_event)\n"
+		lifespan["shutdown"] += "    await stop_kafka()\n"
+	}
+	if req.Infra.NATS {
+		imports.WriteString("from app.messaging.nats_client import start_nats\n")
+		lifespan["startup"] += "    stop_nats = await start_nats(log_event)\n"
+		lifespan["shutdown"] += "    await stop_nats()\n"
+	}
+	if req.Infra.RabbitMQ {
+		imports.WriteString("from app.messaging.rabbitmq import start_rabbitmq\n")
+		lifespan["startup"] += "    stop_rabbitmq = await start_rabbitmq(log_event)\n"
+		lifespan["shutdown"] += "    await stop_rabbitmq()\n"
+	}
+	if req.Features.Observability {
+		imports.WriteString("from app.telemetry import setup as setup_telemetry\n")
+		routes.WriteString("setup_telemetry(app)\n")
+	}
+	if req.Infra.Redis {
+		imports.WriteString("from fastapi.responses import JSONResponse\nfrom app.cache.redis_cache import client as redis_client, health as redis_health\n")
+		routes.WriteString("\n\n@app.get('/health/redis')\ndef health_redis():\n    code, body = redis_health()\n    return JSONResponse(body, status_code=code)\n\n\n")
+		lifespan["shutdown"] += "    redis_client.close()\n"
+	}
+	if req.Features.Swagger {
+		imports.WriteString("from app.docs import openapi_spec\n")
+		routes.WriteString("app.openapi = openapi_spec\n")
+	}
+	models := resolvedModels(req.Custom.Models)
+	if !isEnabled(req.FileToggles.ExampleCRUD) {
+		models = nil
+	}
+	guard := ""
+	if req.Features.JWTAuth {
+		imports.WriteString("from app.auth.routes import router as auth_router\n")
+		routes.WriteString("app.include_router(auth_router)\n")
+		if len(models) > 0 {
+			imports.WriteString("from fastapi import Depends\nfrom app.auth.dependencies import require_user\n")
+			guard = ", dependencies=[Depends(require_user)]"
+		}
+	}
+	for _, model := range models {
+		nameLow := toSnake(model.Name)
+		if req.Architecture == "clean" {
+			imports.WriteString(fmt.Sprintf("from app.delivery.http.%s_controller import router as %s_router\n", nameLow, nameLow))
+			routes.WriteString(fmt.Sprintf("app.include_router(%s_router%s)\n", nameLow, guard))
+		} else if req.Architecture == "hexagonal" {
+			imports.WriteString(fmt.Sprintf("from app.adapters.primary.http.%s_controller import %s_router\n", nameLow, nameLow))
+			routes.WriteString(fmt.Sprintf("app.include_router(%s_router%s)\n", nameLow, guard))
+		} else {
+			imports.WriteString(fmt.Sprintf("from app.routes.%ss import router as %ss_router\n", nameLow, nameLow))
+			routes.WriteString(fmt.Sprintf("app.include_router(%ss_router%s)\n", nameLow, guard))
+		}
+	}
+	return imports.String(), routes.String(), lifespan
+}
+
+// pythonAuthRequirements adds the token and password hashing libraries the
+// auth module uses; Django reuses its own password hashers.
+func pythonAuthRequirements(req *GenerateRequest) string {
+	switch {
+	case !req.Features.JWTAuth:
+		return ""
+	case req.Framework == "django":
+		return "PyJWT==2.10.1\n"
+	default:
+		return "PyJWT==2.10.1\nbcrypt==4.2.1\n"
+	}
+}
+
+// pythonDocsRequirements adds the YAML parser app/docs.py loads the spec with.
+func pythonDocsRequirements(req *GenerateRequest) string {
+	if req.Features.Swagger {
+		return "PyYAML==6.0.2\n"
+	}
+	return ""
+}
+
+// pythonObservabilityRequirements adds the OpenTelemetry SDK, the framework
+// and database driver instrumentations and the Prometheus client.
+func pythonObservabilityRequirements(req *GenerateRequest) string {
+	if !req.Features.Observability {
+		return ""
+	}
+	b := "opentelemetry-api==1.29.0\nopentelemetry-sdk==1.29.0\nopentelemetry-exporter-otlp-proto-grpc==1.29.0\nprometheus-client==0.21.1\n"
+	if req.Framework == "django" {
+		b += "opentelemetry-instrumentation-django==0.50b0\n"
+		switch req.Database {
+		case "postgresql":
+			return b + "opentelemetry-instrumentation-psycopg==0.50b0\n"
+		case "mysql":
+			return b + "opentelemetry-instrumentation-mysqlclient==0.50b0\n"
+		}
+		return b + "opentelemetry-instrumentation-sqlite3==0.50b0\n"
+	}
+	b += "opentelemetry-instrumentation-fastapi==0.50b0\n"
+	switch req.Database {
+	case "postgresql":
+		return b + "opentelemetry-instrumentation-psycopg==0.50b0\n"
+	case "mysql":
+		return b + "opentelemetry-instrumentation-pymysql==0.50b0\n"
+	case "sqlite":
+		return b + "opentelemetry-instrumentation-sqlite3==0.50b0\n"
+	}
+	return b
+}
+
+// pythonCacheRequirements adds the Redis client; the repositories are
+// synchronous, and so is the cache in front of them.
+func pythonCacheRequirements(req *GenerateRequest) string {
+	if !req.Infra.Redis {
+		return ""
+	}
+	return "redis==5.2.1\n"
+}
+
+// pythonMessagingRequirements adds the asyncio Kafka, NATS and RabbitMQ
+// clients the messaging package is built on.
+func pythonMessagingRequirements(req *GenerateRequest) string {
+	b := ""
+	if req.Infra.Kafka {
+		b += "aiokafka==0.12.0\n"
+	}
+	if req.Infra.NATS {
+		b += "nats-py==2.9.0\n"
+	}
+	if req.Infra.RabbitMQ {
+		b += "aio-pika==9.5.4\n"
+	}
+	return b
+}
+
 func (g *PythonGenerator) renderSpecs(ctx *GenerationContext, specs []templateSpec, data map[string]any, root string) error {
 	for _, spec := range specs {
 		body, err := ctx.Registry.Render(spec.Template, data)
@@ -396,6 +912,18 @@ func (g *PythonGenerator) addPythonDBBoilerplate(tree *FileTree, req *GenerateRe
 		return
 	}
 
+	if req.Database == "sqlite" {
+		if req.UseORM {
+			addFile(tree, prefix+"app/repository/sqlalchemy_session.py", pythonSQLiteSetup(req.Custom.Models, "from sqlalchemy import create_engine, event\nfrom sqlalchemy.orm import sessionmaker\n")+"\nengine = create_engine('sqlite:///' + DATABASE_PATH)\nSessionLocal = sessionmaker(bind=engine, autoflush=False, autocommit=False)\n\n\n@event.listens_for(engine, 'connect')\ndef _enable_foreign_keys(conn, _):\n    conn.execute('PRAGMA foreign_keys = ON')\n")
+			addFile(tree, prefix+"app/repository/models.py", renderSQLAlchemyModels(req.Custom.Models))
+			addFile(tree, prefix+"scripts/seed.py", renderPythonSeedScript(req.Custom.Models, true))
+			return
+		}
+		addFile(tree, prefix+"app/repository/sql_driver.py", pythonSQLiteSetup(req.Custom.Models, "")+"\n\ndef connect():\n    conn = sqlite3.connect(DATABASE_PATH)\n    conn.execute('PRAGMA foreign_keys = ON')\n    return conn\n")
+		addFile(tree, prefix+"scripts/seed.py", renderPythonSeedScript(req.Custom.Models, false))
+		return
+	}
+
 	if req.UseORM {
 		driver := "postgresql+psycopg"
 		if req.Database == "mysql" {
@@ -419,42 +947,20 @@ func (g *PythonGenerator) addPythonDBBoilerplate(tree *FileTree, req *GenerateRe
 	addFile(tree, prefix+"scripts/seed.py", renderPythonSeedScript(req.Custom.Models, false))
 }
 
-func pythonMonolithTemplateSpecs(req GenerateRequest) []templateSpec {
-	withCRUD := isEnabled(req.FileToggles.ExampleCRUD)
-	switch req.Architecture {
-	case "clean":
-		base := []templateSpec{
-			{Template: "python/clean/main.tmpl", Output: "app/main.py"},
-		}
-		if withCRUD {
-			return base
-		}
-		return append(base, []templateSpec{
-			{Template: "python/clean/app/domain/ping.tmpl", Output: "app/domain/ping.py"},
-			{Template: "python/clean/app/usecases/ping_usecase.tmpl", Output: "app/usecases/ping_usecase.py"},
-			{Template: "python/clean/app/delivery/http/ping_controller.tmpl", Output: "app/delivery/http/ping_controller.py"},
-			{Template: "python/clean/app/repository/ping_repository.tmpl", Output: "app/repository/ping_repository.py"},
-		}...)
-	case "hexagonal":
-		base := []templateSpec{
-			{Template: "python/hexagonal/main.tmpl", Output: "app/main.py"},
-		}
-		if withCRUD {
-			return base
-		}
-		return append(base, []templateSpec{
-			{Template: "python/hexagonal/app/core/ports/ping_port.tmpl", Output: "app/core/ports/ping_port.py"},
-			{Template: "python/hexagonal/app/core/services/ping_service.tmpl", Output: "app/core/services/ping_service.py"},
-			{Template: "python/hexagonal/app/adapters/primary/http/ping_controller.tmpl", Output: "app/adapters/primary/http/ping_controller.py"},
-			{Template: "python/hexagonal/app/adapters/secondary/database/ping_adapter.tmpl", Output: "app/adapters/secondary/database/ping_adapter.py"},
-		}...)
-	default:
-		return []templateSpec{{Template: fmt.Sprintf("python/%s/main.tmpl", archTemplateName(req.Architecture)), Output: "app/main.py"}}
+// pythonSQLiteSetup resolves the SQLite file DATABASE_URL names and creates
+// it with its tables on import. There is no server to run db/init, and every
+// statement in the schema is IF NOT EXISTS. The SQLAlchemy session and the
+// sqlite3 driver module both start with it, after their own imports.
+func pythonSQLiteSetup(models []DataModel, imports string) string {
+	if imports != "" {
+		imports = "\n" + imports
 	}
-}
-
-func pythonMicroserviceTemplateSpecs(_ GenerateRequest) []templateSpec {
-	return []templateSpec{{Template: "python/microservice/main.tmpl", Output: "app/main.py"}}
+	return "import os\nimport sqlite3\nfrom contextlib import closing\nfrom pathlib import Path\n" + imports + "\n" +
+		"DATABASE_PATH = os.getenv('DATABASE_URL', '" + sqliteURL + "').removeprefix('file:')\n\n" +
+		"SCHEMA = \"\"\"\n" + sampleMigration("sqlite", models) + "\"\"\"\n\n" +
+		"Path(DATABASE_PATH).parent.mkdir(parents=True, exist_ok=True)\n" +
+		"with closing(sqlite3.connect(DATABASE_PATH)) as _conn:\n" +
+		"    _conn.executescript(SCHEMA)\n"
 }
 
 func pythonRequirements(framework string, db string, useORM bool) string {
@@ -469,6 +975,13 @@ func pythonRequirements(framework string, db string, useORM bool) string {
 		return base
 	}
 	base := "fastapi==0.116.0\nuvicorn==0.34.0\npydantic-settings==2.6.1\n"
+	if db == "sqlite" {
+		// The sqlite3 driver ships with Python.
+		if useORM {
+			return base + "SQLAlchemy==2.0.36\n"
+		}
+		return base
+	}
 	if db == "postgresql" {
 		if useORM {
 			return base + "SQLAlchemy==2.0.36\npsycopg[binary]==3.2.3\n"
@@ -509,7 +1022,7 @@ func renderPythonSeedScript(models []DataModel, useORM bool) string {
 		}
 		b.WriteString(strings.Join(mNames, ", "))
 		b.WriteString("\n\ndef seed():\n    print('Seeding database...')\n    with SessionLocal() as session:\n")
-		for _, m := range resolvedModels(models) {
+		for _, m := range parentsFirst(resolvedModels(models)) {
 			sample := buildPythonSampleDict(m)
 			b.WriteString(fmt.Sprintf("        obj_%s = %s(**%s)\n        session.add(obj_%s)\n", toSnake(m.Name), m.Name, sample, toSnake(m.Name)))
 		}
@@ -525,65 +1038,360 @@ func renderPythonSeedScript(models []DataModel, useORM bool) string {
 	return b.String()
 }
 
+// pythonUsecases names the clean-architecture use case behind each CRUD action.
+var pythonUsecases = []struct {
+	action, prefix, suffix, params, call string
+}{
+	{ActionList, "list_", "s", "limit: int, offset: int", "find_all(limit, offset)"},
+	{ActionGet, "get_", "", "id: int", "find_by_id(id)"},
+	{ActionCreate, "create_", "", "data: dict", "create(data)"},
+	{ActionUpdate, "update_", "", "id: int, data: dict", "update(id, data)"},
+	{ActionDelete, "delete_", "", "id: int", "delete(id)"},
+}
+
+// pythonRouteArgs lists a FastAPI handler's path parameters; the item id is
+// an int, any parent parameters stay strings.
+func pythonRouteArgs(r modelRoute) []string {
+	var args []string
+	for _, p := range r.Params {
+		if p == r.Param {
+			args = append(args, p+": int")
+		} else {
+			args = append(args, p+": str")
+		}
+	}
+	return args
+}
+
+// pythonRouteDecorator registers a handler on router for its method and path,
+// behind authorize(permission) when permission is set.
+func pythonRouteDecorator(router string, r modelRoute, permission string) string {
+	opts := ""
+	switch r.Action {
+	case ActionCreate:
+		opts = ", status_code=201"
+	case ActionDelete:
+		opts = ", status_code=204"
+	}
+	if permission != "" {
+		opts += ", dependencies=[Depends(authorize('" + permission + "'))]"
+	}
+	return "@" + router + "." + strings.ToLower(r.Method) + "('" + r.Path + "'" + opts + ")\n"
+}
+
+// pythonHandler renders one FastAPI route of model name on router. calls
+// names the function each CRUD action calls; list routes need PageParams and
+// page_params in scope. Updates only pass the fields the body set, so PATCH
+// and PUT share a handler. A non-empty permission is checked by RBAC.
+func pythonHandler(router, name string, r modelRoute, calls map[string]string, permission string) string {
+	args := pythonRouteArgs(r)
+	switch r.Action {
+	case ActionList:
+		args = append(args, "page: PageParams = Depends(page_params)")
+	case ActionCreate, ActionUpdate:
+		args = append(args, "data: "+name)
+	}
+	notFound := "        raise HTTPException(status_code=404, detail='not found')\n"
+	var b strings.Builder
+	b.WriteString("\n" + pythonRouteDecorator(router, r, permission) +
+		"def " + pythonHandlerName(r.OperationID) + "(" + strings.Join(args, ", ") + "):\n")
+	switch r.Action {
+	case ActionList:
+		b.WriteString("    return {\"limit\": page.limit, \"offset\": page.offset, \"data\": " + calls[ActionList] + "(page.limit, page.offset)}\n")
+	case ActionGet, ActionUpdate:
+		call := calls[ActionGet] + "(" + r.Param + ")"
+		if r.Action == ActionUpdate {
+			call = calls[ActionUpdate] + "(" + r.Param + ", data.model_dump(exclude_unset=True))"
+		}
+		b.WriteString("    row = " + call + "\n    if row is None:\n" + notFound + "    return row\n")
+	case ActionCreate:
+		b.WriteString("    return " + calls[ActionCreate] + "(data.model_dump())\n")
+	case ActionDelete:
+		b.WriteString("    if not " + calls[ActionDelete] + "(" + r.Param + "):\n" + notFound)
+	default:
+		b.WriteString("    raise HTTPException(status_code=501, detail='" + r.OperationID + " is not implemented')\n")
+	}
+	return b.String()
+}
+
+// renderPythonRepository renders the class a FastAPI app persists a model
+// through: SQLAlchemy with the ORM, psycopg or PyMySQL on a SQL database,
+// otherwise a module-level list. Rows go in and come out as dicts; find_by_id
+// and update return None and delete False when the id does not exist. base is
+// the class's parent list, such as "(PostRepositoryPort)", or "".
+func renderPythonRepository(req *GenerateRequest, model DataModel, class, header, base string) string {
+	bind := formatBind
+	if req.Database == "sqlite" {
+		bind = questionBind
+	}
+	st := newCRUDStatements(model, req.Database, bind)
+	quoted := make([]string, 0, len(st.Columns))
+	for _, c := range st.Columns {
+		quoted = append(quoted, strconv.Quote(c))
+	}
+	columns := "COLUMNS = (" + strings.Join(quoted, ", ")
+	if len(quoted) == 1 {
+		columns += ","
+	}
+	columns += ")\n"
+
+	var b strings.Builder
+	switch {
+	case isSQLDB(req.Database) && req.UseORM:
+		row := model.Name + "Row"
+		b.WriteString("from sqlalchemy import select\n\n" + header +
+			"from app.repository.models import " + model.Name + " as " + row + "\n" +
+			"from app.repository.sqlalchemy_session import SessionLocal\n\n" + columns + "\n\n" +
+			"def _to_dict(row: " + row + ") -> dict:\n" +
+			"    return {\"id\": row.id, **{c: getattr(row, c) for c in COLUMNS}}\n\n\n" +
+			"class " + class + base + ":\n" +
+			"    def find_all(self, limit: int, offset: int) -> list[dict]:\n" +
+			"        with SessionLocal() as session:\n" +
+			"            rows = session.scalars(select(" + row + ").order_by(" + row + ".id).limit(limit).offset(offset))\n" +
+			"            return [_to_dict(row) for row in rows]\n\n" +
+			"    def find_by_id(self, id: int) -> dict | None:\n" +
+			"        with SessionLocal() as session:\n" +
+			"            row = session.get(" + row + ", id)\n" +
+			"            return _to_dict(row) if row else None\n\n" +
+			"    def create(self, data: dict) -> dict:\n" +
+			"        with SessionLocal() as session:\n" +
+			"            # Unset values are left out so column defaults apply.\n" +
+			"            row = " + row + "(**{c: data[c] for c in COLUMNS if data.get(c) is not None})\n" +
+			"            session.add(row)\n" +
+			"            session.commit()\n" +
+			"            session.refresh(row)\n" +
+			"            return _to_dict(row)\n\n" +
+			"    def update(self, id: int, data: dict) -> dict | None:\n" +
+			"        with SessionLocal() as session:\n" +
+			"            row = session.get(" + row + ", id)\n" +
+			"            if row is None:\n" +
+			"                return None\n" +
+			"            for c in COLUMNS:\n" +
+			"                if c in data:\n" +
+			"                    setattr(row, c, data[c])\n" +
+			"            session.commit()\n" +
+			"            session.refresh(row)\n" +
+			"            return _to_dict(row)\n\n" +
+			"    def delete(self, id: int) -> bool:\n" +
+			"        with SessionLocal() as session:\n" +
+			"            row = session.get(" + row + ", id)\n" +
+			"            if row is None:\n" +
+			"                return False\n" +
+			"            session.delete(row)\n" +
+			"            session.commit()\n" +
+			"            return True\n")
+	case isSQLDB(req.Database):
+		newID := "rows[0][0]"
+		insert := "        rows, _, _ = _execute(INSERT_SQL, tuple(data.get(c) for c in COLUMNS))\n"
+		if !st.Returning {
+			newID = "new_id"
+			insert = "        _, _, new_id = _execute(INSERT_SQL, tuple(data.get(c) for c in COLUMNS))\n"
+		}
+		// sqlite3 cursors are not context managers; the connection runs the
+		// statement itself.
+		run := "        with conn.cursor() as cur:\n" +
+			"            cur.execute(sql, params)\n" +
+			"            rows = cur.fetchall() if cur.description else []\n" +
+			"            result = rows, cur.rowcount, getattr(cur, \"lastrowid\", None)\n"
+		if req.Database == "sqlite" {
+			run = "        cur = conn.execute(sql, params)\n" +
+				"        result = cur.fetchall(), cur.rowcount, cur.lastrowid\n"
+		}
+		b.WriteString(header + "from app.repository.sql_driver import connect\n\n" + columns +
+			"LIST_SQL = " + strconv.Quote(st.List) + "\n" +
+			"GET_SQL = " + strconv.Quote(st.Get) + "\n" +
+			"INSERT_SQL = " + strconv.Quote(st.Insert) + "\n" +
+			"UPDATE_SQL = " + strconv.Quote(st.Update) + "\n" +
+			"DELETE_SQL = " + strconv.Quote(st.Delete) + "\n\n\n" +
+			"def _execute(sql: str, params: tuple) -> tuple[list, int, int | None]:\n" +
+			"    \"\"\"Runs one statement on its own connection and commits it.\"\"\"\n" +
+			"    conn = connect()\n" +
+			"    try:\n" +
+			run +
+			"        conn.commit()\n" +
+			"        return result\n" +
+			"    finally:\n" +
+			"        conn.close()\n\n\n" +
+			"def _to_dict(row) -> dict:\n" +
+			"    return dict(zip((\"id\", *COLUMNS), row))\n\n\n" +
+			"class " + class + base + ":\n" +
+			"    def find_all(self, limit: int, offset: int) -> list[dict]:\n" +
+			"        rows, _, _ = _execute(LIST_SQL, (limit, offset))\n" +
+			"        return [_to_dict(row) for row in rows]\n\n" +
+			"    def find_by_id(self, id: int) -> dict | None:\n" +
+			"        rows, _, _ = _execute(GET_SQL, (id,))\n" +
+			"        return _to_dict(rows[0]) if rows else None\n\n" +
+			"    def create(self, data: dict) -> dict:\n" +
+			insert +
+			"        return self.find_by_id(" + newID + ")\n\n" +
+			"    def update(self, id: int, data: dict) -> dict | None:\n" +
+			"        current = self.find_by_id(id)\n" +
+			"        if current is None:\n" +
+			"            return None\n" +
+			"        merged = {**current, **data}\n" +
+			"        _execute(UPDATE_SQL, (*(merged.get(c) for c in COLUMNS), id))\n" +
+			"        return self.find_by_id(id)\n\n" +
+			"    def delete(self, id: int) -> bool:\n" +
+			"        _, count, _ = _execute(DELETE_SQL, (id,))\n" +
+			"        return count > 0\n")
+	default:
+		if header != "" {
+			b.WriteString(header + "\n")
+		}
+		b.WriteString("# Rows live in memory; pick a SQL database to persist them.\n" +
+			"_rows: list[dict] = []\n" +
+			"_next_id = 1\n\n\n" +
+			"class " + class + base + ":\n" +
+			"    def find_all(self, limit: int, offset: int) -> list[dict]:\n" +
+			"        return [dict(row) for row in _rows[offset:offset + limit]]\n\n" +
+			"    def find_by_id(self, id: int) -> dict | None:\n" +
+			"        for row in _rows:\n" +
+			"            if row[\"id\"] == id:\n" +
+			"                return dict(row)\n" +
+			"        return None\n\n" +
+			"    def create(self, data: dict) -> dict:\n" +
+			"        global _next_id\n" +
+			"        row = {**data, \"id\": _next_id}\n" +
+			"        _next_id += 1\n" +
+			"        _rows.append(row)\n" +
+			"        return dict(row)\n\n" +
+			"    def update(self, id: int, data: dict) -> dict | None:\n" +
+			"        for row in _rows:\n" +
+			"            if row[\"id\"] == id:\n" +
+			"                row.update(data, id=id)\n" +
+			"                return dict(row)\n" +
+			"        return None\n\n" +
+			"    def delete(self, id: int) -> bool:\n" +
+			"        for i, row in enumerate(_rows):\n" +
+			"            if row[\"id\"] == id:\n" +
+			"                del _rows[i]\n" +
+			"                return True\n" +
+			"        return False\n")
+	}
+	return b.String()
+}
+
+// pythonRepository returns the expression that builds a model's repository of
+// class, read through the cache when the models are cached, and the import
+// that needs besides class itself.
+func pythonRepository(req *GenerateRequest, model DataModel, class string) (string, string) {
+	if !cachesModels(*req) {
+		return class + "()", ""
+	}
+	return fmt.Sprintf("CachedRepository(%s(), '%s')", class, strings.ToLower(model.Name)),
+		"from app.cache.cached_repository import CachedRepository\n"
+}
+
 func (g *PythonGenerator) renderPythonDynamicModel(tree *FileTree, req *GenerateRequest, model DataModel, arch, root string) {
 	prefix := root
 	if prefix != "" {
 		prefix += "/"
 	}
 	name := model.Name
-	nameLow := strings.ToLower(name)
 	snakeName := toSnake(name)
 
-	pydanticFields := buildPydanticFields(model)
-	sampleDict := buildPythonSampleDict(model)
+	schema := renderPydanticModel(model)
+	routes := modelRoutes(model)
+	actions := map[string]bool{}
+	for _, r := range routes {
+		actions[r.Action] = true
+	}
+	// imports is what every router module needs besides its own layer.
+	imports := "from fastapi import APIRouter, HTTPException\n"
+	if actions[ActionList] || req.Features.RBAC {
+		imports = "from fastapi import APIRouter, Depends, HTTPException\n"
+	}
+	if req.Features.RBAC {
+		imports += "from app.auth.rbac import authorize\n"
+	}
+	permission := func(r modelRoute) string {
+		if !req.Features.RBAC {
+			return ""
+		}
+		return routePermission(model, r)
+	}
+	pagination := ""
+	if actions[ActionList] {
+		pagination = "from app.utils.pagination import PageParams, page_params\n"
+	}
 
 	switch arch {
 	case "clean":
-		addFile(tree, prefix+"app/domain/"+snakeName+".py", "from pydantic import BaseModel\n\n\nclass "+name+"(BaseModel):\n"+pydanticFields)
-		addFile(tree, prefix+"app/usecases/list_"+snakeName+"s.py", "from app.repository."+snakeName+"_repository import "+name+"Repository\n\n\ndef list_"+snakeName+"s():\n    return "+name+"Repository().find_all()\n")
-		addFile(tree, prefix+"app/delivery/http/"+snakeName+"_controller.py", "from fastapi import APIRouter\nfrom app.usecases.list_"+snakeName+"s import list_"+snakeName+"s\nfrom app.domain."+snakeName+" import "+name+"\n\nrouter = APIRouter(prefix='/"+nameLow+"s', tags=['"+name+"'])\n\n@router.get('')\ndef get_"+snakeName+"s():\n    return list_"+snakeName+"s()\n\n@router.post('', status_code=201)\ndef create_"+snakeName+"(data: "+name+"):\n    return data\n")
-		addFile(tree, prefix+"app/repository/"+snakeName+"_repository.py", "from app.domain."+snakeName+" import "+name+"\n\nclass "+name+"Repository:\n    def find_all(self) -> list:\n        return ["+name+"(**"+sampleDict+")]\n    def find_by_id(self, id: int):\n        return "+name+"(id=id, **"+sampleDict+")\n    def create(self, data: "+name+"):\n        return data\n")
+		addFile(tree, prefix+"app/domain/"+snakeName+".py", schema)
+		var usecaseImports, handlers strings.Builder
+		calls := map[string]string{}
+		for _, u := range pythonUsecases {
+			fn := u.prefix + snakeName + u.suffix
+			calls[u.action] = fn + "_usecase"
+			if !actions[u.action] {
+				continue
+			}
+			repo, repoImport := pythonRepository(req, model, name+"Repository")
+			addFile(tree, prefix+"app/usecases/"+fn+".py",
+				repoImport+"from app.repository."+snakeName+"_repository import "+name+"Repository\n\n"+
+					"_repo = "+repo+"\n\n\n"+
+					"def "+fn+"("+u.params+"):\n    return _repo."+u.call+"\n")
+			usecaseImports.WriteString("from app.usecases." + fn + " import " + fn + " as " + fn + "_usecase\n")
+		}
+		for _, r := range routes {
+			handlers.WriteString(pythonHandler("router", name, r, calls, permission(r)))
+		}
+		addFile(tree, prefix+"app/delivery/http/"+snakeName+"_controller.py",
+			imports+usecaseImports.String()+
+				"from app.domain."+snakeName+" import "+name+"\n"+pagination+"\n"+
+				"router = APIRouter(tags=['"+name+"'])\n"+handlers.String())
+		addFile(tree, prefix+"app/repository/"+snakeName+"_repository.py",
+			renderPythonRepository(req, model, name+"Repository", "", ""))
 	case "hexagonal":
-		addFile(tree, prefix+"app/domain/"+snakeName+".py", "from pydantic import BaseModel\n\n\nclass "+name+"(BaseModel):\n"+pydanticFields)
-		addFile(tree, prefix+"app/core/ports/"+snakeName+"_repository_port.py", "from abc import ABC, abstractmethod\nfrom app.domain."+snakeName+" import "+name+"\n\n\nclass "+name+"RepositoryPort(ABC):\n    @abstractmethod\n    def find_all(self) -> list: ...\n    @abstractmethod\n    def find_by_id(self, id: int): ...\n    @abstractmethod\n    def create(self, data: "+name+"): ...\n")
-		addFile(tree, prefix+"app/core/services/"+snakeName+"_service.py", "from app.core.ports."+snakeName+"_repository_port import "+name+"RepositoryPort\nfrom app.domain."+snakeName+" import "+name+"\n\n\nclass "+name+"Service:\n    def __init__(self, repo: "+name+"RepositoryPort):\n        self.repo = repo\n\n    def list_all(self): return self.repo.find_all()\n    def get_by_id(self, id: int): return self.repo.find_by_id(id)\n    def create(self, data: "+name+"): return self.repo.create(data)\n")
-		addFile(tree, prefix+"app/adapters/primary/http/"+snakeName+"_controller.py", "from fastapi import APIRouter\nfrom app.core.services."+snakeName+"_service import "+name+"Service\nfrom app.adapters.secondary.database."+snakeName+"_repository_adapter import "+name+"RepositoryAdapter\nfrom app.domain."+snakeName+" import "+name+"\n\n"+snakeName+"_router = APIRouter(prefix='/"+nameLow+"s', tags=['"+name+"'])\n_svc = "+name+"Service("+name+"RepositoryAdapter())\n\n@"+snakeName+"_router.get('')\ndef list_"+snakeName+"s(): return _svc.list_all()\n\n@"+snakeName+"_router.get('/{id}')\ndef get_"+snakeName+"(id: int): return _svc.get_by_id(id)\n\n@"+snakeName+"_router.post('', status_code=201)\ndef create_"+snakeName+"(data: "+name+"): return _svc.create(data)\n")
-		addFile(tree, prefix+"app/adapters/secondary/database/"+snakeName+"_repository_adapter.py", "from app.core.ports."+snakeName+"_repository_port import "+name+"RepositoryPort\nfrom app.domain."+snakeName+" import "+name+"\n\n\nclass "+name+"RepositoryAdapter("+name+"RepositoryPort):\n    def find_all(self): return ["+name+"(**"+sampleDict+")]\n    def find_by_id(self, id: int): return "+name+"(id=id, **"+sampleDict+")\n    def create(self, data: "+name+"): return data\n")
+		addFile(tree, prefix+"app/domain/"+snakeName+".py", schema)
+		addFile(tree, prefix+"app/core/ports/"+snakeName+"_repository_port.py", "from abc import ABC, abstractmethod\n\n\nclass "+name+"RepositoryPort(ABC):\n    @abstractmethod\n    def find_all(self, limit: int, offset: int) -> list[dict]: ...\n    @abstractmethod\n    def find_by_id(self, id: int) -> dict | None: ...\n    @abstractmethod\n    def create(self, data: dict) -> dict: ...\n    @abstractmethod\n    def update(self, id: int, data: dict) -> dict | None: ...\n    @abstractmethod\n    def delete(self, id: int) -> bool: ...\n")
+		addFile(tree, prefix+"app/core/services/"+snakeName+"_service.py", "from app.core.ports."+snakeName+"_repository_port import "+name+"RepositoryPort\n\n\nclass "+name+"Service:\n    def __init__(self, repo: "+name+"RepositoryPort):\n        self.repo = repo\n\n    def list_all(self, limit: int, offset: int): return self.repo.find_all(limit, offset)\n    def get_by_id(self, id: int): return self.repo.find_by_id(id)\n    def create(self, data: dict): return self.repo.create(data)\n    def update(self, id: int, data: dict): return self.repo.update(id, data)\n    def delete(self, id: int): return self.repo.delete(id)\n")
+		calls := map[string]string{
+			ActionList: "_svc.list_all", ActionGet: "_svc.get_by_id", ActionCreate: "_svc.create",
+			ActionUpdate: "_svc.update", ActionDelete: "_svc.delete",
+		}
+		var handlers strings.Builder
+		for _, r := range routes {
+			handlers.WriteString(pythonHandler(snakeName+"_router", name, r, calls, permission(r)))
+		}
+		repo, repoImport := pythonRepository(req, model, name+"RepositoryAdapter")
+		addFile(tree, prefix+"app/adapters/primary/http/"+snakeName+"_controller.py", imports+repoImport+"from app.core.services."+snakeName+"_service import "+name+"Service\nfrom app.adapters.secondary.database."+snakeName+"_repository_adapter import "+name+"RepositoryAdapter\nfrom app.domain."+snakeName+" import "+name+"\n"+pagination+"\n"+snakeName+"_router = APIRouter(tags=['"+name+"'])\n_svc = "+name+"Service("+repo+")\n"+handlers.String())
+		addFile(tree, prefix+"app/adapters/secondary/database/"+snakeName+"_repository_adapter.py",
+			renderPythonRepository(req, model, name+"RepositoryAdapter",
+				"from app.core.ports."+snakeName+"_repository_port import "+name+"RepositoryPort\n", "("+name+"RepositoryPort)"))
 	default:
-		addFile(tree, prefix+"app/schemas/"+snakeName+".py", "from pydantic import BaseModel\n\n\nclass "+name+"(BaseModel):\n"+pydanticFields)
-		addFile(tree, prefix+"app/routes/"+snakeName+"s.py", "from fastapi import APIRouter, Query\nfrom app.schemas."+snakeName+" import "+name+"\n\nrouter = APIRouter(prefix='/"+nameLow+"s', tags=['"+name+"'])\n\n@router.get('')\ndef list_"+snakeName+"s(limit: int = Query(default=20, le=100), offset: int = Query(default=0)):\n    return {\"limit\": limit, \"offset\": offset, \"data\": ["+sampleDict+"]}\n\n@router.get('/{id}')\ndef get_"+snakeName+"(id: int):\n    return {\"id\": id}\n\n@router.post('', status_code=201)\ndef create_"+snakeName+"(data: "+name+"):\n    return {\"id\": 1, **data.dict()}\n\n@router.put('/{id}')\ndef update_"+snakeName+"(id: int, data: "+name+"):\n    return {\"id\": id, **data.dict()}\n\n@router.delete('/{id}')\ndef delete_"+snakeName+"(id: int):\n    return {\"deleted\": id}\n")
-	}
-}
-
-func buildPydanticFields(model DataModel) string {
-	var b strings.Builder
-	for _, f := range model.Fields {
-		fn := strings.ToLower(f.Name)
-		pyType := "str"
-		switch strings.ToLower(f.Type) {
-		case "int", "integer":
-			pyType = "int"
-		case "float", "float64", "double":
-			pyType = "float"
-		case "bool", "boolean":
-			pyType = "bool"
-		case "datetime", "timestamp", "time":
-			pyType = "str"
+		addFile(tree, prefix+"app/schemas/"+snakeName+".py", schema)
+		calls := map[string]string{
+			ActionList: "_repo.find_all", ActionGet: "_repo.find_by_id", ActionCreate: "_repo.create",
+			ActionUpdate: "_repo.update", ActionDelete: "_repo.delete",
 		}
-		b.WriteString("    " + fn + ": " + pyType + "\n")
+		var handlers strings.Builder
+		for _, r := range routes {
+			handlers.WriteString(pythonHandler("router", name, r, calls, permission(r)))
+		}
+		repo, repoImport := pythonRepository(req, model, name+"Repository")
+		addFile(tree, prefix+"app/routes/"+snakeName+"s.py", imports+repoImport+
+			"from app.repository."+snakeName+"_repository import "+name+"Repository\n"+
+			"from app.schemas."+snakeName+" import "+name+"\n"+pagination+"\n"+
+			"router = APIRouter(tags=['"+name+"'])\n_repo = "+repo+"\n"+handlers.String())
+		addFile(tree, prefix+"app/repository/"+snakeName+"_repository.py",
+			renderPythonRepository(req, model, name+"Repository", "", ""))
 	}
-	return b.String()
 }
 
 func buildPythonSampleDict(model DataModel) string {
 	var b strings.Builder
 	b.WriteString("{")
-	for i, f := range model.Fields {
+	for i, f := range modelColumns(model) {
 		if i > 0 {
 			b.WriteString(", ")
 		}
-		fn := strings.ToLower(f.Name)
-		switch strings.ToLower(f.Type) {
+		fn := f.Name
+		if len(f.Enum) > 0 {
+			b.WriteString("\"" + fn + "\": \"" + f.Enum[0] + "\"")
+			continue
+		}
+		switch f.Type {
 		case "int", "integer":
 			b.WriteString("\"" + fn + "\": 1")
 		case "float", "float64", "double":
@@ -613,6 +1421,14 @@ func toSnake(s string) string {
 	return b.String()
 }
 
+// joinList joins items as prose: "a", "a and b", "a, b and c".
+func joinList(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
 // -------------------------------------------------------------------------
 // Django scaffold helpers — called only by PythonGenerator
 // -------------------------------------------------------------------------
@@ -621,34 +1437,526 @@ func addDjangoFiles(tree *FileTree, req GenerateRequest, main string) {
 	_ = main
 	addFile(tree, "manage.py", "#!/usr/bin/env python\nimport os\nimport sys\n\nif __name__ == '__main__':\n    os.environ.setdefault('DJANGO_SETTINGS_MODULE', 'config.settings')\n    from django.core.management import execute_from_command_line\n    execute_from_command_line(sys.argv)\n")
 	addFile(tree, "config/__init__.py", "")
-	addFile(tree, "config/settings.py", djangoSettings(req.Database != "none"))
-	addFile(tree, "config/urls.py", "from django.urls import include, path\n\nurlpatterns = [path('api/', include('api.urls')),]\n")
+	addFile(tree, "config/settings.py", djangoSettings(req))
+	addFile(tree, "config/urls.py", djangoRootURLs(req))
 	addFile(tree, "config/wsgi.py", "import os\nfrom django.core.wsgi import get_wsgi_application\nos.environ.setdefault('DJANGO_SETTINGS_MODULE', 'config.settings')\napplication = get_wsgi_application()\n")
 	addFile(tree, "api/__init__.py", "")
-	addFile(tree, "api/apps.py", "from django.apps import AppConfig\n\nclass ApiConfig(AppConfig):\n    default_auto_field = 'django.db.models.BigAutoField'\n    name = 'api'\n")
+	addFile(tree, "api/apps.py", djangoAppConfig(req))
 	addFile(tree, "api/urls.py", "from django.urls import path\nfrom .views import health, items\n\nurlpatterns = [\n    path('health', health),\n    path('items', items),\n]\n")
 	addFile(tree, "api/views.py", "from rest_framework.decorators import api_view\nfrom rest_framework.response import Response\n\n@api_view(['GET'])\ndef health(request):\n    return Response({\"status\": \"ok\"})\n\n@api_view(['GET'])\ndef items(request):\n    return Response([{\"id\": 1, \"name\": \"sample\"}])\n")
-	addFile(tree, "requirements.txt", pythonRequirements("django", req.Database, req.UseORM))
+	addFile(tree, "requirements.txt", pythonRequirements("django", req.Database, req.UseORM)+pythonAuthRequirements(&req)+pythonObservabilityRequirements(&req)+pythonCacheRequirements(&req)+pythonMessagingRequirements(&req))
+	if req.Features.JWTAuth {
+		addFile(tree, "api/auth.py", djangoAuth(req))
+		addFile(tree, "api/auth_urls.py", djangoAuthURLs)
+	}
+	if req.Features.RBAC {
+		addFile(tree, "api/rbac.py", djangoRBACModule(req))
+	}
+	if req.Features.Observability {
+		addFile(tree, "api/telemetry.py", djangoTelemetryModule(req, "app"))
+	}
 }
 
 func addDjangoFilesAtRoot(tree *FileTree, req GenerateRequest, main string, root string) {
 	_ = main
 	addFile(tree, root+"/manage.py", "#!/usr/bin/env python\nimport os\nimport sys\n\nif __name__ == '__main__':\n    os.environ.setdefault('DJANGO_SETTINGS_MODULE', 'config.settings')\n    from django.core.management import execute_from_command_line\n    execute_from_command_line(sys.argv)\n")
 	addFile(tree, root+"/config/__init__.py", "")
-	addFile(tree, root+"/config/settings.py", djangoSettings(req.Database != "none"))
-	addFile(tree, root+"/config/urls.py", "from django.urls import include, path\nurlpatterns = [path('api/', include('api.urls')),]\n")
+	addFile(tree, root+"/config/settings.py", djangoSettings(req))
+	addFile(tree, root+"/config/urls.py", djangoRootURLs(req))
 	addFile(tree, root+"/config/wsgi.py", "import os\nfrom django.core.wsgi import get_wsgi_application\nos.environ.setdefault('DJANGO_SETTINGS_MODULE', 'config.settings')\napplication = get_wsgi_application()\n")
 	addFile(tree, root+"/api/__init__.py", "")
-	addFile(tree, root+"/api/apps.py", "from django.apps import AppConfig\n\nclass ApiConfig(AppConfig):\n    default_auto_field = 'django.db.models.BigAutoField'\n    name = 'api'\n")
+	addFile(tree, root+"/api/apps.py", djangoAppConfig(req))
 	addFile(tree, root+"/api/urls.py", "from django.urls import path\nfrom .views import health, items\nurlpatterns = [path('health', health), path('items', items)]\n")
 	addFile(tree, root+"/api/views.py", "from rest_framework.decorators import api_view\nfrom rest_framework.response import Response\n\n@api_view(['GET'])\ndef health(request):\n    return Response({\"status\": \"ok\"})\n\n@api_view(['GET'])\ndef items(request):\n    return Response([{\"id\":1,\"name\":\"sample\"}])\n")
-	addFile(tree, root+"/requirements.txt", pythonRequirements("django", req.Database, req.UseORM))
+	addFile(tree, root+"/requirements.txt", pythonRequirements("django", req.Database, req.UseORM)+pythonAuthRequirements(&req)+pythonObservabilityRequirements(&req)+pythonCacheRequirements(&req)+pythonMessagingRequirements(&req))
+	if req.Features.JWTAuth {
+		addFile(tree, root+"/api/auth.py", djangoAuth(req))
+		addFile(tree, root+"/api/auth_urls.py", djangoAuthURLs)
+	}
+	if req.Features.RBAC {
+		addFile(tree, root+"/api/rbac.py", djangoRBACModule(req))
+	}
+	if req.Features.Observability {
+		addFile(tree, root+"/api/telemetry.py", djangoTelemetryModule(req, path.Base(root)))
+	}
+}
+
+// addDjangoMessaging renders the messaging package into the api app. Django
+// has no lifespan to run the consumers from, so it gets the consume_events
+// command instead, and views publish through publish_sync.
+func addDjangoMessaging(ctx *GenerationContext, req GenerateRequest, data map[string]any, root string) error {
+	addFile(ctx.FileTree, path.Join(root, "api/messaging/__init__.py"), "")
+	names := []string{"events", "sync"}
+	if req.Infra.Kafka {
+		names = append(names, "kafka", "kafka_producer", "kafka_consumer")
+	}
+	if req.Infra.NATS {
+		names = append(names, "nats_client", "nats_publisher", "nats_subscriber")
+	}
+	if req.Infra.RabbitMQ {
+		names = append(names, "rabbitmq", "rabbitmq_publisher", "rabbitmq_consumer")
+	}
+	for _, name := range names {
+		body, err := ctx.Registry.Render("python/shared/messaging/"+name+".tmpl", data)
+		if err != nil {
+			return err
+		}
+		addFile(ctx.FileTree, path.Join(root, "api/messaging", name+".py"), body)
+	}
+	addFile(ctx.FileTree, path.Join(root, "api/management/__init__.py"), "")
+	addFile(ctx.FileTree, path.Join(root, "api/management/commands/__init__.py"), "")
+	addFile(ctx.FileTree, path.Join(root, "api/management/commands/consume_events.py"), djangoConsumeEvents(req))
+	return nil
+}
+
+// addDjangoCache renders the Redis client into the api app, next to the view
+// config/urls.py serves /health/redis from.
+func addDjangoCache(ctx *GenerationContext, data map[string]any, root string) error {
+	body, err := ctx.Registry.Render("python/shared/cache/redis_cache.tmpl", data)
+	if err != nil {
+		return err
+	}
+	addFile(ctx.FileTree, path.Join(root, "api/cache/__init__.py"), "")
+	addFile(ctx.FileTree, path.Join(root, "api/cache/redis_cache.py"), body)
+	addFile(ctx.FileTree, path.Join(root, "api/cache/views.py"), djangoCacheViews)
+	return nil
+}
+
+// djangoCacheViews answers /health/redis like the other stacks do.
+const djangoCacheViews = `from django.http import JsonResponse
+
+from .redis_cache import health
+
+
+def redis_health(request):
+    code, body = health()
+    return JsonResponse(body, status=code)
+`
+
+// djangoMessagingReadme follows the Events section on Django, which starts
+// the consumers from a command rather than with the app.
+func djangoMessagingReadme(req GenerateRequest) string {
+	if !usesMessaging(req) || req.Framework != "django" {
+		return ""
+	}
+	var helpers []string
+	if req.Infra.Kafka {
+		helpers = append(helpers, "`api.messaging.kafka.publish_sync`")
+	}
+	if req.Infra.NATS {
+		helpers = append(helpers, "`api.messaging.nats_client.publish_sync`")
+	}
+	if req.Infra.RabbitMQ {
+		helpers = append(helpers, "`api.messaging.rabbitmq.publish_sync`")
+	}
+	return "\nOn Django, run the consumers with `python manage.py consume_events` next to the server; views publish with " + joinList(helpers) + ".\n"
 }
 
-func djangoSettings(withDB bool) string {
+// djangoConsumeEvents is the command that runs the enabled consumers until
+// the process is told to stop.
+func djangoConsumeEvents(req GenerateRequest) string {
+	var imports, starts, names []string
+	if req.Infra.Kafka {
+		imports = append(imports, "from api.messaging.kafka import start_kafka")
+		starts = append(starts, "await start_kafka(handler)")
+		names = append(names, "the Kafka consumer group")
+	}
+	if req.Infra.NATS {
+		imports = append(imports, "from api.messaging.nats_client import start_nats")
+		starts = append(starts, "await start_nats(handler)")
+		names = append(names, "the NATS subscriber")
+	}
+	if req.Infra.RabbitMQ {
+		imports = append(imports, "from api.messaging.rabbitmq import start_rabbitmq")
+		starts = append(starts, "await start_rabbitmq(handler)")
+		names = append(names, "the RabbitMQ consumer")
+	}
+	return fmt.Sprintf(`import asyncio
+import logging
+import signal
+
+from django.core.management.base import BaseCommand
+
+from api.messaging.events import log_event
+%s
+
+
+async def consume(handler) -> None:
+    stops = [%s]
+    done = asyncio.Event()
+    loop = asyncio.get_running_loop()
+    for sig in (signal.SIGINT, signal.SIGTERM):
+        loop.add_signal_handler(sig, done.set)
+    await done.wait()
+    for stop in reversed(stops):
+        await stop()
+
+
+class Command(BaseCommand):
+    help = 'Runs %s until SIGINT or SIGTERM.'
+
+    def handle(self, *args, **options):
+        logging.basicConfig(level=logging.INFO)
+        asyncio.run(consume(log_event))
+`, strings.Join(imports, "\n"), strings.Join(starts, ", "), joinList(names))
+}
+
+// djangoSettings points Django at the chosen SQL database, a SQLite one at
+// the file DATABASE_URL names; it has no driver for the others, so they fall
+// back to SQLite. With observability on it
+// mounts the request ID and metrics middleware.
+func djangoSettings(req GenerateRequest) string {
+	database := req.Database
+	middleware := ""
+	if req.Features.Observability {
+		middleware = "'api.middleware.RequestIDMiddleware', 'api.telemetry.MetricsMiddleware'"
+	}
+	paths := "BASE_DIR = Path(__file__).resolve().parent.parent\n"
 	db := "\"ENGINE\": \"django.db.backends.sqlite3\", \"NAME\": BASE_DIR / \"db.sqlite3\""
-	if withDB {
+	switch database {
+	case "sqlite":
+		db = "\"ENGINE\": \"django.db.backends.sqlite3\", \"NAME\": DATABASE_PATH"
+		paths += "DATABASE_PATH = Path(os.getenv('DATABASE_URL', '" + sqliteURL + "').removeprefix('file:'))\nDATABASE_PATH.parent.mkdir(parents=True, exist_ok=True)\n"
+	case "postgresql":
 		db = "\"ENGINE\": \"django.db.backends.postgresql\", \"NAME\": \"app\", \"USER\": \"app\", \"PASSWORD\": \"app\", \"HOST\": \"postgres\", \"PORT\": \"5432\""
+	case "mysql":
+		db = "\"ENGINE\": \"django.db.backends.mysql\", \"NAME\": \"app\", \"USER\": \"app\", \"PASSWORD\": \"app\", \"HOST\": \"mysql\", \"PORT\": \"3306\""
+	}
+	return fmt.Sprintf("import os\nfrom pathlib import Path\n\n%sSECRET_KEY = 'dev'\nDEBUG = True\nALLOWED_HOSTS = ['*']\nINSTALLED_APPS = ['django.contrib.contenttypes', 'django.contrib.auth', 'rest_framework', 'api']\nMIDDLEWARE = [%s]\nROOT_URLCONF = 'config.urls'\nTEMPLATES = []\nWSGI_APPLICATION = 'config.wsgi.application'\nDATABASES = {'default': {%s}}\nLANGUAGE_CODE = 'en-us'\nTIME_ZONE = 'UTC'\nUSE_I18N = True\nUSE_TZ = True\n", paths, middleware, db)
+}
+
+// djangoServesModels reports whether the api app mounts model routes.
+func djangoServesModels(req GenerateRequest) bool {
+	return isEnabled(req.FileToggles.ExampleCRUD) && len(req.Custom.Models) > 0
+}
+
+// djangoFieldName is the model attribute behind a column: a belongs_to
+// foreign key user_id becomes the relation user, whose column Django names
+// user_id itself.
+func djangoFieldName(c modelColumn) string {
+	if c.References != "" {
+		return strings.TrimSuffix(c.Name, "_id")
+	}
+	return c.Name
+}
+
+func djangoModelField(c modelColumn) string {
+	var opts []string
+	typ := "CharField"
+	switch {
+	case c.References != "":
+		typ = "ForeignKey"
+		onDelete := "models.CASCADE"
+		if !c.Required {
+			onDelete = "models.SET_NULL"
+		}
+		opts = append(opts, strconv.Quote(c.References), "on_delete="+onDelete, `related_name="+"`)
+	case len(c.Enum) > 0:
+		choices := make([]string, 0, len(c.Enum))
+		for _, v := range c.Enum {
+			choices = append(choices, fmt.Sprintf("(%q, %q)", v, v))
+		}
+		opts = append(opts, fmt.Sprintf("max_length=%d", maxLength(c.DataField)), "choices=["+strings.Join(choices, ", ")+"]")
+	case c.Type == "int" || c.Type == "integer":
+		typ = "IntegerField"
+	case c.Type == "float" || c.Type == "float64" || c.Type == "double":
+		typ = "FloatField"
+	case c.Type == "bool" || c.Type == "boolean":
+		typ = "BooleanField"
+	case isDateTimeField(c.DataField):
+		typ = "DateTimeField"
+	default:
+		opts = append(opts, fmt.Sprintf("max_length=%d", maxLength(c.DataField)))
+	}
+	if c.Unique && c.References == "" {
+		opts = append(opts, "unique=True")
+	}
+	switch {
+	case c.Default != "" && isDateTimeField(c.DataField):
+		opts = append(opts, "auto_now_add=True")
+	case c.Default != "":
+		opts = append(opts, "default="+pythonLiteral(c.DataField))
+	}
+	if !c.Required {
+		opts = append(opts, "null=True", "blank=True")
+	}
+	return djangoFieldName(c) + " = models." + typ + "(" + strings.Join(opts, ", ") + ")"
+}
+
+// renderDjangoModels renders api/models.py with one model per data model on
+// the same tables the other stacks use.
+func renderDjangoModels(models []DataModel) string {
+	var b strings.Builder
+	b.WriteString("from django.db import models\n")
+	for _, m := range models {
+		b.WriteString("\n\nclass " + m.Name + "(models.Model):\n")
+		attr := map[string]string{}
+		for _, c := range storedColumns(m) {
+			attr[c.Name] = djangoFieldName(c)
+			b.WriteString("    " + djangoModelField(c) + "\n")
+		}
+		table := modelTable(m)
+		b.WriteString("\n    class Meta:\n        db_table = " + strconv.Quote(table) + "\n")
+		var indexes, constraints []string
+		for _, idx := range m.Indexes {
+			fields := make([]string, 0, len(idx.Fields))
+			for _, col := range indexColumns(idx) {
+				if a, ok := attr[col]; ok {
+					col = a
+				}
+				fields = append(fields, strconv.Quote(col))
+			}
+			if idx.Unique {
+				constraints = append(constraints, fmt.Sprintf("models.UniqueConstraint(fields=[%s], name=%q)", strings.Join(fields, ", "), indexName(table, idx)))
+			} else {
+				indexes = append(indexes, fmt.Sprintf("models.Index(fields=[%s], name=%q)", strings.Join(fields, ", "), indexName(table, idx)))
+			}
+		}
+		if len(indexes) > 0 {
+			b.WriteString("        indexes = [" + strings.Join(indexes, ", ") + "]\n")
+		}
+		if len(constraints) > 0 {
+			b.WriteString("        constraints = [" + strings.Join(constraints, ", ") + "]\n")
+		}
+	}
+	return b.String()
+}
+
+// renderDjangoSerializers renders one ModelSerializer per model. Foreign keys
+// are exposed under their column name, as the other stacks' JSON does.
+func renderDjangoSerializers(models []DataModel) string {
+	var b strings.Builder
+	names := make([]string, 0, len(models))
+	for _, m := range models {
+		names = append(names, m.Name)
+	}
+	b.WriteString("from rest_framework import serializers\n\nfrom .models import " + strings.Join(names, ", ") + "\n")
+	for _, m := range models {
+		b.WriteString("\n\nclass " + m.Name + "Serializer(serializers.ModelSerializer):\n")
+		fields := []string{`"id"`}
+		related := false
+		for _, c := range storedColumns(m) {
+			fields = append(fields, strconv.Quote(c.Name))
+			if c.References != "" {
+				related = true
+				b.WriteString(fmt.Sprintf("    %s = serializers.PrimaryKeyRelatedField(source=%q, queryset=%s.objects.all(), allow_null=%s, required=%s)\n",
+					c.Name, djangoFieldName(c), c.References, pythonBool(!c.Required), pythonBool(c.Required)))
+			}
+		}
+		if related {
+			b.WriteString("\n")
+		}
+		b.WriteString("    class Meta:\n        model = " + m.Name + "\n        fields = [" + strings.Join(fields, ", ") + "]\n")
+	}
+	return b.String()
+}
+
+// djangoPath turns /posts/{postId} into posts/<int:postId>; idParams are
+// the parameters holding item ids, every other parameter stays a string.
+func djangoPath(p string, idParams map[string]bool) string {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			name := strings.Trim(s, "{}")
+			conv := "str"
+			if idParams[name] {
+				conv = "int"
+			}
+			segments[i] = "<" + conv + ":" + name + ">"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// djangoViewName names the view dispatching every method on path p, e.g.
+// users_user_id_posts_view for /users/{userId}/posts.
+func djangoViewName(p string) string {
+	var parts []string
+	for _, s := range strings.Split(strings.Trim(p, "/"), "/") {
+		s = toSnake(strings.Trim(s, "{}"))
+		s = strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' {
+				return r
+			}
+			return '_'
+		}, strings.ToLower(s))
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	if len(parts) == 0 {
+		parts = []string{"root"}
+	}
+	return strings.Join(parts, "_") + "_view"
+}
+
+// djangoHandler renders the plain function serving one route; the views
+// django dispatches to call it by method. With cached on, reads go through
+// Redis and writes drop the entries they make stale.
+func djangoHandler(m DataModel, r modelRoute, cached bool) string {
+	fn := pythonHandlerName(r.OperationID)
+	serializer := m.Name + "Serializer"
+	model := strconv.Quote(strings.ToLower(m.Name))
+	notFound := "        return Response({\"detail\": \"not found\"}, status=404)\n"
+	find := "    row = " + m.Name + ".objects.filter(pk=id).first()\n    if row is None:\n" + notFound
+	invalidate := func(args string) string {
+		if !cached {
+			return ""
+		}
+		return "    invalidate(" + args + ")\n"
+	}
+	switch r.Action {
+	case ActionList:
+		data := serializer + "(rows, many=True).data"
+		read := ""
+		if cached {
+			read = "    data = read_through(\n" +
+				"        key(" + model + ", \"list\", generation(" + model + "), page[\"limit\"], page[\"offset\"]),\n" +
+				"        lambda: " + data + ",\n    )\n"
+			data = "data"
+		}
+		return "def " + fn + "(request):\n" +
+			"    page = parse_page(_query_int(request, \"limit\", 20), _query_int(request, \"offset\", 0))\n" +
+			"    rows = " + m.Name + ".objects.order_by(\"id\")[page[\"offset\"]:page[\"offset\"] + page[\"limit\"]]\n" +
+			read +
+			"    return Response({**page, \"data\": " + data + "})\n"
+	case ActionGet:
+		if cached {
+			return "def " + fn + "(request, id):\n" +
+				"    data = read_through(key(" + model + ", id), lambda: _serialize(" + serializer + ", " + m.Name + ".objects.filter(pk=id).first()))\n" +
+				"    if data is None:\n" + notFound +
+				"    return Response(data)\n"
+		}
+		return "def " + fn + "(request, id):\n" + find +
+			"    return Response(" + serializer + "(row).data)\n"
+	case ActionCreate:
+		return "def " + fn + "(request):\n" +
+			"    serializer = " + serializer + "(data=request.data)\n" +
+			"    serializer.is_valid(raise_exception=True)\n" +
+			"    serializer.save()\n" +
+			invalidate(model) +
+			"    return Response(serializer.data, status=201)\n"
+	case ActionUpdate:
+		return "def " + fn + "(request, id):\n" + find +
+			"    serializer = " + serializer + "(row, data=request.data, partial=request.method == \"PATCH\")\n" +
+			"    serializer.is_valid(raise_exception=True)\n" +
+			"    serializer.save()\n" +
+			invalidate(model+", id") +
+			"    return Response(serializer.data)\n"
+	case ActionDelete:
+		return "def " + fn + "(request, id):\n" +
+			"    deleted, _ = " + m.Name + ".objects.filter(pk=id).delete()\n" +
+			"    if not deleted:\n" + notFound +
+			invalidate(model+", id") +
+			"    return Response(status=204)\n"
+	}
+	return "def " + fn + "(request):\n" +
+		"    return Response({\"detail\": \"" + r.OperationID + " is not implemented\"}, status=501)\n"
+}
+
+// addDjangoModelFiles writes the models, serializers, views and URLs that
+// serve every model's routes under root; config/urls.py includes the URLs.
+func addDjangoModelFiles(tree *FileTree, req *GenerateRequest, root string) {
+	prefix := root
+	if prefix != "" {
+		prefix += "/"
+	}
+	models := resolvedModels(req.Custom.Models)
+	type pathRoutes struct {
+		path        string
+		routes      []modelRoute
+		permissions []string // parallel to routes
+	}
+	var paths []*pathRoutes
+	byPath := map[string]*pathRoutes{}
+	var handlers strings.Builder
+	names := make([]string, 0, len(models))
+	for _, m := range models {
+		names = append(names, m.Name)
+		for _, r := range modelRoutes(m) {
+			handlers.WriteString("\n\n" + djangoHandler(m, r, cachesModels(*req)))
+			p := byPath[r.Path]
+			if p == nil {
+				p = &pathRoutes{path: r.Path}
+				byPath[r.Path] = p
+				paths = append(paths, p)
+			}
+			p.routes = append(p.routes, r)
+			p.permissions = append(p.permissions, routePermission(m, r))
+		}
+	}
+
+	guard := ""
+	if req.Features.JWTAuth {
+		guard = "@authentication_classes([JWTAuthentication])\n@permission_classes([IsAuthenticated])\n"
+	}
+	var views, urls strings.Builder
+	for _, p := range paths {
+		idParams := map[string]bool{}
+		var methods []string
+		for _, r := range p.routes {
+			if r.Param != "" {
+				idParams[r.Param] = true
+			}
+			methods = append(methods, strconv.Quote(r.Method))
+		}
+		params := p.routes[0].Params
+		view := djangoViewName(p.path)
+		pathGuard := guard
+		if req.Features.RBAC {
+			var perms []string
+			for i, r := range p.routes {
+				perms = append(perms, strconv.Quote(r.Method)+": "+strconv.Quote(p.permissions[i]))
+			}
+			pathGuard = "@authentication_classes([JWTAuthentication])\n@permission_classes([requires({" + strings.Join(perms, ", ") + "})])\n"
+		}
+		views.WriteString("\n\n@api_view([" + strings.Join(methods, ", ") + "])\n" + pathGuard +
+			"def " + view + "(" + strings.Join(append([]string{"request"}, params...), ", ") + "):\n")
+		for i, r := range p.routes {
+			args := "request"
+			if r.Param != "" {
+				args += ", " + r.Param
+			}
+			call := "return " + pythonHandlerName(r.OperationID) + "(" + args + ")\n"
+			if i == len(p.routes)-1 {
+				views.WriteString("    " + call)
+			} else {
+				views.WriteString("    if request.method == \"" + r.Method + "\":\n        " + call)
+			}
+		}
+		urls.WriteString("    path('" + djangoPath(p.path, idParams) + "', views." + view + "),\n")
+	}
+
+	serializers := make([]string, 0, len(names))
+	for _, n := range names {
+		serializers = append(serializers, n+"Serializer")
+	}
+	addFile(tree, prefix+"api/models.py", renderDjangoModels(models))
+	addFile(tree, prefix+"api/serializers.py", renderDjangoSerializers(models))
+	head := "from rest_framework.decorators import api_view\nfrom rest_framework.response import Response\n\n"
+	if req.Features.JWTAuth {
+		head = "from rest_framework.decorators import api_view, authentication_classes, permission_classes\n" +
+			"from rest_framework.permissions import IsAuthenticated\nfrom rest_framework.response import Response\n\n" +
+			"from .auth import JWTAuthentication\n"
+	}
+	if req.Features.RBAC {
+		head = strings.Replace(head, "from rest_framework.permissions import IsAuthenticated\n", "", 1) +
+			"from .rbac import requires\n"
+	}
+	helpers := ""
+	if cachesModels(*req) {
+		head += "from .cache.redis_cache import generation, invalidate, key, read_through\n"
+		helpers = "\n\ndef _serialize(serializer, row):\n    return serializer(row).data if row is not None else None\n"
 	}
-	return fmt.Sprintf("from pathlib import Path\n\nBASE_DIR = Path(__file__).resolve().parent.parent\nSECRET_KEY = 'dev'\nDEBUG = True\nALLOWED_HOSTS = ['*']\nINSTALLED_APPS = ['django.contrib.contenttypes', 'django.contrib.auth', 'rest_framework', 'api']\nMIDDLEWARE = []\nROOT_URLCONF = 'config.urls'\nTEMPLATES = []\nWSGI_APPLICATION = 'config.wsgi.application'\nDATABASES = {'default': {%s}}\nLANGUAGE_CODE = 'en-us'\nTIME_ZONE = 'UTC'\nUSE_I18N = True\nUSE_TZ = True\n", db)
+	addFile(tree, prefix+"api/model_views.py",
+		head+
+			"from .models import "+strings.Join(names, ", ")+"\n"+
+			"from .pagination import parse_page\n"+
+			"from .serializers import "+strings.Join(serializers, ", ")+"\n\n\n"+
+			"def _query_int(request, name: str, default: int) -> int:\n"+
+			"    try:\n        return int(request.query_params.get(name, default))\n"+
+			"    except ValueError:\n        return default\n"+
+			helpers+handlers.String()+views.String())
+	addFile(tree, prefix+"api/model_urls.py",
+		"from django.urls import path\n\nfrom . import model_views as views\n\nurlpatterns = [\n"+urls.String()+"]\n")
 }
@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	roleNameRegex   = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	permissionRegex = regexp.MustCompile(`^(\*|(\*|[a-z0-9_]+):(\*|[A-Za-z0-9_.-]+))$`)
+)
+
+// defaultRBACRoles are enforced when features.rbac is on and rbac.roles is
+// empty: admins may do anything, everyone else may read.
+var defaultRBACRoles = []RBACRole{
+	{Name: "admin", Permissions: []string{"*"}},
+	{Name: "user", Permissions: []string{"*:list", "*:get"}},
+}
+
+// applyRBACDefaults turns on JWT auth, whose access tokens carry the role
+// RBAC checks, and fills in the default roles.
+func applyRBACDefaults(req GenerateRequest) (GenerateRequest, []Decision) {
+	if !req.Features.RBAC {
+		return req, nil
+	}
+	var decisions []Decision
+	if !req.Features.JWTAuth {
+		req.Features.JWTAuth = true
+		decisions = append(decisions, Decision{
+			Code:        "JWT_AUTH_ENABLED_FOR_RBAC",
+			Description: "Enabled JWT auth since RBAC checks the role carried by access tokens.",
+			TriggeredBy: "ApplyRuleEngine",
+		})
+	}
+	if len(req.RBAC.Roles) == 0 {
+		req.RBAC.Roles = append([]RBACRole(nil), defaultRBACRoles...)
+		decisions = append(decisions, Decision{
+			Code:        "DEFAULT_RBAC_ROLES_INJECTED",
+			Description: "Injected the admin and user roles since rbac.roles is empty.",
+			TriggeredBy: "ApplyRuleEngine",
+		})
+	}
+	if strings.TrimSpace(req.RBAC.DefaultRole) == "" {
+		req.RBAC.DefaultRole = req.RBAC.Roles[len(req.RBAC.Roles)-1].Name
+	}
+	return req, decisions
+}
+
+// routePermission is the permission a model route requires, such as
+// orders:delete. Custom routes use their operation id as the action.
+func routePermission(m DataModel, r modelRoute) string {
+	action := r.Action
+	if action == ActionCustom {
+		action = r.OperationID
+	}
+	return modelTable(m) + ":" + action
+}
+
+// rbacGrants reports whether a role holding perms may use a route requiring
+// permission. The generated policy code matches the same way.
+func rbacGrants(perms []string, permission string) bool {
+	resource, action, _ := strings.Cut(permission, ":")
+	for _, p := range perms {
+		if p == "*" || p == permission || p == resource+":*" || p == "*:"+action {
+			return true
+		}
+	}
+	return false
+}
+
+// authData is the auth templates' data, under .Auth. Roles is nil unless
+// features.rbac is on; accounts still get DefaultRole, which access tokens
+// carry.
+type authData struct {
+	DefaultRole string
+	Roles       []RBACRole
+}
+
+func newAuthData(req GenerateRequest) authData {
+	if !req.Features.RBAC {
+		return authData{DefaultRole: "user"}
+	}
+	return authData{DefaultRole: req.RBAC.DefaultRole, Roles: req.RBAC.Roles}
+}
+
+// rbacReadmeSection documents the policy in the generated README: the roles,
+// then a matrix with a row per model route and a column per role.
+func rbacReadmeSection(req GenerateRequest) string {
+	if !req.Features.RBAC {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n## Access control\n\n")
+	b.WriteString("Model routes require a bearer access token from `POST /auth/login` whose role grants the route's permission; other roles get `403`. ")
+	fmt.Fprintf(&b, "New accounts get the `%s` role; change `auth_users.role` (on Django, the user's group) to promote one.\n\n", req.RBAC.DefaultRole)
+	b.WriteString("| Role | Permissions |\n|---|---|\n")
+	for _, role := range req.RBAC.Roles {
+		fmt.Fprintf(&b, "| `%s` | `%s` |\n", role.Name, strings.Join(role.Permissions, "`, `"))
+	}
+	models := resolvedModels(req.Custom.Models)
+	if !isEnabled(req.FileToggles.ExampleCRUD) || len(models) == 0 {
+		return b.String()
+	}
+	b.WriteString("\n| Route | Permission |")
+	for _, role := range req.RBAC.Roles {
+		b.WriteString(" " + role.Name + " |")
+	}
+	b.WriteString("\n|---|---|" + strings.Repeat("---|", len(req.RBAC.Roles)) + "\n")
+	for _, m := range models {
+		for _, r := range modelRoutes(m) {
+			perm := routePermission(m, r)
+			fmt.Fprintf(&b, "| `%s %s` | `%s` |", r.Method, r.Path, perm)
+			for _, role := range req.RBAC.Roles {
+				if rbacGrants(role.Permissions, perm) {
+					b.WriteString(" ✓ |")
+				} else {
+					b.WriteString("   |")
+				}
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// validateRBAC checks role names, the permission syntax and, when model
+// routes are served, that every permission grants at least one of them.
+func validateRBAC(v *validator, req GenerateRequest) {
+	if !req.Features.RBAC {
+		return
+	}
+	known := map[string]bool{}
+	if isEnabled(req.FileToggles.ExampleCRUD) {
+		for _, m := range resolvedModels(req.Custom.Models) {
+			for _, r := range modelRoutes(m) {
+				known[routePermission(m, r)] = true
+			}
+		}
+	}
+	routes := make([]string, 0, len(known))
+	for p := range known {
+		routes = append(routes, p)
+	}
+	sort.Strings(routes)
+
+	roles := map[string]bool{}
+	var names []string
+	for i, role := range req.RBAC.Roles {
+		pointer := fmt.Sprintf("/rbac/roles/%d", i)
+		switch {
+		case !roleNameRegex.MatchString(role.Name):
+			v.add(pointer+"/name", "RBAC_ROLE_INVALID", fmt.Sprintf("role name %q must be lowercase letters, digits, '_' or '-'", role.Name))
+		case roles[role.Name]:
+			v.add(pointer+"/name", "RBAC_ROLE_DUPLICATE", fmt.Sprintf("duplicate role %q", role.Name))
+		default:
+			roles[role.Name] = true
+			names = append(names, role.Name)
+		}
+		for j, p := range role.Permissions {
+			switch {
+			case !permissionRegex.MatchString(p):
+				v.add(fmt.Sprintf("%s/permissions/%d", pointer, j), "RBAC_PERMISSION_INVALID", fmt.Sprintf("permission %q must be \"*\" or <resource>:<action>", p))
+			case len(routes) > 0 && !grantsAny(p, routes):
+				v.add(fmt.Sprintf("%s/permissions/%d", pointer, j), "RBAC_PERMISSION_UNKNOWN", fmt.Sprintf("permission %q grants no generated route", p), routes...)
+			}
+		}
+	}
+	if !roles[req.RBAC.DefaultRole] {
+		v.add("/rbac/default_role", "RBAC_DEFAULT_ROLE_UNKNOWN", fmt.Sprintf("default role %q is not one of the roles", req.RBAC.DefaultRole), names...)
+	}
+}
+
+func grantsAny(permission string, routes []string) bool {
+	for _, r := range routes {
+		if rbacGrants([]string{permission}, r) {
+			return true
+		}
+	}
+	return false
+}
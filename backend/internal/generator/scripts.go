@@ -27,6 +27,11 @@ func buildBash(req GenerateRequest, tree FileTree) string {
 	b.WriteString("mkdir -p \"$ROOT_DIR\"\n")
 	b.WriteString("cd \"$ROOT_DIR\"\n\n")
 
+	existing := strings.ToLower(req.Root.Mode) == "existing"
+	if existing {
+		b.WriteString(existingRootBash)
+	}
+
 	if strings.ToLower(req.Root.Mode) == "new" {
 		if req.Root.GitInit {
 			b.WriteString("git init\n")
@@ -48,7 +53,11 @@ func buildBash(req GenerateRequest, tree FileTree) string {
 	files := fileNamesSorted(tree.Files)
 	for _, f := range files {
 		content := tree.Files[f]
-		b.WriteString(fmt.Sprintf("cat > %q <<'%s'\n", f, bashHeredocDelimiter))
+		if existing {
+			b.WriteString(fmt.Sprintf("stacksprint_place %q %q <<'%s'\n", f, conflictPolicy(req.Root, f), bashHeredocDelimiter))
+		} else {
+			b.WriteString(fmt.Sprintf("cat > %q <<'%s'\n", f, bashHeredocDelimiter))
+		}
 		b.WriteString(content)
 		if !strings.HasSuffix(content, "\n") {
 			b.WriteString("\n")
@@ -56,11 +65,112 @@ func buildBash(req GenerateRequest, tree FileTree) string {
 		b.WriteString(bashHeredocDelimiter + "\n\n")
 	}
 
+	if existing {
+		b.WriteString(existingRootSummaryBash)
+	}
 	b.WriteString("echo \"StackSprint project generated successfully.\"\n")
 	b.WriteString("echo \"Run: docker compose up --build\"\n")
 	return b.String()
 }
 
+// existingRootBash defines stacksprint_place, which the script uses instead of
+// `cat >` when generating into an existing root: new files are created, and a
+// file that already exists is handled by the policy resolved for its path
+// (conflictPolicy). The merge branch mirrors the Go mergers in existing.go.
+const existingRootBash = `SS_CREATED=() SS_OVERWRITTEN=() SS_BACKED_UP=() SS_MERGED=() SS_SKIPPED=() SS_UNCHANGED=()
+
+# stacksprint_place PATH POLICY: write stdin to PATH; POLICY applies if PATH exists.
+stacksprint_place() {
+  local target="$1" policy="$2" tmp
+  tmp="$(mktemp)"
+  cat > "$tmp"
+  if [ ! -e "$target" ]; then
+    cat "$tmp" > "$target"
+    SS_CREATED+=("$target")
+  elif cmp -s "$tmp" "$target"; then
+    SS_UNCHANGED+=("$target")
+  else
+    case "$policy" in
+      overwrite) cat "$tmp" > "$target"; SS_OVERWRITTEN+=("$target") ;;
+      backup) cp -p "$target" "$target.orig"; cat "$tmp" > "$target"; SS_BACKED_UP+=("$target") ;;
+      merge)
+        cp -p "$target" "$tmp.before"
+        if ! stacksprint_merge "$target" "$tmp"; then
+          cat "$tmp.before" > "$target"
+          SS_SKIPPED+=("$target")
+        elif cmp -s "$tmp.before" "$target"; then
+          SS_UNCHANGED+=("$target")
+        else
+          SS_MERGED+=("$target")
+        fi
+        rm -f "$tmp.before" ;;
+      *) SS_SKIPPED+=("$target") ;;
+    esac
+  fi
+  rm -f "$tmp"
+}
+
+# stacksprint_merge PATH GENERATED: merge known formats into PATH in place.
+stacksprint_merge() {
+  local target="$1" generated="$2" line missing added=""
+  case "$(basename "$target")" in
+    .gitignore)
+      while IFS= read -r line || [ -n "$line" ]; do
+        case "$line" in ""|\#*) continue ;; esac
+        grep -qxF -- "$line" "$target" && continue
+        if [ -z "$added" ]; then
+          if [ -s "$target" ] && [ -n "$(tail -c1 "$target")" ]; then echo >> "$target"; fi
+          printf '\n# Added by StackSprint\n' >> "$target"
+          added=1
+        fi
+        printf '%s\n' "$line" >> "$target"
+      done < "$generated"
+      ;;
+    go.mod)
+      missing="$(awk '
+        FNR == 1 { blk = 0 }
+        blk && $1 == ")" { blk = 0; next }
+        $1 == "require" && $2 == "(" { blk = 1; next }
+        {
+          if (blk && NF > 0 && $1 !~ /^\/\//) { mod = $1; line = $0 }
+          else if (!blk && $1 == "require" && NF >= 3) { mod = $2; line = $0; sub(/^[ \t]*require/, "", line) }
+          else next
+          sub(/^[ \t]+/, "", line)
+          if (NR == FNR) have[mod] = 1
+          else if (!(mod in have)) print "\t" line
+        }' "$target" "$generated")"
+      if [ -n "$missing" ]; then printf '\nrequire (\n%s\n)\n' "$missing" >> "$target"; fi
+      ;;
+    package.json)
+      command -v node > /dev/null 2>&1 || return 1
+      node -e '
+        const fs = require("fs");
+        const [target, generated] = process.argv.slice(1);
+        const cur = JSON.parse(fs.readFileSync(target, "utf8"));
+        const gen = JSON.parse(fs.readFileSync(generated, "utf8"));
+        for (const section of ["dependencies", "devDependencies"]) {
+          for (const [name, version] of Object.entries(gen[section] || {})) {
+            cur[section] = cur[section] || {};
+            if (!(name in cur[section])) cur[section][name] = version;
+          }
+        }
+        fs.writeFileSync(target, JSON.stringify(cur, null, 2) + "\n");
+      ' "$target" "$generated"
+      ;;
+    *) return 1 ;;
+  esac
+}
+
+`
+
+const existingRootSummaryBash = `echo "StackSprint summary: ${#SS_CREATED[@]} created, ${#SS_OVERWRITTEN[@]} overwritten, ${#SS_BACKED_UP[@]} backed up to .orig, ${#SS_MERGED[@]} merged, ${#SS_SKIPPED[@]} skipped, ${#SS_UNCHANGED[@]} unchanged"
+for f in ${SS_OVERWRITTEN[@]+"${SS_OVERWRITTEN[@]}"}; do echo "  overwritten: $f"; done
+for f in ${SS_BACKED_UP[@]+"${SS_BACKED_UP[@]}"}; do echo "  backed up:   $f (previous version in $f.orig)"; done
+for f in ${SS_MERGED[@]+"${SS_MERGED[@]}"}; do echo "  merged:      $f"; done
+for f in ${SS_SKIPPED[@]+"${SS_SKIPPED[@]}"}; do echo "  skipped:     $f"; done
+
+`
+
 func ensureGitKeepFiles(tree *FileTree) {
 	for _, d := range dirsSorted(tree.Dirs) {
 		if d == "." || d == "" {
@@ -82,14 +192,22 @@ func ensureGitKeepFiles(tree *FileTree) {
 }
 
 func rootExpressionBash(req GenerateRequest) string {
+	return fmt.Sprintf("%q", rootDirName(req))
+}
+
+// rootDirName is the project root directory: the existing path, or the new project name.
+func rootDirName(req GenerateRequest) string {
 	if strings.ToLower(req.Root.Mode) == "existing" {
-		return fmt.Sprintf("%q", req.Root.Path)
+		return req.Root.Path
 	}
-	return fmt.Sprintf("%q", req.Root.Name)
+	return req.Root.Name
 }
 
 func languageInitBash(req GenerateRequest) string {
-	gen := GetGenerator(req.Language)
+	gen, err := GetGenerator(req.Language)
+	if err != nil {
+		return ""
+	}
 	return gen.GetInitCommand(&req)
 }
 
@@ -156,8 +274,9 @@ func buildGenerationWarnings(req GenerateRequest) []Warning {
 
 	// Delegate language/framework-specific warnings to the generator — keeps
 	// req.Language and req.Framework checks OUT of this shared pipeline file.
-	gen := GetGenerator(req.Language)
-	warnings = append(warnings, gen.GetConfigWarnings(&req)...)
+	if gen, err := GetGenerator(req.Language); err == nil {
+		warnings = append(warnings, gen.GetConfigWarnings(&req)...)
+	}
 
 	return warnings
 }
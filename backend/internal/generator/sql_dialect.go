@@ -0,0 +1,291 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sqlDialect spells what differs between the SQL databases in the DDL and
+// seed rows renderSQLTablesTemplate writes: identifier quoting, the id
+// column, integer, boolean and timestamp types, and how updated_at is kept
+// current.
+type sqlDialect struct {
+	quoteChar string // wraps identifiers
+	identity  string // type and constraints of the id column
+	intType   string // foreign keys and int fields
+	boolType  string
+	boolTrue  string
+	boolFalse string
+	timeType  string
+	// onUpdate is appended to updated_at when the database can refresh it
+	// itself; the others get a trigger, which calls triggerFunction if set.
+	onUpdate        string
+	triggerFunction string
+	// indexIfNotExists is false for MySQL, which has no CREATE INDEX IF NOT
+	// EXISTS; its init script only runs against an empty data directory.
+	indexIfNotExists bool
+}
+
+func newSQLDialect(db string) sqlDialect {
+	switch db {
+	case "mysql":
+		return sqlDialect{
+			quoteChar: "`",
+			identity:  "INT AUTO_INCREMENT PRIMARY KEY",
+			intType:   "INT",
+			boolType:  "TINYINT(1)",
+			boolTrue:  "1",
+			boolFalse: "0",
+			timeType:  "DATETIME",
+			onUpdate:  " ON UPDATE CURRENT_TIMESTAMP",
+		}
+	case "sqlite":
+		// SQLite only assigns ids to an INTEGER PRIMARY KEY, which aliases
+		// the rowid; AUTOINCREMENT keeps ids of deleted rows from being
+		// reused. BOOLEAN and TIMESTAMP are kept as declared types so the
+		// drivers convert the values they read.
+		return sqlDialect{
+			quoteChar:        `"`,
+			identity:         "INTEGER PRIMARY KEY AUTOINCREMENT",
+			intType:          "INTEGER",
+			boolType:         "BOOLEAN",
+			boolTrue:         "1",
+			boolFalse:        "0",
+			timeType:         "TIMESTAMP",
+			indexIfNotExists: true,
+		}
+	default:
+		return sqlDialect{
+			quoteChar:        `"`,
+			identity:         "INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY",
+			intType:          "INTEGER",
+			boolType:         "BOOLEAN",
+			boolTrue:         "TRUE",
+			boolFalse:        "FALSE",
+			timeType:         "TIMESTAMPTZ",
+			triggerFunction:  "CREATE OR REPLACE FUNCTION set_updated_at() RETURNS trigger AS $$\nBEGIN\n  NEW.updated_at = CURRENT_TIMESTAMP;\n  RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql;\n",
+			indexIfNotExists: true,
+		}
+	}
+}
+
+func (d sqlDialect) quote(ident string) string {
+	return d.quoteChar + strings.ReplaceAll(ident, d.quoteChar, d.quoteChar+d.quoteChar) + d.quoteChar
+}
+
+func (d sqlDialect) columnType(f DataField) string {
+	switch {
+	case isStringField(f):
+		return fmt.Sprintf("VARCHAR(%d)", maxLength(f))
+	case f.Type == "int" || f.Type == "integer":
+		return d.intType
+	case f.Type == "bool" || f.Type == "boolean":
+		return d.boolType
+	case isDateTimeField(f):
+		return d.timeType
+	}
+	return sqlTypeFromField(f.Type)
+}
+
+// columnDefinition declares col; models resolve the table a foreign key
+// references.
+func (d sqlDialect) columnDefinition(col modelColumn, models []DataModel) string {
+	def := d.quote(col.Name) + " " + d.columnType(col.DataField)
+	if col.Required {
+		def += " NOT NULL"
+	}
+	if col.Unique {
+		def += " UNIQUE"
+	}
+	if col.Default != "" {
+		def += " DEFAULT " + d.defaultValue(col.DataField)
+	}
+	if len(col.Enum) > 0 {
+		def += fmt.Sprintf(" CHECK (%s IN (%s))", d.quote(col.Name), sqlStringList(col.Enum))
+	}
+	if col.References != "" {
+		def += " " + d.references(modelTable(findModel(models, col.References)))
+	}
+	return def
+}
+
+func (d sqlDialect) references(table string) string {
+	return fmt.Sprintf("REFERENCES %s (%s)", d.quote(table), d.quote("id"))
+}
+
+func (d sqlDialect) defaultValue(f DataField) string {
+	switch {
+	case isDateTimeField(f):
+		return "CURRENT_TIMESTAMP"
+	case f.Type == "bool" || f.Type == "boolean":
+		b, _ := strconv.ParseBool(f.Default)
+		return d.boolLiteral(b)
+	case isStringField(f):
+		return sqlStringList([]string{f.Default})
+	}
+	return f.Default
+}
+
+func (d sqlDialect) boolLiteral(b bool) string {
+	if b {
+		return d.boolTrue
+	}
+	return d.boolFalse
+}
+
+func (d sqlDialect) updatedAtColumn() string {
+	return d.quote("updated_at") + " " + d.timeType + " NOT NULL DEFAULT CURRENT_TIMESTAMP" + d.onUpdate
+}
+
+// updatedAtTrigger keeps table's updated_at current where the column cannot
+// do it itself. SQLite's AFTER UPDATE trigger does not fire again for its own
+// UPDATE, since recursive triggers are off by default.
+func (d sqlDialect) updatedAtTrigger(table string) string {
+	switch {
+	case d.onUpdate != "":
+		return ""
+	case d.triggerFunction != "":
+		return fmt.Sprintf("CREATE OR REPLACE TRIGGER %s BEFORE UPDATE ON %s FOR EACH ROW EXECUTE FUNCTION set_updated_at();",
+			d.quote(table+"_set_updated_at"), d.quote(table))
+	}
+	return fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s AFTER UPDATE ON %s FOR EACH ROW\nBEGIN\n  UPDATE %s SET %s = CURRENT_TIMESTAMP WHERE %s = OLD.%s;\nEND;",
+		d.quote(table+"_set_updated_at"), d.quote(table), d.quote(table), d.quote("updated_at"), d.quote("id"), d.quote("id"))
+}
+
+func (d sqlDialect) createIndex(table string, idx ModelIndex) string {
+	create := "CREATE INDEX "
+	if idx.Unique {
+		create = "CREATE UNIQUE INDEX "
+	}
+	if d.indexIfNotExists {
+		create += "IF NOT EXISTS "
+	}
+	cols := indexColumns(idx)
+	for i, c := range cols {
+		cols[i] = d.quote(c)
+	}
+	return fmt.Sprintf("%s%s ON %s (%s);", create, d.quote(indexName(table, idx)), d.quote(table), strings.Join(cols, ", "))
+}
+
+func (d sqlDialect) insert(table string, cols, vals []string) string {
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);", d.quote(table), strings.Join(cols, ", "), strings.Join(vals, ", "))
+}
+
+// sqlSampleTimestamp is the value seeded into datetime columns; every
+// dialect reads it as a timestamp.
+const sqlSampleTimestamp = "'2024-01-15 09:30:00'"
+
+// sampleValue is the seed value for a column: its default when it declares
+// one, otherwise a plausible value for its name and type. Foreign keys point
+// at the first seeded row of the parent table, which is created first.
+func (d sqlDialect) sampleValue(col modelColumn) string {
+	switch {
+	case col.References != "":
+		return "1"
+	case len(col.Enum) > 0:
+		if col.Default != "" {
+			return sqlStringList([]string{col.Default})
+		}
+		return sqlStringList(col.Enum[:1])
+	case isDateTimeField(col.DataField):
+		return sqlSampleTimestamp
+	case col.Default != "":
+		return d.defaultValue(col.DataField)
+	case col.Type == "bool" || col.Type == "boolean":
+		return d.boolTrue
+	case col.Type == "int" || col.Type == "integer":
+		return sampleInt(col.Name)
+	case !isStringField(col.DataField):
+		return sampleFloat(col.Name)
+	}
+	s := sampleString(col.Name)
+	if n := maxLength(col.DataField); len(s) > n {
+		s = s[:n]
+	}
+	return sqlStringList([]string{s})
+}
+
+func sampleInt(name string) string {
+	switch {
+	case nameHas(name, "age"):
+		return "34"
+	case nameHas(name, "year"):
+		return "2024"
+	case nameHas(name, "price", "amount", "cents"):
+		return "1999"
+	case nameHas(name, "quantity", "qty", "stock", "count"):
+		return "12"
+	case nameHas(name, "rating", "score"):
+		return "4"
+	case nameHas(name, "views"):
+		return "128"
+	}
+	return "1"
+}
+
+func sampleFloat(name string) string {
+	switch {
+	case nameHas(name, "price", "amount", "cost", "total", "balance"):
+		return "19.99"
+	case nameHas(name, "rating", "score"):
+		return "4.5"
+	case nameHas(name, "lat", "latitude"):
+		return "51.5074"
+	case nameHas(name, "lng", "lon", "longitude"):
+		return "-0.1278"
+	}
+	return "1.5"
+}
+
+func sampleString(name string) string {
+	switch {
+	case nameHas(name, "email"):
+		return "ada@example.com"
+	case nameHas(name, "url", "website", "link", "homepage"):
+		return "https://example.com"
+	case nameHas(name, "phone", "mobile"):
+		return "+44 20 7946 0958"
+	case strings.EqualFold(name, "first_name"):
+		return "Ada"
+	case strings.EqualFold(name, "last_name"):
+		return "Lovelace"
+	case nameHas(name, "username", "handle"):
+		return "ada"
+	case nameHas(name, "name"):
+		return "Ada Lovelace"
+	case nameHas(name, "title", "subject", "headline"):
+		return "Notes on the Analytical Engine"
+	case nameHas(name, "slug"):
+		return "notes-on-the-analytical-engine"
+	case nameHas(name, "description", "body", "content", "summary", "bio", "text", "note", "notes"):
+		return "The engine might compose elaborate pieces of music."
+	case nameHas(name, "label", "tag", "category"):
+		return "featured"
+	case nameHas(name, "city"):
+		return "London"
+	case nameHas(name, "country"):
+		return "GB"
+	case nameHas(name, "address", "street"):
+		return "12 St James's Square"
+	case nameHas(name, "color", "colour"):
+		return "#3366ff"
+	case nameHas(name, "status", "state"):
+		return "active"
+	case nameHas(name, "currency"):
+		return "GBP"
+	}
+	return strings.ReplaceAll(strings.ToLower(name), "_", " ") + " 1"
+}
+
+// nameHas reports whether the snake_case field name contains one of words.
+func nameHas(name string, words ...string) bool {
+	for _, part := range strings.Split(strings.ToLower(name), "_") {
+		for _, w := range words {
+			if part == w {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSQLDialectDDL(t *testing.T) {
+	models := richModels()
+
+	pg := renderSQLTablesTemplate("postgresql", models, true)
+	assertContainsAll(t, "postgres ddl", pg,
+		`"id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY`,
+		`"created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP`,
+		"CREATE OR REPLACE FUNCTION set_updated_at() RETURNS trigger AS $$",
+		`CREATE OR REPLACE TRIGGER "posts_set_updated_at" BEFORE UPDATE ON "posts" FOR EACH ROW EXECUTE FUNCTION set_updated_at();`,
+	)
+	if strings.Count(pg, "CREATE OR REPLACE FUNCTION") != 1 {
+		t.Errorf("trigger function should be created once:\n%s", pg)
+	}
+
+	mysql := renderSQLTablesTemplate("mysql", models, true)
+	assertContainsAll(t, "mysql ddl", mysql,
+		"CREATE TABLE IF NOT EXISTS `posts` (",
+		"`id` INT AUTO_INCREMENT PRIMARY KEY",
+		"`user_id` INT NOT NULL REFERENCES `users` (`id`)",
+		"`updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP",
+		"CREATE INDEX `idx_posts_status_user_id` ON `posts` (`status`, `user_id`);",
+	)
+	if strings.Contains(mysql, "TRIGGER") || strings.Contains(mysql, `"`) {
+		t.Errorf("mysql ddl should use ON UPDATE and backticks:\n%s", mysql)
+	}
+
+	for db, ddl := range map[string]string{"postgresql": pg, "mysql": mysql, "sqlite": renderSQLTablesTemplate("sqlite", models, true)} {
+		if strings.Contains(ddl, "SERIAL") || strings.Contains(ddl, "DEFAULT VALUES") {
+			t.Errorf("%s ddl:\n%s", db, ddl)
+		}
+	}
+}
+
+func TestSQLDialectSeedValues(t *testing.T) {
+	models := []DataModel{{Name: "Customer", Fields: []DataField{
+		{Name: "email", Type: "string", Required: true},
+		{Name: "full_name", Type: "string", MaxLength: 5},
+		{Name: "note", Type: "string", Default: "it's new"},
+		{Name: "age", Type: "int"},
+		{Name: "balance", Type: "float"},
+		{Name: "active", Type: "bool"},
+		{Name: "tier", Type: "string", Enum: []string{"free", "pro"}},
+	}}}
+	ddl := renderSQLTablesTemplate("mysql", models, true)
+	assertContainsAll(t, "mysql seed", ddl,
+		"INSERT INTO `customers` (`email`, `full_name`, `note`, `age`, `balance`, `active`, `tier`) VALUES ('ada@example.com', 'Ada L', 'it''s new', 34, 19.99, 1, 'free');",
+	)
+	if seedless := renderSQLTablesTemplate("mysql", models, false); strings.Contains(seedless, "INSERT INTO") {
+		t.Errorf("seed rows rendered without withSeed:\n%s", seedless)
+	}
+	joins := renderSQLTablesTemplate("postgresql", richModels(), true)
+	assertContainsAll(t, "postgres seed", joins, `INSERT INTO "posts_tags" ("post_id", "tag_id") VALUES (1, 1);`)
+}
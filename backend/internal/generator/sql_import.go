@@ -0,0 +1,1019 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ImportSQLModels turns PostgreSQL or MySQL CREATE TABLE (and CREATE INDEX)
+// statements into data models: column types, NOT NULL, UNIQUE, defaults,
+// enums (ENUM columns or CHECK ... IN lists), indexes and foreign keys. A
+// foreign key named after its target (user_id -> users) becomes a belongs_to
+// relation and a table holding only two such keys becomes a many_to_many.
+// Anything it cannot use is reported as a warning naming the line.
+func ImportSQLModels(ddl string) ModelImport {
+	imp := &sqlImporter{tables: map[string]*sqlTable{}}
+	for _, stmt := range splitSQLStatements(tokenizeSQL(ddl)) {
+		imp.statement(stmt)
+	}
+	models := imp.models()
+	if models == nil {
+		models = []DataModel{}
+	}
+	if imp.warnings == nil {
+		imp.warnings = []Warning{}
+	}
+	return ModelImport{Models: models, Warnings: imp.warnings}
+}
+
+// -------------------------------------------------------------------------
+// Tokenizer
+// -------------------------------------------------------------------------
+
+type sqlTokenKind int
+
+const (
+	sqlWord   sqlTokenKind = iota // keyword or bare identifier
+	sqlIdent                      // "quoted" or `quoted` identifier
+	sqlString                     // 'literal' or $$literal$$
+	sqlNumber
+	sqlPunct
+)
+
+type sqlToken struct {
+	Kind sqlTokenKind
+	Text string
+	Line int
+}
+
+func (t sqlToken) is(word string) bool {
+	return t.Kind == sqlWord && strings.EqualFold(t.Text, word)
+}
+
+func (t sqlToken) isPunct(p string) bool {
+	return t.Kind == sqlPunct && t.Text == p
+}
+
+func (t sqlToken) isName() bool {
+	return t.Kind == sqlWord || t.Kind == sqlIdent
+}
+
+// tokenizeSQL splits DDL into tokens, dropping comments. Quotes that never
+// close swallow the rest of the input, so the statement fails to parse and is
+// reported rather than silently misread.
+func tokenizeSQL(src string) []sqlToken {
+	var toks []sqlToken
+	rs := []rune(src)
+	line := 1
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		start := line
+		switch {
+		case r == '\n':
+			line++
+			i++
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(rs) && rs[i+1] == '-', r == '#':
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			i += 2
+			for i < len(rs) && !(rs[i] == '*' && i+1 < len(rs) && rs[i+1] == '/') {
+				if rs[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			i += 2
+		case r == '\'' || r == '"' || r == '`':
+			var b strings.Builder
+			i++
+			for i < len(rs) {
+				if rs[i] == r {
+					if i+1 < len(rs) && rs[i+1] == r {
+						b.WriteRune(r)
+						i += 2
+						continue
+					}
+					break
+				}
+				if r == '\'' && rs[i] == '\\' && i+1 < len(rs) {
+					i++
+				}
+				if rs[i] == '\n' {
+					line++
+				}
+				b.WriteRune(rs[i])
+				i++
+			}
+			i++
+			kind := sqlIdent
+			if r == '\'' {
+				kind = sqlString
+			}
+			toks = append(toks, sqlToken{Kind: kind, Text: b.String(), Line: start})
+		case r == '$' && dollarTag(rs, i) != "":
+			tag := dollarTag(rs, i)
+			i += len([]rune(tag))
+			end := strings.Index(string(rs[i:]), tag)
+			body := string(rs[i:])
+			if end >= 0 {
+				body = body[:end]
+			}
+			line += strings.Count(body, "\n")
+			i += len([]rune(body)) + len([]rune(tag))
+			toks = append(toks, sqlToken{Kind: sqlString, Text: body, Line: start})
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(rs) && (unicode.IsDigit(rs[j]) || rs[j] == '.') {
+				j++
+			}
+			toks = append(toks, sqlToken{Kind: sqlNumber, Text: string(rs[i:j]), Line: start})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || rs[j] == '_' || rs[j] == '$') {
+				j++
+			}
+			toks = append(toks, sqlToken{Kind: sqlWord, Text: string(rs[i:j]), Line: start})
+			i = j
+		default:
+			toks = append(toks, sqlToken{Kind: sqlPunct, Text: string(r), Line: start})
+			i++
+		}
+	}
+	return toks
+}
+
+// dollarTag returns the PostgreSQL dollar-quote opener ($$ or $tag$) at i.
+func dollarTag(rs []rune, i int) string {
+	for j := i + 1; j < len(rs); j++ {
+		if rs[j] == '$' {
+			return string(rs[i : j+1])
+		}
+		if !unicode.IsLetter(rs[j]) && rs[j] != '_' {
+			return ""
+		}
+	}
+	return ""
+}
+
+func splitSQLStatements(toks []sqlToken) [][]sqlToken {
+	var out [][]sqlToken
+	start := 0
+	for i, t := range toks {
+		if t.isPunct(";") {
+			if i > start {
+				out = append(out, toks[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(toks) {
+		out = append(out, toks[start:])
+	}
+	return out
+}
+
+// sqlParser walks the tokens of one statement (or one part of it).
+type sqlParser struct {
+	toks []sqlToken
+	pos  int
+}
+
+func (p *sqlParser) done() bool { return p.pos >= len(p.toks) }
+
+func (p *sqlParser) peek() sqlToken {
+	if p.done() {
+		return sqlToken{Kind: sqlPunct}
+	}
+	return p.toks[p.pos]
+}
+
+func (p *sqlParser) next() sqlToken {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+// keyword consumes the given words if they come next, in order.
+func (p *sqlParser) keyword(words ...string) bool {
+	for i, w := range words {
+		if p.pos+i >= len(p.toks) || !p.toks[p.pos+i].is(w) {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *sqlParser) punct(s string) bool {
+	if p.peek().isPunct(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// group consumes a parenthesised group and returns the tokens inside it.
+func (p *sqlParser) group() ([]sqlToken, error) {
+	if !p.punct("(") {
+		return nil, fmt.Errorf("expected ( near %q", p.peek().Text)
+	}
+	start, depth := p.pos, 1
+	for !p.done() {
+		t := p.next()
+		switch {
+		case t.isPunct("("):
+			depth++
+		case t.isPunct(")"):
+			depth--
+			if depth == 0 {
+				return p.toks[start : p.pos-1], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unbalanced parentheses")
+}
+
+// skip consumes one token, or a whole group when it opens one.
+func (p *sqlParser) skip() {
+	if p.peek().isPunct("(") {
+		_, _ = p.group()
+		return
+	}
+	p.next()
+}
+
+// name reads a possibly schema-qualified name and returns its last part.
+func (p *sqlParser) name() (string, error) {
+	t := p.next()
+	if !t.isName() {
+		return "", fmt.Errorf("expected a name near %q", t.Text)
+	}
+	name := t.Text
+	for p.peek().isPunct(".") {
+		p.next()
+		if t = p.next(); !t.isName() {
+			return "", fmt.Errorf("expected a name after '.'")
+		}
+		name = t.Text
+	}
+	return strings.ToLower(name), nil
+}
+
+// splitTopLevel splits tokens on commas outside parentheses.
+func splitTopLevel(toks []sqlToken) [][]sqlToken {
+	var out [][]sqlToken
+	start, depth := 0, 0
+	for i, t := range toks {
+		switch {
+		case t.isPunct("("):
+			depth++
+		case t.isPunct(")"):
+			depth--
+		case t.isPunct(",") && depth == 0:
+			out = append(out, toks[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(toks) {
+		out = append(out, toks[start:])
+	}
+	return out
+}
+
+// columnList reads "(a, b DESC, c(10))". Expressions are rejected.
+func (p *sqlParser) columnList() ([]string, error) {
+	inner, err := p.group()
+	if err != nil {
+		return nil, err
+	}
+	var cols []string
+	for _, part := range splitTopLevel(inner) {
+		if len(part) == 0 || !part[0].isName() {
+			return nil, fmt.Errorf("unsupported column list")
+		}
+		if len(part) > 1 && part[1].isPunct("(") && (len(part) < 3 || part[2].Kind != sqlNumber) {
+			return nil, fmt.Errorf("expression %s(...) is not a plain column", part[0].Text)
+		}
+		cols = append(cols, strings.ToLower(part[0].Text))
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("empty column list")
+	}
+	return cols, nil
+}
+
+// -------------------------------------------------------------------------
+// Statements
+// -------------------------------------------------------------------------
+
+type sqlTable struct {
+	Name    string
+	Line    int
+	Columns []*sqlColumn
+	Indexes []sqlIndex
+}
+
+type sqlColumn struct {
+	DataField
+	Line       int
+	PrimaryKey bool
+	RefTable   string
+}
+
+type sqlIndex struct {
+	ModelIndex
+	Line int
+}
+
+func (t *sqlTable) column(name string) *sqlColumn {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+type sqlImporter struct {
+	tables   map[string]*sqlTable
+	order    []string
+	indexes  map[string][]sqlIndex // CREATE INDEX statements, by table
+	warnings []Warning
+}
+
+func (imp *sqlImporter) warn(code, severity string, line int, reason, format string, args ...any) {
+	imp.warnings = append(imp.warnings, Warning{
+		Code:     code,
+		Severity: severity,
+		Message:  fmt.Sprintf("line %d: ", line) + fmt.Sprintf(format, args...),
+		Reason:   reason,
+	})
+}
+
+func (imp *sqlImporter) statement(toks []sqlToken) {
+	line := toks[0].Line
+	p := &sqlParser{toks: toks}
+	if p.keyword("CREATE") {
+		p.keyword("OR", "REPLACE")
+		for p.keyword("TEMPORARY") || p.keyword("TEMP") || p.keyword("UNLOGGED") {
+		}
+		if p.keyword("TABLE") {
+			if err := imp.createTable(p, line); err != nil {
+				imp.warn("SQL_STATEMENT_UNPARSED", "warn", line, "The table was not imported.", "could not parse CREATE TABLE: %v", err)
+			}
+			return
+		}
+		unique := p.keyword("UNIQUE")
+		if p.keyword("INDEX") {
+			if err := imp.createIndex(p, line, unique); err != nil {
+				imp.warn("SQL_STATEMENT_UNPARSED", "warn", line, "The index was not imported.", "could not parse CREATE INDEX: %v", err)
+			}
+			return
+		}
+	}
+	words := make([]string, 0, 2)
+	for _, t := range toks {
+		if len(words) == 2 || !t.isName() {
+			break
+		}
+		words = append(words, strings.ToUpper(t.Text))
+	}
+	imp.warn("SQL_STATEMENT_SKIPPED", "info", line, "Only CREATE TABLE and CREATE INDEX statements describe models.", "skipped %s statement", strings.Join(words, " "))
+}
+
+func (imp *sqlImporter) createTable(p *sqlParser, line int) error {
+	p.keyword("IF", "NOT", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if p.peek().is("AS") || p.peek().is("LIKE") {
+		return fmt.Errorf("CREATE TABLE ... %s is not supported", strings.ToUpper(p.peek().Text))
+	}
+	body, err := p.group()
+	if err != nil {
+		return err
+	}
+	if _, dup := imp.tables[name]; dup {
+		return fmt.Errorf("table %s is defined twice", name)
+	}
+	table := &sqlTable{Name: name, Line: line}
+	checks := map[string][]string{}
+	for _, item := range splitTopLevel(body) {
+		if len(item) == 0 {
+			continue
+		}
+		if err := imp.tableItem(table, item, checks); err != nil {
+			imp.warn("SQL_DEFINITION_UNPARSED", "warn", item[0].Line, "The definition was left out of the model.", "table %s: could not parse %q: %v", name, tokensText(item), err)
+		}
+	}
+	for col, values := range checks {
+		if c := table.column(col); c != nil && isStringField(c.DataField) {
+			c.Enum = values
+		}
+	}
+	imp.tables[name] = table
+	imp.order = append(imp.order, name)
+	return nil
+}
+
+// tableItem parses one comma-separated entry of a CREATE TABLE body.
+func (imp *sqlImporter) tableItem(table *sqlTable, item []sqlToken, checks map[string][]string) error {
+	p := &sqlParser{toks: item}
+	line := item[0].Line
+	if p.keyword("CONSTRAINT") {
+		p.next()
+	}
+	switch {
+	case p.keyword("PRIMARY", "KEY"):
+		cols, err := p.columnList()
+		if err != nil {
+			return err
+		}
+		if len(cols) == 1 {
+			if c := table.column(cols[0]); c != nil {
+				c.PrimaryKey = true
+				return nil
+			}
+		}
+		table.Indexes = append(table.Indexes, sqlIndex{ModelIndex{Fields: cols, Unique: true}, line})
+		return nil
+	case p.keyword("UNIQUE"):
+		return indexItem(table, p, line, true)
+	case p.keyword("KEY"), p.keyword("INDEX"):
+		return indexItem(table, p, line, false)
+	case p.keyword("FOREIGN", "KEY"):
+		if !p.peek().isPunct("(") {
+			p.next()
+		}
+		cols, err := p.columnList()
+		if err != nil {
+			return err
+		}
+		if !p.keyword("REFERENCES") {
+			return fmt.Errorf("expected REFERENCES")
+		}
+		ref, err := p.name()
+		if err != nil {
+			return err
+		}
+		if len(cols) != 1 {
+			return fmt.Errorf("composite foreign keys are not supported")
+		}
+		c := table.column(cols[0])
+		if c == nil {
+			return fmt.Errorf("unknown column %s", cols[0])
+		}
+		c.RefTable = ref
+		return nil
+	case p.keyword("CHECK"):
+		inner, err := p.group()
+		if err != nil {
+			return err
+		}
+		if col, values, ok := checkEnum(inner); ok {
+			checks[col] = values
+			return nil
+		}
+		return fmt.Errorf("only CHECK (column IN (...)) constraints are imported")
+	case p.peek().is("FULLTEXT"), p.peek().is("SPATIAL"), p.peek().is("EXCLUDE"):
+		return fmt.Errorf("%s constraints are not supported", strings.ToUpper(p.peek().Text))
+	}
+	col, err := parseSQLColumn(p, checks)
+	if err != nil {
+		return err
+	}
+	col.Line = line
+	table.Columns = append(table.Columns, &col.sqlColumn)
+	for _, w := range col.warnings {
+		imp.warn(w.code, "warn", line, w.reason, "%s.%s: %s", table.Name, col.Name, w.message)
+	}
+	return nil
+}
+
+// indexItem parses the rest of a UNIQUE / KEY / INDEX table entry.
+func indexItem(table *sqlTable, p *sqlParser, line int, unique bool) error {
+	if unique {
+		_ = p.keyword("KEY") || p.keyword("INDEX")
+	}
+	if !p.peek().isPunct("(") && !p.peek().is("USING") {
+		p.next()
+	}
+	if p.keyword("USING") {
+		p.next()
+	}
+	cols, err := p.columnList()
+	if err != nil {
+		return err
+	}
+	if unique && len(cols) == 1 {
+		if c := table.column(cols[0]); c != nil {
+			c.Unique = true
+			return nil
+		}
+	}
+	table.Indexes = append(table.Indexes, sqlIndex{ModelIndex{Fields: cols, Unique: unique}, line})
+	return nil
+}
+
+func (imp *sqlImporter) createIndex(p *sqlParser, line int, unique bool) error {
+	p.keyword("CONCURRENTLY")
+	p.keyword("IF", "NOT", "EXISTS")
+	if !p.peek().is("ON") {
+		if _, err := p.name(); err != nil {
+			return err
+		}
+	}
+	if !p.keyword("ON") {
+		return fmt.Errorf("expected ON")
+	}
+	p.keyword("ONLY")
+	table, err := p.name()
+	if err != nil {
+		return err
+	}
+	if p.keyword("USING") {
+		p.next()
+	}
+	cols, err := p.columnList()
+	if err != nil {
+		return err
+	}
+	if p.keyword("WHERE") {
+		return fmt.Errorf("partial indexes are not supported")
+	}
+	if imp.indexes == nil {
+		imp.indexes = map[string][]sqlIndex{}
+	}
+	imp.indexes[table] = append(imp.indexes[table], sqlIndex{ModelIndex{Fields: cols, Unique: unique}, line})
+	return nil
+}
+
+// -------------------------------------------------------------------------
+// Columns
+// -------------------------------------------------------------------------
+
+type columnWarning struct {
+	code, message, reason string
+}
+
+type parsedColumn struct {
+	sqlColumn
+	warnings []columnWarning
+}
+
+// columnStopWords end a column's type and start its constraints.
+var columnStopWords = map[string]bool{
+	"NOT": true, "NULL": true, "DEFAULT": true, "PRIMARY": true, "UNIQUE": true,
+	"REFERENCES": true, "CHECK": true, "CONSTRAINT": true, "AUTO_INCREMENT": true,
+	"AUTOINCREMENT": true, "GENERATED": true, "COLLATE": true, "COMMENT": true,
+	"ON": true, "CHARSET": true, "IDENTITY": true, "KEY": true,
+}
+
+func parseSQLColumn(p *sqlParser, checks map[string][]string) (*parsedColumn, error) {
+	nameTok := p.next()
+	if !nameTok.isName() {
+		return nil, fmt.Errorf("expected a column name")
+	}
+	col := &parsedColumn{}
+	col.Name = strings.ToLower(nameTok.Text)
+
+	var words, args []string
+	for !p.done() {
+		t := p.peek()
+		if t.Kind != sqlWord || columnStopWords[strings.ToUpper(t.Text)] {
+			if t.isPunct("[") {
+				for !p.done() && !p.next().isPunct("]") {
+				}
+				words = append(words, "[]")
+				continue
+			}
+			break
+		}
+		if t.is("CHARACTER") && len(words) > 0 {
+			break // CHARACTER SET
+		}
+		words = append(words, strings.ToLower(p.next().Text))
+		if p.peek().isPunct("(") {
+			inner, err := p.group()
+			if err != nil {
+				return nil, err
+			}
+			for _, a := range splitTopLevel(inner) {
+				args = append(args, tokensText(a))
+			}
+		}
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("column %s has no type", col.Name)
+	}
+	typ, ok := importColumnType(words, args, &col.DataField)
+	col.Type = typ
+	if !ok {
+		col.warnings = append(col.warnings, columnWarning{"SQL_TYPE_UNSUPPORTED", fmt.Sprintf("type %s imported as string", strings.Join(words, " ")), "Only string, int, float, bool, datetime and enum fields are modelled."})
+	}
+
+	var rawDefault string
+	for !p.done() {
+		switch {
+		case p.keyword("NOT", "NULL"):
+			col.Required = true
+		case p.keyword("NULL"):
+		case p.keyword("PRIMARY", "KEY"):
+			col.PrimaryKey = true
+		case p.keyword("UNIQUE"):
+			p.keyword("KEY")
+			col.Unique = true
+		case p.keyword("DEFAULT"):
+			start := p.pos
+			def, ok := parseSQLDefault(p)
+			rawDefault = tokensText(p.toks[start:p.pos])
+			if ok {
+				col.Default = def
+			} else {
+				col.warnings = append(col.warnings, columnWarning{"SQL_DEFAULT_UNSUPPORTED", fmt.Sprintf("default %s dropped", rawDefault), "Only literal defaults and the current timestamp are modelled."})
+			}
+		case p.keyword("REFERENCES"):
+			ref, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			col.RefTable = ref
+			if p.peek().isPunct("(") {
+				p.skip()
+			}
+		case p.keyword("CHECK"):
+			inner, err := p.group()
+			if err != nil {
+				return nil, err
+			}
+			if _, values, ok := checkEnum(inner); ok {
+				checks[col.Name] = values
+			} else {
+				col.warnings = append(col.warnings, columnWarning{"SQL_CHECK_UNSUPPORTED", "CHECK constraint dropped", "Only CHECK (column IN (...)) constraints are modelled."})
+			}
+		case p.keyword("ON"):
+			// ON DELETE / ON UPDATE actions.
+			p.next()
+			switch {
+			case p.keyword("NO", "ACTION"), p.keyword("SET", "NULL"), p.keyword("SET", "DEFAULT"):
+			default:
+				p.next()
+				if p.peek().isPunct("(") {
+					p.skip()
+				}
+			}
+		case p.keyword("CONSTRAINT"), p.keyword("COLLATE"), p.keyword("COMMENT"), p.keyword("CHARSET"), p.keyword("CHARACTER", "SET"):
+			p.next()
+		default:
+			// AUTO_INCREMENT, GENERATED ... AS IDENTITY and similar modifiers
+			// do not change the model.
+			p.skip()
+		}
+	}
+
+	if col.Default != "" {
+		if col.Type == "bool" {
+			switch col.Default {
+			case "1":
+				col.Default = "true"
+			case "0":
+				col.Default = "false"
+			}
+		}
+		if err := checkDefault(col.DataField, col.Default); err != nil {
+			col.warnings = append(col.warnings, columnWarning{"SQL_DEFAULT_UNSUPPORTED", fmt.Sprintf("default %s dropped: %v", rawDefault, err), "The default does not fit the imported field type."})
+			col.Default = ""
+		}
+	}
+	return col, nil
+}
+
+// importColumnType maps a SQL type to a field type, filling max_length and
+// enum values on f. ok is false when the type fell back to string.
+func importColumnType(words, args []string, f *DataField) (string, bool) {
+	if len(words) > 1 && words[len(words)-1] == "[]" {
+		return "string", false
+	}
+	switch words[0] {
+	case "tinyint":
+		if len(args) == 1 && args[0] == "1" {
+			return "bool", true
+		}
+		return "int", true
+	case "int", "integer", "smallint", "mediumint", "bigint", "int2", "int4", "int8",
+		"serial", "smallserial", "bigserial", "serial4", "serial8", "year":
+		return "int", true
+	case "decimal", "numeric", "real", "float", "float4", "float8", "double", "money", "dec", "fixed":
+		return "float", true
+	case "bool", "boolean":
+		return "bool", true
+	case "bit":
+		if len(args) == 0 || args[0] == "1" {
+			return "bool", true
+		}
+		return "string", false
+	case "timestamp", "timestamptz", "datetime", "date", "time", "timetz":
+		return "datetime", true
+	case "varchar", "character", "char", "nvarchar", "nchar", "varchar2":
+		if len(args) == 1 {
+			var n int
+			if _, err := fmt.Sscanf(args[0], "%d", &n); err == nil && n > 0 {
+				f.MaxLength = n
+			}
+		}
+		return "string", true
+	case "text", "tinytext", "mediumtext", "longtext", "citext", "uuid", "json", "jsonb":
+		return "string", true
+	case "enum", "set":
+		if words[0] == "enum" && len(args) > 0 {
+			values := make([]string, 0, len(args))
+			for _, a := range args {
+				values = append(values, strings.Trim(a, "'"))
+			}
+			f.Enum = values
+			return "enum", true
+		}
+	}
+	return "string", false
+}
+
+// parseSQLDefault reads a DEFAULT expression. It returns the literal and true
+// for supported defaults, "" and true for DEFAULT NULL, and the expression
+// text and false otherwise.
+func parseSQLDefault(p *sqlParser) (string, bool) {
+	if p.peek().isPunct("(") {
+		inner, _ := p.group()
+		return parseSQLDefault(&sqlParser{toks: inner})
+	}
+	t := p.next()
+	var value string
+	switch {
+	case t.Kind == sqlString:
+		value = t.Text
+	case t.Kind == sqlNumber:
+		value = t.Text
+	case t.isPunct("-") && p.peek().Kind == sqlNumber:
+		value = "-" + p.next().Text
+	case t.is("TRUE"), t.is("FALSE"):
+		value = strings.ToLower(t.Text)
+	case t.is("NULL"):
+		return "", true
+	case t.is("CURRENT_TIMESTAMP"), t.is("NOW"), t.is("LOCALTIMESTAMP"), t.is("CURRENT_DATE"), t.is("CURRENT_TIME"):
+		value = "now"
+		if p.peek().isPunct("(") {
+			p.skip()
+		}
+	default:
+		if p.peek().isPunct("(") {
+			p.skip()
+		}
+		return t.Text, false
+	}
+	// PostgreSQL casts: 'draft'::character varying
+	for p.peek().isPunct(":") {
+		p.next()
+		p.punct(":")
+		for p.peek().Kind == sqlWord && !columnStopWords[strings.ToUpper(p.peek().Text)] {
+			p.next()
+			if p.peek().isPunct("(") {
+				p.skip()
+			}
+		}
+	}
+	return value, true
+}
+
+// checkEnumWords may appear in a CHECK that only lists allowed values, in
+// either the written form (status IN ('a', 'b')) or the one pg_dump prints
+// (((status)::text = ANY ((ARRAY['a'::character varying])::text[]))).
+var checkEnumWords = map[string]bool{
+	"IN": true, "ANY": true, "ARRAY": true, "TEXT": true, "CHARACTER": true, "VARYING": true, "VARCHAR": true,
+}
+
+func checkEnum(toks []sqlToken) (string, []string, bool) {
+	var col string
+	var values []string
+	hasList := false
+	for _, t := range toks {
+		switch {
+		case t.Kind == sqlString:
+			values = append(values, t.Text)
+		case t.Kind == sqlWord && checkEnumWords[strings.ToUpper(t.Text)]:
+			if t.is("IN") || t.is("ANY") {
+				hasList = true
+			}
+		case t.isName():
+			if col != "" {
+				return "", nil, false
+			}
+			col = strings.ToLower(t.Text)
+		case t.Kind == sqlPunct && strings.Contains("()[],:=", t.Text):
+		default:
+			return "", nil, false
+		}
+	}
+	return col, values, col != "" && hasList && len(values) > 0
+}
+
+func tokensText(toks []sqlToken) string {
+	parts := make([]string, 0, len(toks))
+	for _, t := range toks {
+		switch t.Kind {
+		case sqlString:
+			parts = append(parts, "'"+strings.ReplaceAll(t.Text, "'", "''")+"'")
+		case sqlIdent:
+			parts = append(parts, `"`+t.Text+`"`)
+		default:
+			parts = append(parts, t.Text)
+		}
+	}
+	s := strings.Join(parts, " ")
+	for _, r := range []struct{ from, to string }{{"( ", "("}, {" )", ")"}, {" ,", ","}, {" : : ", "::"}} {
+		s = strings.ReplaceAll(s, r.from, r.to)
+	}
+	return s
+}
+
+// -------------------------------------------------------------------------
+// Tables to models
+// -------------------------------------------------------------------------
+
+// singularModelName turns a table or collection name into a model name:
+// order_items -> OrderItem.
+func singularModelName(table string) string {
+	switch {
+	case strings.HasSuffix(table, "ies") && len(table) > 3:
+		table = strings.TrimSuffix(table, "ies") + "y"
+	case strings.HasSuffix(table, "sses"), strings.HasSuffix(table, "xes"),
+		strings.HasSuffix(table, "ches"), strings.HasSuffix(table, "shes"):
+		table = strings.TrimSuffix(table, "es")
+	case strings.HasSuffix(table, "ss"):
+	case strings.HasSuffix(table, "s"):
+		table = strings.TrimSuffix(table, "s")
+	}
+	return toPascal(table)
+}
+
+// sameColumn compares column names ignoring case and underscores, so
+// order_item_id matches the orderitem_id key the renderers derive.
+func sameColumn(a, b string) bool {
+	norm := func(s string) string { return strings.ToLower(strings.ReplaceAll(s, "_", "")) }
+	return norm(a) == norm(b)
+}
+
+// joinTargets reports whether t only links two other tables, i.e. is the join
+// table of a many-to-many relation.
+func (imp *sqlImporter) joinTargets(t *sqlTable) (string, string, bool) {
+	var refs []string
+	for _, c := range t.Columns {
+		if isIDColumn(c.Name) {
+			continue
+		}
+		if _, known := imp.tables[c.RefTable]; !known || c.RefTable == t.Name {
+			return "", "", false
+		}
+		refs = append(refs, c.RefTable)
+	}
+	if len(refs) != 2 || refs[0] == refs[1] {
+		return "", "", false
+	}
+	return refs[0], refs[1], true
+}
+
+func (imp *sqlImporter) models() []DataModel {
+	for table, idx := range imp.indexes {
+		if t, ok := imp.tables[table]; ok {
+			t.Indexes = append(t.Indexes, idx...)
+			continue
+		}
+		for _, i := range idx {
+			imp.warn("SQL_INDEX_TABLE_UNKNOWN", "warn", i.Line, "The index was not imported.", "index on unknown table %s", table)
+		}
+	}
+
+	joins := map[string][2]string{}
+	for _, name := range imp.order {
+		if a, b, ok := imp.joinTargets(imp.tables[name]); ok {
+			joins[name] = [2]string{a, b}
+		}
+	}
+
+	var models []DataModel
+	var modelTables []string
+	byName := map[string]int{}
+	for _, name := range imp.order {
+		if _, ok := joins[name]; ok {
+			continue
+		}
+		t := imp.tables[name]
+		model := DataModel{Name: singularModelName(name)}
+		if prev, dup := byName[toPascal(model.Name)]; dup {
+			imp.warn("SQL_MODEL_DUPLICATE", "warn", t.Line, "Each model needs a distinct name.", "table %s maps to model %s, already taken by table %s", name, model.Name, modelTables[prev])
+			continue
+		}
+		byName[toPascal(model.Name)] = len(models)
+		modelTables = append(modelTables, name)
+		// The table keeps its source name, which re-pluralising the model
+		// name does not always give back (entries -> Entry -> entrys).
+		if tableNameRegex.MatchString(name) {
+			model.Table = name
+		} else {
+			imp.warn("SQL_TABLE_RENAMED", "info", t.Line, "Table names need a lowercase ASCII letter first and lowercase ASCII letters, digits or underscores after it.", "table %s imported as %s", name, modelTable(model))
+		}
+
+		columns := map[string]string{} // SQL column -> model column
+		taken := map[string]string{}   // model column -> SQL column
+		for _, c := range t.Columns {
+			if isIDColumn(c.Name) {
+				columns[c.Name] = "id"
+				continue
+			}
+			field, ok := importedFieldName(c.Name)
+			if !ok {
+				imp.warn("SQL_COLUMN_NAME_UNSUPPORTED", "warn", c.Line, "Field names need an ASCII letter first and ASCII letters, digits or underscores after it.", "column %s.%s skipped", name, c.Name)
+				continue
+			}
+			if prev, dup := taken[field]; dup {
+				imp.warn("SQL_COLUMN_DUPLICATE", "warn", c.Line, "Each field needs a distinct name.", "column %s.%s maps to field %s, already taken by column %s; skipped", name, c.Name, field, prev)
+				continue
+			}
+			taken[field] = c.Name
+			if field != c.Name {
+				imp.warn("SQL_COLUMN_RENAMED", "info", c.Line, "Field names are used as identifiers in the generated Go, JavaScript, Python and SQL code.", "column %s.%s imported as %s", name, c.Name, field)
+			}
+			if c.RefTable != "" {
+				target, known := imp.tables[c.RefTable]
+				_, isJoin := joins[c.RefTable]
+				if known && target != t && !isJoin {
+					targetModel := singularModelName(target.Name)
+					if fk := foreignKeyColumn(toPascal(targetModel)); sameColumn(field, fk) {
+						model.Relations = append(model.Relations, ModelRelation{Kind: RelationBelongsTo, Model: targetModel, Required: c.Required})
+						columns[c.Name] = fk
+						continue
+					}
+				}
+				imp.warn("SQL_FOREIGN_KEY_UNMAPPED", "warn", c.Line, "Relations need a <model>_id column referencing another table in the same import.", "foreign key %s.%s -> %s kept as a plain column", name, c.Name, c.RefTable)
+			}
+			f := c.DataField
+			f.Name = field
+			if c.PrimaryKey {
+				f.Required, f.Unique = true, true
+				imp.warn("SQL_PRIMARY_KEY_NOT_ID", "info", c.Line, "Generated models always use an integer id primary key.", "primary key %s.%s imported as a unique field", name, c.Name)
+			}
+			if len(f.Enum) > 0 {
+				for _, v := range f.Enum {
+					if !enumValueRegex.MatchString(v) {
+						imp.warn("SQL_ENUM_UNSUPPORTED", "warn", c.Line, "Enum values must be identifiers.", "%s.%s: enum value %q is not an identifier; imported as string", name, c.Name, v)
+						f.Type, f.Enum = "string", nil
+						if f.Default != "" && !enumValueRegex.MatchString(f.Default) {
+							f.Default = ""
+						}
+						break
+					}
+				}
+			}
+			if len(f.Enum) > 0 {
+				f.Type = "enum"
+			}
+			columns[c.Name] = f.Name
+			model.Fields = append(model.Fields, f)
+		}
+
+		for _, idx := range t.Indexes {
+			fields := make([]string, 0, len(idx.Fields))
+			for _, col := range idx.Fields {
+				mapped, ok := columns[col]
+				if !ok {
+					imp.warn("SQL_INDEX_COLUMN_UNKNOWN", "warn", idx.Line, "The index was not imported.", "index on %s references unknown column %s", name, col)
+					fields = nil
+					break
+				}
+				fields = append(fields, mapped)
+			}
+			if len(fields) > 0 && !(len(fields) == 1 && isIDColumn(fields[0])) {
+				model.Indexes = append(model.Indexes, ModelIndex{Fields: fields, Unique: idx.Unique})
+			}
+		}
+		models = append(models, model)
+	}
+
+	for _, name := range imp.order {
+		pair, ok := joins[name]
+		if !ok {
+			continue
+		}
+		from, ok := byName[toPascal(singularModelName(pair[0]))]
+		if !ok {
+			continue
+		}
+		models[from].Relations = append(models[from].Relations, ModelRelation{Kind: RelationManyToMany, Model: singularModelName(pair[1])})
+	}
+	return models
+}
@@ -0,0 +1,225 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportSQLModelsPostgres(t *testing.T) {
+	ddl := `-- pg_dump style schema
+CREATE TABLE public.users (
+    id SERIAL PRIMARY KEY,
+    email character varying(120) NOT NULL UNIQUE,
+    active boolean DEFAULT true,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE TABLE posts (
+    id bigserial NOT NULL,
+    title text NOT NULL,
+    status character varying(20) DEFAULT 'draft'::character varying,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    score numeric(5,2) DEFAULT nextval('posts_score_seq'::regclass),
+    CONSTRAINT posts_pkey PRIMARY KEY (id),
+    CONSTRAINT posts_status_check CHECK (((status)::text = ANY ((ARRAY['draft'::character varying, 'published'::character varying])::text[])))
+);
+
+CREATE TABLE tags (id serial PRIMARY KEY, label varchar(40) NOT NULL);
+CREATE TABLE posts_tags (
+    post_id integer REFERENCES posts(id),
+    tag_id integer REFERENCES tags(id),
+    PRIMARY KEY (post_id, tag_id)
+);
+CREATE INDEX idx_posts_status_user ON posts USING btree (status, user_id);
+CREATE FUNCTION touch() RETURNS trigger AS $$ BEGIN; RETURN NEW; END; $$ LANGUAGE plpgsql;
+CREATE TABLE broken (id int,
+`
+	got := ImportSQLModels(ddl)
+
+	want := []DataModel{
+		{Name: "User", Table: "users", Fields: []DataField{
+			{Name: "email", Type: "string", Required: true, Unique: true, MaxLength: 120},
+			{Name: "active", Type: "bool", Default: "true"},
+			{Name: "created_at", Type: "datetime", Required: true, Default: "now"},
+		}},
+		{
+			Name:  "Post",
+			Table: "posts",
+			Fields: []DataField{
+				{Name: "title", Type: "string", Required: true},
+				{Name: "status", Type: "enum", Default: "draft", MaxLength: 20, Enum: []string{"draft", "published"}},
+				{Name: "score", Type: "float"},
+			},
+			Indexes:   []ModelIndex{{Fields: []string{"status", "user_id"}}},
+			Relations: []ModelRelation{{Kind: RelationBelongsTo, Model: "User", Required: true}, {Kind: RelationManyToMany, Model: "Tag"}},
+		},
+		{Name: "Tag", Table: "tags", Fields: []DataField{{Name: "label", Type: "string", Required: true, MaxLength: 40}}},
+	}
+	if !reflect.DeepEqual(got.Models, want) {
+		t.Errorf("ImportSQLModels() models =\n%+v\nwant\n%+v", got.Models, want)
+	}
+
+	codes := map[string]string{}
+	for _, w := range got.Warnings {
+		codes[w.Code] = w.Message
+	}
+	for code, line := range map[string]string{
+		"SQL_DEFAULT_UNSUPPORTED": "line 14:",
+		"SQL_STATEMENT_SKIPPED":   "line 26:",
+		"SQL_STATEMENT_UNPARSED":  "line 27:",
+	} {
+		if !strings.HasPrefix(codes[code], line) {
+			t.Errorf("warning %s = %q, want it to start with %q", code, codes[code], line)
+		}
+	}
+	if len(got.Warnings) != 3 {
+		t.Errorf("got %d warnings, want 3: %+v", len(got.Warnings), got.Warnings)
+	}
+	if v := ValidateAll(GenerateRequest{Language: "go", Framework: "gin", Architecture: "mvp", Database: "postgresql", Root: RootOptions{Mode: "new", Name: "x"}, Custom: CustomOptions{Models: got.Models}}); len(v) != 0 {
+		t.Errorf("imported models do not validate: %v", v)
+	}
+}
+
+func TestImportSQLModelsMySQL(t *testing.T) {
+	ddl := "CREATE TABLE `order_items` (\n" +
+		"  `id` int unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `sku` varchar(64) CHARACTER SET utf8mb4 NOT NULL COMMENT 'stock unit',\n" +
+		"  `state` enum('open','shipped') NOT NULL DEFAULT 'open',\n" +
+		"  `gift` tinyint(1) DEFAULT '0',\n" +
+		"  `owner_id` int DEFAULT NULL,\n" +
+		"  `updated_at` datetime DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uq_sku_state` (`sku`, `state`),\n" +
+		"  KEY `idx_owner` (`owner_id`),\n" +
+		"  CONSTRAINT `fk_owner` FOREIGN KEY (`owner_id`) REFERENCES `accounts` (`id`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n"
+	got := ImportSQLModels(ddl)
+	want := []DataModel{{
+		Name:  "OrderItem",
+		Table: "order_items",
+		Fields: []DataField{
+			{Name: "sku", Type: "string", Required: true, MaxLength: 64},
+			{Name: "state", Type: "enum", Required: true, Default: "open", Enum: []string{"open", "shipped"}},
+			{Name: "gift", Type: "bool", Default: "false"},
+			{Name: "owner_id", Type: "int"},
+			{Name: "updated_at", Type: "datetime", Default: "now"},
+		},
+		Indexes: []ModelIndex{{Fields: []string{"sku", "state"}, Unique: true}, {Fields: []string{"owner_id"}}},
+	}}
+	if !reflect.DeepEqual(got.Models, want) {
+		t.Errorf("ImportSQLModels() models =\n%+v\nwant\n%+v", got.Models, want)
+	}
+	// accounts is not part of the import, so owner_id stays a plain column.
+	if len(got.Warnings) != 1 || got.Warnings[0].Code != "SQL_FOREIGN_KEY_UNMAPPED" || !strings.HasPrefix(got.Warnings[0].Message, "line 6:") {
+		t.Errorf("warnings = %+v", got.Warnings)
+	}
+}
+
+func TestApplyRuleEngineImportsModelsFromSQL(t *testing.T) {
+	req := GenerateRequest{Custom: CustomOptions{
+		Models:        []DataModel{{Name: "User", Table: "users", Fields: []DataField{{Name: "name", Type: "string"}}}},
+		ModelsFromSQL: "CREATE TABLE users (id int, email text);\nCREATE TABLE products (id int, price decimal(10,2));",
+	}}
+	got, decisions, warnings := ApplyRuleEngine(req)
+	if len(got.Custom.Models) != 2 || got.Custom.Models[0].Fields[0].Name != "name" || got.Custom.Models[1].Name != "Product" {
+		t.Errorf("models = %+v", got.Custom.Models)
+	}
+	if len(decisions) != 1 || decisions[0].Code != "MODELS_IMPORTED_FROM_SQL" {
+		t.Errorf("decisions = %+v", decisions)
+	}
+	if len(warnings) != 1 || warnings[0].Code != "SQL_MODEL_SHADOWED" {
+		t.Errorf("warnings = %+v", warnings)
+	}
+}
+
+func TestImportSQLModelsColumnNames(t *testing.T) {
+	ddl := `CREATE TABLE items (
+    id serial PRIMARY KEY,
+    "first name" text NOT NULL,
+    "2abc" integer,
+    "ñame" text,
+    class text,
+    "def" integer,
+    "from" date,
+    "select" text,
+    "order" integer,
+    "First_Name" text
+);
+CREATE INDEX idx_items_name ON items ("first name", class);
+`
+	got := ImportSQLModels(ddl)
+	want := []DataModel{{
+		Name:  "Item",
+		Table: "items",
+		Fields: []DataField{
+			{Name: "first_name", Type: "string", Required: true},
+			{Name: "class_", Type: "string"},
+			{Name: "def_", Type: "int"},
+			{Name: "from_", Type: "datetime"},
+			{Name: "select_", Type: "string"},
+			{Name: "order_", Type: "int"},
+		},
+		Indexes: []ModelIndex{{Fields: []string{"first_name", "class_"}}},
+	}}
+	if !reflect.DeepEqual(got.Models, want) {
+		t.Errorf("ImportSQLModels() models =\n%+v\nwant\n%+v", got.Models, want)
+	}
+
+	var messages []string
+	for _, w := range got.Warnings {
+		messages = append(messages, w.Code+" "+w.Message)
+	}
+	wantWarnings := []string{
+		"SQL_COLUMN_RENAMED line 3: column items.first name imported as first_name",
+		"SQL_COLUMN_NAME_UNSUPPORTED line 4: column items.2abc skipped",
+		"SQL_COLUMN_NAME_UNSUPPORTED line 5: column items.ñame skipped",
+		"SQL_COLUMN_RENAMED line 6: column items.class imported as class_",
+		"SQL_COLUMN_RENAMED line 7: column items.def imported as def_",
+		"SQL_COLUMN_RENAMED line 8: column items.from imported as from_",
+		"SQL_COLUMN_RENAMED line 9: column items.select imported as select_",
+		"SQL_COLUMN_RENAMED line 10: column items.order imported as order_",
+		"SQL_COLUMN_DUPLICATE line 11: column items.first_name maps to field first_name, already taken by column first name; skipped",
+	}
+	if !reflect.DeepEqual(messages, wantWarnings) {
+		t.Errorf("warnings =\n%s\nwant\n%s", strings.Join(messages, "\n"), strings.Join(wantWarnings, "\n"))
+	}
+}
+
+func TestImportSQLModelsKeepTableNames(t *testing.T) {
+	ddl := `CREATE TABLE categories (id serial PRIMARY KEY, label text);
+CREATE TABLE entries (
+    id serial PRIMARY KEY,
+    title text,
+    category_id integer NOT NULL REFERENCES categories(id)
+);
+CREATE TABLE addresses (id serial PRIMARY KEY, city text);
+CREATE TABLE "Audit Log" (id serial PRIMARY KEY, action text);
+`
+	got := ImportSQLModels(ddl)
+	tables := map[string]string{}
+	for _, m := range got.Models {
+		tables[m.Name] = modelTable(m)
+	}
+	want := map[string]string{"Category": "categories", "Entry": "entries", "Address": "addresses", "AuditLog": "auditlogs"}
+	if !reflect.DeepEqual(tables, want) {
+		t.Errorf("tables = %v, want %v", tables, want)
+	}
+	if len(got.Warnings) != 1 || got.Warnings[0].Code != "SQL_TABLE_RENAMED" || got.Warnings[0].Message != "line 8: table audit log imported as auditlogs" {
+		t.Errorf("warnings = %+v", got.Warnings)
+	}
+
+	// Statements, DDL and routes use the source tables.
+	entry := findModel(resolvedModels(got.Models), "Entry")
+	if st := newCRUDStatements(entry, "postgresql", dollarBind); st.Delete != `DELETE FROM "entries" WHERE "id" = $1` {
+		t.Errorf("delete = %q", st.Delete)
+	}
+	assertContainsAll(t, "postgres DDL", renderSQLTablesTemplate("postgresql", got.Models, false),
+		`CREATE TABLE IF NOT EXISTS "entries" (`,
+		`"category_id" INTEGER NOT NULL REFERENCES "categories" ("id")`,
+		`CREATE TABLE IF NOT EXISTS "addresses" (`,
+	)
+	if routes := modelRoutes(entry); routes[0].Path != "/entries" {
+		t.Errorf("list route = %s, want /entries", routes[0].Path)
+	}
+}
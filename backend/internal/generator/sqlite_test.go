@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSQLiteComposeEnvAndSchema(t *testing.T) {
+	req := GenerateRequest{
+		Architecture: "microservices",
+		Database:     "sqlite",
+		Services:     []ServiceConfig{{Name: "users", Port: 8081}, {Name: "orders", Port: 8082}},
+	}
+	spec := newComposeSpec(req)
+	if len(spec.Services) != 2 {
+		t.Errorf("sqlite should not add a database service: %v", sortedKeys(spec.Services))
+	}
+	if orders := spec.Services["orders"]; len(orders.DependsOn) != 0 || len(orders.Volumes) != 1 || orders.Volumes[0] != "./services/orders/data:/app/data" {
+		t.Errorf("orders = %+v", orders)
+	}
+	assertContainsAll(t, ".env", buildEnv(req, "orders", 8082), "DATABASE_URL=file:./data/app.db\n")
+	prisma := GenerateRequest{Language: "node", Database: "sqlite", UseORM: true}
+	assertContainsAll(t, "prisma .env", buildEnv(prisma, "", 8080), "DATABASE_URL=file:../data/app.db\n")
+	if dbWeight("sqlite") >= dbWeight("postgresql") {
+		t.Error("sqlite should weigh less than a database server")
+	}
+
+	models := []DataModel{{Name: "Tag", Fields: []DataField{{Name: "label", Type: "string", Required: true}, {Name: "pinned", Type: "bool", Default: "true"}}}}
+	ddl := sampleMigration("sqlite", models)
+	assertContainsAll(t, "sqlite ddl", ddl, `"id" INTEGER PRIMARY KEY AUTOINCREMENT`, `"label" VARCHAR(255) NOT NULL`, `"pinned" BOOLEAN DEFAULT 1`)
+	if strings.Contains(ddl, "SERIAL") {
+		t.Errorf("sqlite ddl uses SERIAL:\n%s", ddl)
+	}
+	if st := newCRUDStatements(models[0], "sqlite", questionBind); st.Returning || st.Insert != `INSERT INTO "tags" ("label", "pinned") VALUES (?, ?)` {
+		t.Errorf("sqlite statements = %+v", st)
+	}
+	if schema := renderPrismaSchema("sqlite", richModels()); !strings.Contains(schema, `provider = "sqlite"`) || strings.Contains(schema, "@db.") {
+		t.Errorf("prisma sqlite schema:\n%s", schema)
+	}
+}
+
+func TestGenerateWiresSQLite(t *testing.T) {
+	registry, err := NewTemplateRegistry("../../../templates")
+	if err != nil {
+		t.Fatalf("failed to init registry: %v", err)
+	}
+	engine := NewEngine(registry)
+	models := CustomOptions{Models: []DataModel{{Name: "Tag", Fields: []DataField{{Name: "label", Type: "string"}}}}}
+	auth := FeatureOptions{JWTAuth: true}
+	cases := []struct {
+		name    string
+		req     GenerateRequest
+		files   map[string][]string
+		missing []string
+	}{
+		{
+			name: "go clean",
+			req:  GenerateRequest{Language: "go", Framework: "gin", Architecture: "clean", Custom: models, Features: auth},
+			files: map[string][]string{
+				"internal/db/connection.go":             {`_ "modernc.org/sqlite"`, `sql.Open("sqlite", dsn)`, `"id" INTEGER PRIMARY KEY AUTOINCREMENT`, "conn.Exec(schema)"},
+				"internal/repository/tag_repository.go": {"VALUES (?)", "res.LastInsertId()"},
+				"internal/auth/users.go":                {"id INTEGER PRIMARY KEY AUTOINCREMENT", "WHERE email = ?"},
+				"go.mod":                                {"modernc.org/sqlite"},
+				"migrations/001_initial.sql":            {`CREATE TABLE IF NOT EXISTS "tags"`},
+			},
+			missing: []string{"db/init/001_init.sql"},
+		},
+		{
+			name: "go gorm",
+			req:  GenerateRequest{Language: "go", Framework: "fiber", Architecture: "mvp", UseORM: true, Custom: models},
+			files: map[string][]string{
+				"internal/db/connection.go": {`"github.com/glebarez/sqlite"`, "gorm.Open(sqlite.Open(dsn), &gorm.Config{})", "conn.Exec(schema).Error"},
+				"go.mod":                    {"github.com/glebarez/sqlite"},
+			},
+		},
+		{
+			name: "express",
+			req:  GenerateRequest{Language: "node", Framework: "express", Architecture: "mvp", Custom: models, Features: auth},
+			files: map[string][]string{
+				"src/db/sqlClient.js": {"import Database from 'better-sqlite3';", "db.exec(schema);", "export function bindable(value)"},
+				"src/auth/users.js":   {"id INTEGER PRIMARY KEY AUTOINCREMENT", "lastInsertRowid"},
+				"package.json":        {`"better-sqlite3"`},
+			},
+		},
+		{
+			name: "fastify prisma",
+			req:  GenerateRequest{Language: "node", Framework: "fastify", Architecture: "clean", UseORM: true, Custom: models},
+			files: map[string][]string{
+				"prisma/schema.prisma": {`provider = "sqlite"`},
+				"package.json":         {`"start": "prisma db push && node src/index.js"`, `"@prisma/client"`},
+			},
+		},
+		{
+			name: "fastapi",
+			req:  GenerateRequest{Language: "python", Framework: "fastapi", Architecture: "mvp", Custom: models, Features: FeatureOptions{JWTAuth: true, Observability: true}},
+			files: map[string][]string{
+				"app/repository/sql_driver.py": {"import sqlite3", "_conn.executescript(SCHEMA)", "PRAGMA foreign_keys = ON"},
+				"app/auth/users.py":            {"id INTEGER PRIMARY KEY AUTOINCREMENT", "VALUES (?, ?, ?)"},
+				"app/telemetry.py":             {"SQLite3Instrumentor().instrument()"},
+				"requirements.txt":             {"opentelemetry-instrumentation-sqlite3"},
+			},
+		},
+		{
+			name: "fastapi sqlalchemy",
+			req:  GenerateRequest{Language: "python", Framework: "fastapi", Architecture: "clean", UseORM: true, Custom: models},
+			files: map[string][]string{
+				"app/repository/sqlalchemy_session.py": {"create_engine('sqlite:///' + DATABASE_PATH)"},
+				"requirements.txt":                     {"SQLAlchemy=="},
+			},
+			missing: []string{"alembic.ini"},
+		},
+		{
+			name:  "django",
+			req:   GenerateRequest{Language: "python", Framework: "django", Architecture: "mvp", Custom: models},
+			files: map[string][]string{"config/settings.py": {`"NAME": DATABASE_PATH`, "removeprefix('file:')"}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req.Database = "sqlite"
+			out, err := engine.GenerateProject(context.Background(), tc.req)
+			if err != nil {
+				t.Fatalf("GenerateProject() error = %v", err)
+			}
+			for _, w := range out.Response.Warnings {
+				if w.Code == "INJECTION_MARKER_MISSING" || w.Code == "TEMPLATE_RENDER_FAILED" {
+					t.Errorf("unexpected warning %+v", w)
+				}
+			}
+			for file, want := range tc.files {
+				assertContainsAll(t, file, out.Tree.Files[file], want...)
+			}
+			for _, file := range tc.missing {
+				if _, ok := out.Tree.Files[file]; ok {
+					t.Errorf("%s should not be generated", file)
+				}
+			}
+		})
+	}
+}
@@ -34,19 +34,5 @@ type Generator interface {
 }
 
 func isSQLDB(db string) bool {
-	return db == "postgresql" || db == "mysql"
-}
-
-func GetGenerator(lang string) Generator {
-	switch lang {
-	case "go":
-		return &GoGenerator{}
-	case "node":
-		return &NodeGenerator{}
-	case "python":
-		return &PythonGenerator{}
-	default:
-		// Fallback to Go as a default, though Validator should prevent this.
-		return &GoGenerator{}
-	}
+	return db == "postgresql" || db == "mysql" || db == "sqlite"
 }
@@ -3,30 +3,156 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"io/fs"
 	"os"
-	"path/filepath"
+	"path"
+	"sort"
+	"strings"
 	"text/template"
 )
 
+// TemplateRegistry serves pre-parsed templates from a base filesystem (normally
+// the embedded templates tree) and optional overlays. An overlay shadows the
+// base template at the same path, so an organisation can customise individual
+// files without forking the whole tree.
 type TemplateRegistry struct {
-	root string
+	templates map[string]*template.Template
+	bodies    map[string]string // template path -> raw source, for marker checks
+	sources   map[string]string // template path -> source label, for diagnostics
+	manifests map[string]*TemplateManifest
 }
 
-func NewTemplateRegistry(root string) (*TemplateRegistry, error) {
+// NewTemplateRegistry builds a registry from an on-disk template root plus
+// optional on-disk overlay directories.
+func NewTemplateRegistry(root string, overlayDirs ...string) (*TemplateRegistry, error) {
 	if root == "" {
 		return nil, fmt.Errorf("template root cannot be empty")
 	}
 	if _, err := os.Stat(root); err != nil {
 		return nil, fmt.Errorf("template root is not accessible: %w", err)
 	}
-	return &TemplateRegistry{root: root}, nil
+	return NewTemplateRegistryWithOverlays(os.DirFS(root), overlayDirs...)
+}
+
+// NewTemplateRegistryWithOverlays builds a registry from base plus on-disk
+// overlay directories. Later overlays take precedence over earlier ones.
+func NewTemplateRegistryWithOverlays(base fs.FS, overlayDirs ...string) (*TemplateRegistry, error) {
+	layers := []templateLayer{{label: "base", fsys: base}}
+	for _, dir := range overlayDirs {
+		if strings.TrimSpace(dir) == "" {
+			continue
+		}
+		if _, err := os.Stat(dir); err != nil {
+			return nil, fmt.Errorf("template overlay is not accessible: %w", err)
+		}
+		layers = append(layers, templateLayer{label: dir, fsys: os.DirFS(dir)})
+	}
+	return newTemplateRegistry(layers)
+}
+
+type templateLayer struct {
+	label string
+	fsys  fs.FS
+}
+
+// newTemplateRegistry parses every *.tmpl file and manifest up front, so a
+// syntax error or a dangling manifest entry fails startup instead of the first
+// request that needs the template.
+func newTemplateRegistry(layers []templateLayer) (*TemplateRegistry, error) {
+	r := &TemplateRegistry{
+		templates: map[string]*template.Template{},
+		bodies:    map[string]string{},
+		sources:   map[string]string{},
+		manifests: map[string]*TemplateManifest{},
+	}
+	for _, layer := range layers {
+		err := fs.WalkDir(layer.fsys, ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			if path.Base(p) == manifestFile {
+				body, err := fs.ReadFile(layer.fsys, p)
+				if err != nil {
+					return err
+				}
+				m, err := parseTemplateManifest(path.Dir(p), body)
+				if err != nil {
+					return fmt.Errorf("failed to parse manifest %s (%s): %w", p, layer.label, err)
+				}
+				r.manifests[m.Dir] = m
+				return nil
+			}
+			if path.Ext(p) != ".tmpl" {
+				return nil
+			}
+			body, err := fs.ReadFile(layer.fsys, p)
+			if err != nil {
+				return err
+			}
+			tpl, err := template.New(p).Parse(string(body))
+			if err != nil {
+				return fmt.Errorf("failed to parse template %s (%s): %w", p, layer.label, err)
+			}
+			r.templates[p] = tpl
+			r.bodies[p] = string(body)
+			r.sources[p] = layer.label
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(r.templates) == 0 {
+		return nil, fmt.Errorf("no templates found")
+	}
+	if err := r.checkManifests(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// checkManifests verifies every manifest entry points at a parsed template and
+// that declared injection markers are present in that template's source.
+func (r *TemplateRegistry) checkManifests() error {
+	dirs := make([]string, 0, len(r.manifests))
+	for dir := range r.manifests {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		m := r.manifests[dir]
+		for _, f := range append(append([]ManifestFile{}, m.Files...), m.Models...) {
+			body, ok := r.bodies[f.Template]
+			if !ok {
+				return fmt.Errorf("manifest %s references missing template %s", path.Join(dir, manifestFile), f.Template)
+			}
+			for _, marker := range f.Markers {
+				if !strings.Contains(body, "stacksprint:"+marker) {
+					return fmt.Errorf("manifest %s: template %s lacks marker stacksprint:%s", path.Join(dir, manifestFile), f.Template, marker)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Manifest returns the rendering manifest for a language and architecture.
+func (r *TemplateRegistry) Manifest(lang, arch string) (*TemplateManifest, error) {
+	dir := path.Join(lang, archTemplateName(arch))
+	m, ok := r.manifests[dir]
+	if !ok {
+		return nil, fmt.Errorf("no template manifest for %s", dir)
+	}
+	return m, nil
 }
 
 func (r *TemplateRegistry) Render(path string, data any) (string, error) {
-	fullPath := filepath.Join(r.root, filepath.FromSlash(path))
-	tpl, err := template.ParseFiles(fullPath)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse template %s: %w", path, err)
+	tpl, ok := r.templates[path]
+	if !ok {
+		return "", fmt.Errorf("failed to parse template %s: template not found", path)
 	}
 	var buf bytes.Buffer
 	if err := tpl.Execute(&buf, data); err != nil {
@@ -34,3 +160,15 @@ func (r *TemplateRegistry) Render(path string, data any) (string, error) {
 	}
 	return buf.String(), nil
 }
+
+// Overridden lists templates served from an overlay rather than the base tree.
+func (r *TemplateRegistry) Overridden() []string {
+	var out []string
+	for p, src := range r.sources {
+		if src != "base" {
+			out = append(out, p)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTemplateRegistryOverlayShadowsBase(t *testing.T) {
+	base := fstest.MapFS{
+		"go/mvp/main.tmpl":  {Data: []byte("base {{.Name}}")},
+		"go/mvp/other.tmpl": {Data: []byte("other")},
+	}
+	overlay := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(overlay, "go", "mvp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(overlay, "go", "mvp", "main.tmpl"), []byte("overlay {{.Name}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry, err := NewTemplateRegistryWithOverlays(base, overlay)
+	if err != nil {
+		t.Fatalf("NewTemplateRegistryWithOverlays() failed: %v", err)
+	}
+	if got, _ := registry.Render("go/mvp/main.tmpl", map[string]string{"Name": "x"}); got != "overlay x" {
+		t.Errorf("expected overlay template, got %q", got)
+	}
+	if got, _ := registry.Render("go/mvp/other.tmpl", nil); got != "other" {
+		t.Errorf("expected base template, got %q", got)
+	}
+	if overridden := registry.Overridden(); len(overridden) != 1 || overridden[0] != "go/mvp/main.tmpl" {
+		t.Errorf("Overridden() = %v", overridden)
+	}
+}
+
+func TestTemplateRegistryRejectsInvalidTemplateAtStartup(t *testing.T) {
+	base := fstest.MapFS{"go/mvp/main.tmpl": {Data: []byte("{{if}")}}
+	_, err := NewTemplateRegistryWithOverlays(base)
+	if err == nil || !strings.Contains(err.Error(), "go/mvp/main.tmpl") {
+		t.Fatalf("expected parse error naming the template, got %v", err)
+	}
+}
+
+func TestTemplateRegistryChecksManifests(t *testing.T) {
+	manifest := "files:\n  - template: main.tmpl\n    output: cmd/server/main.go\n    markers: [routes]\n"
+	cases := map[string]fstest.MapFS{
+		"missing template": {
+			"go/mvp/manifest.yaml": {Data: []byte(manifest)},
+			"go/mvp/other.tmpl":    {Data: []byte("other")},
+		},
+		"missing marker": {
+			"go/mvp/manifest.yaml": {Data: []byte(manifest)},
+			"go/mvp/main.tmpl":     {Data: []byte("package main\n")},
+		},
+	}
+	for name, base := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewTemplateRegistryWithOverlays(base)
+			if err == nil || !strings.Contains(err.Error(), "go/mvp/manifest.yaml") {
+				t.Fatalf("expected manifest error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTemplateManifestSpecs(t *testing.T) {
+	base := fstest.MapFS{
+		"go/mvp/manifest.yaml": {Data: []byte(`files:
+  - template: main.tmpl
+    output: cmd/server/main.go
+    markers: [imports, routes]
+  - template: item.tmpl
+    output: internal/handlers/item_handler.go
+    when: {example_crud: false}
+  - template: item.tmpl
+    output: internal/auth/jwt.go
+    when: {jwt_auth: true}
+models:
+  - template: ../shared/model.tmpl
+    output: internal/handlers/{lower}_handler.go
+`)},
+		"go/mvp/main.tmpl":     {Data: []byte("// stacksprint:imports\n// stacksprint:routes\n")},
+		"go/mvp/item.tmpl":     {Data: []byte("item")},
+		"go/shared/model.tmpl": {Data: []byte("model")},
+	}
+	registry, err := NewTemplateRegistryWithOverlays(base)
+	if err != nil {
+		t.Fatalf("NewTemplateRegistryWithOverlays() failed: %v", err)
+	}
+	m, err := registry.Manifest("go", "mvp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := GenerateRequest{Framework: "gin", Database: "none", FileToggles: FileToggleOptions{ExampleCRUD: ptr(true)}}
+	if specs := m.fileSpecs(req); len(specs) != 1 || specs[0].Output != "cmd/server/main.go" {
+		t.Errorf("fileSpecs(example_crud) = %+v", specs)
+	}
+	req.FileToggles.ExampleCRUD = ptr(false)
+	if specs := m.fileSpecs(req); len(specs) != 2 {
+		t.Errorf("fileSpecs(no example_crud) = %+v", specs)
+	}
+	req.Features.JWTAuth = true
+	if specs := m.fileSpecs(req); len(specs) != 3 || specs[2].Output != "internal/auth/jwt.go" {
+		t.Errorf("fileSpecs(jwt_auth) = %+v", specs)
+	}
+	specs := m.modelSpecs(req, DataModel{Name: "BlogPost"})
+	if len(specs) != 1 || specs[0].Template != "go/shared/model.tmpl" || specs[0].Output != "internal/handlers/blogpost_handler.go" {
+		t.Errorf("modelSpecs() = %+v", specs)
+	}
+	if target, ok := m.markerTarget(req, "routes"); !ok || target != "cmd/server/main.go" {
+		t.Errorf("markerTarget() = %q, %v", target, ok)
+	}
+}
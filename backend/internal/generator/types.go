@@ -11,8 +11,10 @@ type GenerateRequest struct {
 	Features             FeatureOptions    `json:"features"`
 	FileToggles          FileToggleOptions `json:"file_toggles"`
 	Custom               CustomOptions     `json:"custom"`
+	RBAC                 RBACOptions       `json:"rbac"`
 	Root                 RootOptions       `json:"root"`
 	ServiceCommunication string            `json:"service_communication"`
+	Strict               bool              `json:"strict"` // fail when any error-severity warning is raised
 }
 
 type ServiceConfig struct {
@@ -21,13 +23,16 @@ type ServiceConfig struct {
 }
 
 type InfraOptions struct {
-	Redis bool `json:"redis"`
-	Kafka bool `json:"kafka"`
-	NATS  bool `json:"nats"`
+	Redis     bool `json:"redis"`
+	Kafka     bool `json:"kafka"`
+	NATS      bool `json:"nats"`
+	JetStream bool `json:"nats_jetstream"` // streams and durable consumers; implies nats
+	RabbitMQ  bool `json:"rabbitmq"`
 }
 
 type FeatureOptions struct {
 	JWTAuth       bool `json:"jwt_auth"`
+	RBAC          bool `json:"rbac"` // role checks on the model routes; implies jwt_auth
 	Swagger       bool `json:"swagger"`
 	GitHubActions bool `json:"github_actions_ci"`
 	Makefile      bool `json:"makefile"`
@@ -35,6 +40,9 @@ type FeatureOptions struct {
 	GlobalError   bool `json:"global_error_handler"`
 	Health        bool `json:"health_endpoint"`
 	SampleTest    bool `json:"sample_test"`
+	Kubernetes    bool `json:"kubernetes"`    // manifests under k8s/
+	Helm          bool `json:"helm"`          // chart under helm/; implies kubernetes
+	Observability bool `json:"observability"` // tracing, /metrics and the compose monitoring stack
 }
 
 type FileToggleOptions struct {
@@ -54,19 +62,66 @@ type CustomOptions struct {
 	AddFolders      []string     `json:"add_folders"`
 	AddFiles        []CustomFile `json:"add_files"`
 	Models          []DataModel  `json:"models"`
+	ModelsFromSQL   string       `json:"models_from_sql,omitempty"` // CREATE TABLE statements imported as extra models
+	OpenAPI         string       `json:"openapi,omitempty"`         // OpenAPI 3 document (YAML or JSON) imported as models and routes
 	AddServiceNames []string     `json:"add_service_names"`
 	RemoveFolders   []string     `json:"remove_folders"`
 	RemoveFiles     []string     `json:"remove_files"`
 }
 
 type DataModel struct {
-	Name   string      `json:"name"`
-	Fields []DataField `json:"fields"`
+	Name      string          `json:"name"`
+	Table     string          `json:"table,omitempty"` // database table; the lowercased name plus "s" when empty
+	Fields    []DataField     `json:"fields"`
+	Indexes   []ModelIndex    `json:"indexes,omitempty"`
+	Relations []ModelRelation `json:"relations,omitempty"`
+	Routes    []ModelRoute    `json:"routes,omitempty"` // HTTP operations; list/get/create/update/delete when empty
 }
 
 type DataField struct {
-	Name string `json:"name"`
-	Type string `json:"type"`
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Required  bool     `json:"required,omitempty"` // NOT NULL / non-optional in validators
+	Unique    bool     `json:"unique,omitempty"`
+	Default   string   `json:"default,omitempty"`    // literal for the field type; "now" for datetime fields
+	MaxLength int      `json:"max_length,omitempty"` // string columns; 255 when unset
+	Enum      []string `json:"enum,omitempty"`       // allowed values; makes the field an enum
+}
+
+// ModelIndex is a (possibly composite) index over field or foreign-key column names.
+type ModelIndex struct {
+	Fields []string `json:"fields"`
+	Unique bool     `json:"unique,omitempty"`
+}
+
+// ModelRelation links a model to another model in the same request.
+type ModelRelation struct {
+	Kind     string `json:"kind"`               // "belongs_to" | "has_many" | "many_to_many"
+	Model    string `json:"model"`              // target model name
+	Required bool   `json:"required,omitempty"` // belongs_to only: the foreign key is NOT NULL
+}
+
+// ModelRoute is one HTTP operation served by a model's generated handlers.
+type ModelRoute struct {
+	OperationID string `json:"operation_id"`     // names the generated handler
+	Method      string `json:"method"`           // GET | POST | PUT | PATCH | DELETE
+	Path        string `json:"path"`             // OpenAPI style, e.g. /posts/{id}
+	Action      string `json:"action,omitempty"` // list | get | create | update | delete | custom; derived from method and path when empty
+}
+
+// RBACOptions configures the roles features.rbac enforces.
+type RBACOptions struct {
+	Roles       []RBACRole `json:"roles,omitempty"`        // admin ["*"] and user ["*:list", "*:get"] when empty
+	DefaultRole string     `json:"default_role,omitempty"` // role new accounts get; the last role when empty
+}
+
+// RBACRole grants permissions of the form <resource>:<action>, such as
+// orders:delete, where the resource is a model's table and the action a route
+// action or, for custom routes, the operation id. "*" stands for any resource
+// or action, or alone for everything.
+type RBACRole struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
 }
 
 type CustomFile struct {
@@ -80,6 +135,9 @@ type RootOptions struct {
 	Path    string `json:"path"`
 	GitInit bool   `json:"git_init"`
 	Module  string `json:"module"`
+	// Existing mode only: what to do with files that are already present.
+	OnConflict   string            `json:"on_conflict,omitempty"`   // "skip" | "overwrite" | "backup" | "merge" (default)
+	FilePolicies map[string]string `json:"file_policies,omitempty"` // path -> policy, overrides on_conflict
 }
 
 type Warning struct {
@@ -87,12 +145,14 @@ type Warning struct {
 	Severity string `json:"severity"` // "info" | "warn" | "error"
 	Message  string `json:"message"`
 	Reason   string `json:"reason"`
+	Path     string `json:"path,omitempty"` // output file that triggered the warning, if any
 }
 
 type Decision struct {
 	Code        string `json:"code"`
 	Description string `json:"description"`
 	TriggeredBy string `json:"triggered_by"`
+	Path        string `json:"path,omitempty"` // output file the decision applies to, if any
 }
 
 type GenerateResponse struct {
@@ -103,6 +163,14 @@ type GenerateResponse struct {
 	ComplexityReport ComplexityReport `json:"complexity_report"`
 }
 
+// ValidationReport is the dry-run result of validating a request without generating it.
+type ValidationReport struct {
+	Valid     bool              `json:"valid"`
+	Errors    []ValidationError `json:"errors"`
+	Warnings  []Warning         `json:"warnings"`
+	Decisions []Decision        `json:"decisions"`
+}
+
 type FileTree struct {
 	Files map[string]string
 	Dirs  map[string]struct{}
@@ -0,0 +1,210 @@
+package generator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ProjectManifestPath is where generation records what it produced, relative
+// to the project root.
+const ProjectManifestPath = ".stacksprint.json"
+
+const projectManifestVersion = 1
+
+// ProjectManifest records the request a project was generated from and a
+// content hash per generated file, so a later upgrade can tell hand edits
+// apart from untouched generated files.
+type ProjectManifest struct {
+	Version int               `json:"version"`
+	Request GenerateRequest   `json:"request"`
+	Files   map[string]string `json:"files"` // path -> sha256 of the generated content
+}
+
+// UpgradeFile is one entry of an upgrade plan.
+type UpgradeFile struct {
+	Path   string `json:"path"`
+	Action string `json:"action"` // "create" | "update" | "merge" | "conflict" | "unchanged" | "kept" | "obsolete"
+}
+
+// UpgradeResult is the outcome of Engine.Upgrade. Tree holds only the files
+// that must be written (including the refreshed manifest).
+type UpgradeResult struct {
+	Request  GenerateRequest
+	Tree     FileTree
+	Files    []UpgradeFile
+	Warnings []Warning
+}
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// addProjectManifest adds .stacksprint.json describing every other file in tree.
+func addProjectManifest(tree *FileTree, req GenerateRequest) error {
+	m := ProjectManifest{Version: projectManifestVersion, Request: req, Files: make(map[string]string, len(tree.Files))}
+	for p, content := range tree.Files {
+		if p == ProjectManifestPath {
+			continue
+		}
+		m.Files[p] = contentHash(content)
+	}
+	body, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	addFile(tree, ProjectManifestPath, string(body)+"\n")
+	return nil
+}
+
+// ReadProjectManifest loads .stacksprint.json from a generated project.
+func ReadProjectManifest(dir string) (ProjectManifest, error) {
+	var m ProjectManifest
+	raw, err := os.ReadFile(filepath.Join(dir, ProjectManifestPath))
+	if err != nil {
+		return m, fmt.Errorf("read project manifest: %w", err)
+	}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return m, fmt.Errorf("parse project manifest: %w", err)
+	}
+	if m.Version != projectManifestVersion {
+		return m, fmt.Errorf("unsupported project manifest version %d", m.Version)
+	}
+	return m, nil
+}
+
+// Upgrade regenerates the project in dir from next and three-way merges the
+// result with the files on disk. The merge base is the output of the request
+// recorded in the project manifest; files whose hash still matches the
+// manifest were never edited and are simply replaced. Conflicts keep both
+// versions between markers and are reported as MERGE_CONFLICT warnings.
+func (e *Engine) Upgrade(ctx context.Context, dir string, next GenerateRequest) (UpgradeResult, error) {
+	prev, err := ReadProjectManifest(dir)
+	if err != nil {
+		return UpgradeResult{}, err
+	}
+	project, err := e.GenerateProject(ctx, next)
+	if err != nil {
+		return UpgradeResult{}, err
+	}
+
+	// Materialise .gitkeep placeholders so empty directories are planned like
+	// any other file and the write tree can stay limited to changed files.
+	ensureGitKeepFiles(&project.Tree)
+	result := UpgradeResult{
+		Request:  project.Request,
+		Tree:     FileTree{Files: map[string]string{}, Dirs: map[string]struct{}{".": {}}},
+		Warnings: project.Response.Warnings,
+	}
+
+	// The previous output is only a trustworthy merge base when it reproduces
+	// the recorded hashes; otherwise the generator has changed since and the
+	// file is merged two-way against an empty base.
+	var base FileTree
+	if previous, err := e.GenerateProject(ctx, prev.Request); err == nil {
+		base = previous.Tree
+	} else {
+		result.Warnings = append(result.Warnings, Warning{
+			Code:     "UPGRADE_BASE_UNAVAILABLE",
+			Severity: "warn",
+			Message:  "Could not regenerate the previous project; edited files are merged without a common base.",
+			Reason:   err.Error(),
+		})
+	}
+	baseContent := func(p string) string {
+		content, ok := base.Files[p]
+		if !ok || contentHash(content) != prev.Files[p] {
+			return ""
+		}
+		return content
+	}
+
+	plan := func(p, action string) {
+		result.Files = append(result.Files, UpgradeFile{Path: p, Action: action})
+	}
+	for _, p := range fileNamesSorted(project.Tree.Files) {
+		generated := project.Tree.Files[p]
+		if p == ProjectManifestPath {
+			result.Tree.Files[p] = generated
+			continue
+		}
+		current, exists, err := readProjectFile(dir, p)
+		if err != nil {
+			return UpgradeResult{}, err
+		}
+		_, tracked := prev.Files[p]
+		switch {
+		case !exists && tracked:
+			// Deleted by hand after generation; respect that.
+			plan(p, "kept")
+		case !exists:
+			result.Tree.Files[p] = generated
+			plan(p, "create")
+		case current == generated:
+			plan(p, "unchanged")
+		case tracked && contentHash(current) == prev.Files[p]:
+			result.Tree.Files[p] = generated
+			plan(p, "update")
+		default:
+			merged, conflict := merge3(baseContent(p), current, generated)
+			if merged == current {
+				plan(p, "kept")
+				continue
+			}
+			result.Tree.Files[p] = merged
+			if conflict {
+				plan(p, "conflict")
+				result.Warnings = append(result.Warnings, Warning{
+					Code:     "MERGE_CONFLICT",
+					Severity: "warn",
+					Message:  "Local edits overlap regenerated content; resolve the conflict markers.",
+					Reason:   "three-way merge against the previously generated version",
+					Path:     p,
+				})
+			} else {
+				plan(p, "merge")
+			}
+		}
+	}
+
+	var obsolete []string
+	for p := range prev.Files {
+		if _, still := project.Tree.Files[p]; !still {
+			obsolete = append(obsolete, p)
+		}
+	}
+	sort.Strings(obsolete)
+	for _, p := range obsolete {
+		if _, exists, _ := readProjectFile(dir, p); !exists {
+			continue
+		}
+		plan(p, "obsolete")
+		result.Warnings = append(result.Warnings, Warning{
+			Code:     "UPGRADE_FILE_OBSOLETE",
+			Severity: "info",
+			Message:  "File is no longer generated for this configuration and was left in place.",
+			Reason:   "not produced by the new request",
+			Path:     p,
+		})
+	}
+	return result, nil
+}
+
+func readProjectFile(dir, p string) (string, bool, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(p)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return string(raw), true, nil
+}
@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMerge3(t *testing.T) {
+	base := "a\nb\nc\n"
+	tests := []struct {
+		name, ours, theirs, want string
+		conflict                 bool
+	}{
+		{name: "only ours changed", ours: "a\nB\nc\n", theirs: base, want: "a\nB\nc\n"},
+		{name: "only theirs changed", ours: base, theirs: "a\nb\nc\nd\n", want: "a\nb\nc\nd\n"},
+		{name: "disjoint edits", ours: "A\nb\nc\n", theirs: "a\nb\nc\nd\n", want: "A\nb\nc\nd\n"},
+		{name: "same edit", ours: "a\nX\nc\n", theirs: "a\nX\nc\n", want: "a\nX\nc\n"},
+		{
+			name:     "overlapping edits",
+			ours:     "a\nmine\nc\n",
+			theirs:   "a\ntheirs\nc\n",
+			want:     "a\n" + conflictOurs + "\nmine\n" + conflictBase + "\nb\n" + conflictSep + "\ntheirs\n" + conflictTheirs + "\nc\n",
+			conflict: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflict := merge3(base, tt.ours, tt.theirs)
+			if got != tt.want || conflict != tt.conflict {
+				t.Errorf("merge3() = %q, %v; want %q, %v", got, conflict, tt.want, tt.conflict)
+			}
+		})
+	}
+}
+
+func TestEngine_Upgrade(t *testing.T) {
+	registry, err := NewTemplateRegistry("../../../templates")
+	if err != nil {
+		t.Fatalf("failed to init registry: %v", err)
+	}
+	engine := NewEngine(registry)
+	ctx := context.Background()
+	req := GenerateRequest{
+		Language:     "go",
+		Framework:    "gin",
+		Architecture: "clean",
+		Database:     "postgresql",
+		UseORM:       true,
+		Root:         RootOptions{Mode: "new", Name: "demo-api"},
+	}
+
+	project, err := engine.GenerateProject(ctx, req)
+	if err != nil {
+		t.Fatalf("GenerateProject() failed: %v", err)
+	}
+	if _, ok := project.Tree.Files[ProjectManifestPath]; !ok {
+		t.Fatalf("expected %s in generated tree", ProjectManifestPath)
+	}
+	dir := t.TempDir()
+	if _, err := WriteTree(dir, project.Tree, WriteOptions{}); err != nil {
+		t.Fatalf("WriteTree() failed: %v", err)
+	}
+
+	// Upgrading to the same request is a no-op apart from the manifest.
+	same, err := engine.Upgrade(ctx, dir, req)
+	if err != nil {
+		t.Fatalf("Upgrade() failed: %v", err)
+	}
+	for _, f := range same.Files {
+		if f.Action != "unchanged" {
+			t.Errorf("same request: %s planned as %s", f.Path, f.Action)
+		}
+	}
+
+	// Hand edits: one far from what Redis changes, one right where it lands.
+	edit := func(p string, fn func(string) string) {
+		target := filepath.Join(dir, filepath.FromSlash(p))
+		raw, err := os.ReadFile(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(target, []byte(fn(string(raw))), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	edit("docker-compose.yaml", func(s string) string { return "# local note\n" + s })
+	edit(".env", func(s string) string { return strings.TrimSuffix(s, "\n") + "&connect_timeout=5\n" })
+
+	next := req
+	next.Infra.Redis = true
+	result, err := engine.Upgrade(ctx, dir, next)
+	if err != nil {
+		t.Fatalf("Upgrade() failed: %v", err)
+	}
+	actions := map[string]string{}
+	for _, f := range result.Files {
+		actions[f.Path] = f.Action
+	}
+	want := map[string]string{
+		"docker-compose.yaml":     "merge",
+		".env":                    "conflict",
+		"internal/cache/redis.go": "create",
+	}
+	for p, action := range want {
+		if actions[p] != action {
+			t.Errorf("%s planned as %q, want %q", p, actions[p], action)
+		}
+	}
+	if !hasWarning(result.Warnings, "MERGE_CONFLICT") {
+		t.Errorf("expected MERGE_CONFLICT warning, got %+v", result.Warnings)
+	}
+	merged := result.Tree.Files["docker-compose.yaml"]
+	if !strings.HasPrefix(merged, "# local note\n") || !strings.Contains(merged, "redis") {
+		t.Errorf("merge lost a side:\n%s", merged)
+	}
+	if !strings.Contains(result.Tree.Files[".env"], conflictOurs) {
+		t.Errorf("expected conflict markers in .env:\n%s", result.Tree.Files[".env"])
+	}
+	if _, ok := result.Tree.Files[ProjectManifestPath]; !ok {
+		t.Error("upgrade must refresh the project manifest")
+	}
+}
+
+func hasWarning(warnings []Warning, code string) bool {
+	for _, w := range warnings {
+		if w.Code == code {
+			return true
+		}
+	}
+	return false
+}
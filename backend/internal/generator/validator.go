@@ -5,100 +5,167 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 )
 
+// Languages, frameworks and architectures are validated against the generator
+// registry (see generators.go); only cross-language tables live here.
 var (
-	allowedLanguages     = map[string]struct{}{"go": {}, "node": {}, "python": {}}
-	allowedArchitectures = map[string]struct{}{
-		"mvp": {}, "clean": {}, "hexagonal": {}, "modular-monolith": {}, "microservices": {},
-	}
-	allowedDBs          = map[string]struct{}{"postgresql": {}, "mysql": {}, "mongodb": {}, "none": {}}
-	frameworkByLanguage = map[string]map[string]struct{}{
-		"go":     {"gin": {}, "fiber": {}},
-		"node":   {"express": {}, "fastify": {}},
-		"python": {"fastapi": {}, "django": {}},
-	}
+	allowedDBs       = map[string]struct{}{"postgresql": {}, "mysql": {}, "mongodb": {}, "sqlite": {}, "none": {}}
 	serviceNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
 )
 
+const (
+	minMicroservices = 2
+	maxMicroservices = 5
+)
+
+// ValidationError is a single structured violation. Pointer is a JSON pointer
+// into the request body (e.g. /services/2/name) so clients can highlight the field.
+type ValidationError struct {
+	Pointer string   `json:"pointer"`
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+// ValidationErrors is every violation found in a request. Its Error() reports
+// the first one, which keeps single-message callers working unchanged.
+type ValidationErrors []ValidationError
+
+func (v ValidationErrors) Error() string {
+	if len(v) == 0 {
+		return "invalid request"
+	}
+	if len(v) == 1 {
+		return v[0].Message
+	}
+	return fmt.Sprintf("%s (and %d more)", v[0].Message, len(v)-1)
+}
+
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) add(pointer, code, message string, allowed ...string) {
+	v.errs = append(v.errs, ValidationError{Pointer: pointer, Code: code, Message: message, Allowed: allowed})
+}
+
+// Validate returns a ValidationErrors describing every violation, or nil.
 func Validate(req GenerateRequest) error {
-	lang := strings.ToLower(strings.TrimSpace(req.Language))
-	if _, ok := allowedLanguages[lang]; !ok {
-		return errors.New("language must be one of: go, node, python")
+	if errs := ValidateAll(req); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidateAll collects every violation instead of stopping at the first.
+func ValidateAll(req GenerateRequest) ValidationErrors {
+	v := &validator{}
+
+	languages := RegisteredLanguages()
+	spec, langOK := LookupLanguage(req.Language)
+	if !langOK {
+		v.add("/language", "LANGUAGE_UNSUPPORTED", "language must be one of: "+strings.Join(languages, ", "), languages...)
 	}
 
 	fw := strings.ToLower(strings.TrimSpace(req.Framework))
+	frameworks := append([]string(nil), spec.Frameworks...)
+	sort.Strings(frameworks)
 	if fw == "" {
-		return fmt.Errorf("framework is required for %s. please specify a valid framework (e.g. express, fastify). defaults are explicitly not supported.", req.Language)
-	}
-	if _, ok := frameworkByLanguage[lang][fw]; !ok {
-		return fmt.Errorf("framework %q is not valid for %s", req.Framework, req.Language)
+		v.add("/framework", "FRAMEWORK_REQUIRED", fmt.Sprintf("framework is required for %s. please specify a valid framework (e.g. express, fastify). defaults are explicitly not supported.", req.Language), frameworks...)
+	} else if _, ok := setOf(spec.Frameworks)[fw]; !ok {
+		v.add("/framework", "FRAMEWORK_UNSUPPORTED", fmt.Sprintf("framework %q is not valid for %s", req.Framework, req.Language), frameworks...)
 	}
 
 	arch := strings.ToLower(strings.TrimSpace(req.Architecture))
-	if _, ok := allowedArchitectures[arch]; !ok {
-		return errors.New("architecture must be one of: mvp, clean, hexagonal, modular-monolith, microservices")
+	if _, ok := registeredArchitectures()[arch]; !ok {
+		archs := sortedSet(registeredArchitectures())
+		v.add("/architecture", "ARCHITECTURE_UNSUPPORTED", "architecture must be one of: "+strings.Join(archs, ", "), archs...)
+	} else if _, ok := setOf(spec.Architectures)[arch]; langOK && !ok {
+		v.add("/architecture", "ARCHITECTURE_UNSUPPORTED", fmt.Sprintf("architecture %q is not supported for %s", req.Architecture, req.Language), spec.Architectures...)
 	}
 
 	db := strings.ToLower(strings.TrimSpace(req.Database))
 	if _, ok := allowedDBs[db]; !ok {
-		return errors.New("db must be one of: postgresql, mysql, mongodb, none")
+		v.add("/db", "DB_UNSUPPORTED", "db must be one of: postgresql, mysql, mongodb, sqlite, none", sortedSet(allowedDBs)...)
 	}
 
 	if arch == "microservices" {
-		if len(req.Services) < 2 || len(req.Services) > 5 {
-			return errors.New("microservices mode requires 2 to 5 services")
+		if len(req.Services) < minMicroservices || len(req.Services) > maxMicroservices {
+			v.add("/services", "SERVICE_COUNT_OUT_OF_RANGE", fmt.Sprintf("microservices mode requires %d to %d services", minMicroservices, maxMicroservices))
 		}
 		seen := map[string]struct{}{}
 		for i, svc := range req.Services {
 			name := strings.TrimSpace(svc.Name)
 			if !serviceNameRegex.MatchString(name) {
-				return fmt.Errorf("services[%d].name is invalid", i)
-			}
-			if _, ok := seen[strings.ToLower(name)]; ok {
-				return fmt.Errorf("duplicate service name %q", name)
+				v.add(fmt.Sprintf("/services/%d/name", i), "SERVICE_NAME_INVALID", fmt.Sprintf("services[%d].name is invalid", i))
+			} else if _, ok := seen[strings.ToLower(name)]; ok {
+				v.add(fmt.Sprintf("/services/%d/name", i), "SERVICE_NAME_DUPLICATE", fmt.Sprintf("duplicate service name %q", name))
 			}
 			seen[strings.ToLower(name)] = struct{}{}
 			if svc.Port <= 0 {
-				return fmt.Errorf("services[%d].port must be a positive number", i)
+				v.add(fmt.Sprintf("/services/%d/port", i), "SERVICE_PORT_INVALID", fmt.Sprintf("services[%d].port must be a positive number", i))
 			}
 		}
 	}
 
 	rootMode := strings.ToLower(strings.TrimSpace(req.Root.Mode))
 	if rootMode != "new" && rootMode != "existing" {
-		return errors.New("root.mode must be either 'new' or 'existing'")
+		v.add("/root/mode", "ROOT_MODE_INVALID", "root.mode must be either 'new' or 'existing'", "existing", "new")
 	}
 	if rootMode == "new" && strings.TrimSpace(req.Root.Name) == "" {
-		return errors.New("root.name is required when root.mode is 'new'")
+		v.add("/root/name", "ROOT_NAME_REQUIRED", "root.name is required when root.mode is 'new'")
 	}
 	if rootMode == "existing" && strings.TrimSpace(req.Root.Path) == "" {
-		return errors.New("root.path is required when root.mode is 'existing'")
+		v.add("/root/path", "ROOT_PATH_REQUIRED", "root.path is required when root.mode is 'existing'")
+	}
+	if policy := strings.ToLower(strings.TrimSpace(req.Root.OnConflict)); policy != "" && !isConflictPolicy(policy) {
+		v.add("/root/on_conflict", "CONFLICT_POLICY_INVALID", "root.on_conflict must be one of: "+strings.Join(conflictPolicies, ", "), conflictPolicies...)
+	}
+	for _, p := range sortedStringKeys(req.Root.FilePolicies) {
+		pointer := "/root/file_policies/" + jsonPointerEscape(p)
+		if err := validateRelPath(p); err != nil {
+			v.add(pointer, "PATH_INVALID", fmt.Sprintf("invalid file policy path %q: %v", p, err))
+		}
+		if !isConflictPolicy(strings.ToLower(strings.TrimSpace(req.Root.FilePolicies[p]))) {
+			v.add(pointer, "CONFLICT_POLICY_INVALID", fmt.Sprintf("root.file_policies[%q] must be one of: %s", p, strings.Join(conflictPolicies, ", ")), conflictPolicies...)
+		}
+	}
+
+	validateModels(v, req.Custom.Models)
+	validateRBAC(v, req)
+	if doc := strings.TrimSpace(req.Custom.OpenAPI); doc != "" {
+		// A document that parses was already folded into custom.models by
+		// ApplyRuleEngine; only a broken one is still here.
+		if _, err := parseOpenAPI(doc); err != nil {
+			v.add("/custom/openapi", "OPENAPI_INVALID", err.Error())
+		}
 	}
 
-	for _, p := range req.Custom.AddFolders {
+	for i, p := range req.Custom.AddFolders {
 		if err := validateRelPath(p); err != nil {
-			return fmt.Errorf("invalid custom folder %q: %w", p, err)
+			v.add(fmt.Sprintf("/custom/add_folders/%d", i), "PATH_INVALID", fmt.Sprintf("invalid custom folder %q: %v", p, err))
 		}
 	}
-	for _, f := range req.Custom.AddFiles {
+	for i, f := range req.Custom.AddFiles {
 		if err := validateRelPath(f.Path); err != nil {
-			return fmt.Errorf("invalid custom file path %q: %w", f.Path, err)
+			v.add(fmt.Sprintf("/custom/add_files/%d/path", i), "PATH_INVALID", fmt.Sprintf("invalid custom file path %q: %v", f.Path, err))
 		}
 	}
-	for _, p := range req.Custom.RemoveFolders {
+	for i, p := range req.Custom.RemoveFolders {
 		if err := validateRelPath(p); err != nil {
-			return fmt.Errorf("invalid remove folder %q: %w", p, err)
+			v.add(fmt.Sprintf("/custom/remove_folders/%d", i), "PATH_INVALID", fmt.Sprintf("invalid remove folder %q: %v", p, err))
 		}
 	}
-	for _, p := range req.Custom.RemoveFiles {
+	for i, p := range req.Custom.RemoveFiles {
 		if err := validateRelPath(p); err != nil {
-			return fmt.Errorf("invalid remove file %q: %w", p, err)
+			v.add(fmt.Sprintf("/custom/remove_files/%d", i), "PATH_INVALID", fmt.Sprintf("invalid remove file %q: %v", p, err))
 		}
 	}
 
-	return nil
+	return v.errs
 }
 
 func validateRelPath(p string) error {
@@ -120,6 +187,29 @@ func archTemplateName(arch string) string {
 	return a
 }
 
+func sortedSet(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// jsonPointerEscape escapes a map key for use as a JSON pointer token (RFC 6901).
+func jsonPointerEscape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
 func isEnabled(flag *bool) bool {
 	if flag == nil {
 		return true
@@ -0,0 +1,46 @@
+package generator
+
+import "testing"
+
+func TestValidateAllCollectsEveryViolation(t *testing.T) {
+	req := GenerateRequest{
+		Language:     "rust",
+		Framework:    "gin",
+		Architecture: "microservices",
+		Database:     "oracle",
+		Services:     []ServiceConfig{{Name: "users", Port: 8081}, {Name: "users", Port: 8082}, {Name: "9bad", Port: 0}},
+		Root:         RootOptions{Mode: "new", Name: "demo", OnConflict: "replace", FilePolicies: map[string]string{"go.mod": "keep"}},
+		Custom:       CustomOptions{AddFiles: []CustomFile{{Path: "../etc/passwd"}}},
+	}
+
+	errs := ValidateAll(req)
+	got := map[string]string{}
+	for _, e := range errs {
+		got[e.Pointer] = e.Code
+	}
+	expected := map[string]string{
+		"/language":                  "LANGUAGE_UNSUPPORTED",
+		"/framework":                 "FRAMEWORK_UNSUPPORTED",
+		"/db":                        "DB_UNSUPPORTED",
+		"/services/1/name":           "SERVICE_NAME_DUPLICATE",
+		"/services/2/name":           "SERVICE_NAME_INVALID",
+		"/services/2/port":           "SERVICE_PORT_INVALID",
+		"/custom/add_files/0/path":   "PATH_INVALID",
+		"/root/on_conflict":          "CONFLICT_POLICY_INVALID",
+		"/root/file_policies/go.mod": "CONFLICT_POLICY_INVALID",
+	}
+	for pointer, code := range expected {
+		if got[pointer] != code {
+			t.Errorf("pointer %s: got code %q, want %q", pointer, got[pointer], code)
+		}
+	}
+	for _, e := range errs {
+		if e.Pointer == "/language" && len(e.Allowed) != len(RegisteredLanguages()) {
+			t.Errorf("expected allowed languages on /language, got %v", e.Allowed)
+		}
+	}
+
+	if err := Validate(req); err == nil || err.Error() != errs[0].Message+" (and 8 more)" {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
@@ -1,8 +1,30 @@
 'use client';
 
-import { useMemo, useState } from 'react';
+import { useEffect, useMemo, useState } from 'react';
 import { useConfig, Service } from '@/src/context/ConfigContext';
 
+interface LanguageCapability {
+    id: string;
+    frameworks: string[];
+    architectures: string[];
+}
+
+// Used until GET /capabilities answers (or if the backend is unreachable).
+const FALLBACK_LANGUAGES: LanguageCapability[] = [
+    { id: 'go', frameworks: ['fiber', 'gin'], architectures: ['mvp', 'clean', 'hexagonal', 'modular-monolith', 'microservices'] },
+    { id: 'node', frameworks: ['express', 'fastify'], architectures: ['mvp', 'clean', 'hexagonal', 'modular-monolith', 'microservices'] },
+    { id: 'python', frameworks: ['fastapi', 'django'], architectures: ['mvp', 'clean', 'hexagonal', 'modular-monolith', 'microservices'] },
+];
+
+const LANGUAGE_LABELS: Record<string, string> = { go: 'Go', node: 'Node', python: 'Python' };
+const ARCHITECTURE_LABELS: Record<string, string> = {
+    mvp: 'MVP',
+    clean: 'Clean Architecture',
+    hexagonal: 'Hexagonal',
+    'modular-monolith': 'Modular Monolith',
+    microservices: 'Microservices (2-5)',
+};
+
 export function LanguageArchitectureForm() {
     const {
         language, setLanguage,
@@ -16,11 +38,25 @@ export function LanguageArchitectureForm() {
     const [advancedMode, setAdvancedMode] = useState(false);
     const isMvp = architecture === 'mvp';
 
-    const frameworkChoices = useMemo(() => {
-        if (language === 'go') return ['gin', 'fiber'];
-        if (language === 'node') return ['express', 'fastify'];
-        return ['fastapi', 'django'];
-    }, [language]);
+    const [languages, setLanguages] = useState<LanguageCapability[]>(FALLBACK_LANGUAGES);
+
+    useEffect(() => {
+        const api = process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8080';
+        fetch(`${api}/capabilities`)
+            .then((res) => (res.ok ? res.json() : null))
+            .then((body) => {
+                if (body && Array.isArray(body.languages) && body.languages.length > 0) {
+                    setLanguages(body.languages);
+                }
+            })
+            .catch(() => { /* keep fallback */ });
+    }, []);
+
+    const current = useMemo(
+        () => languages.find((l) => l.id === language) ?? languages[0],
+        [languages, language]
+    );
+    const frameworkChoices = current.frameworks;
 
     const archHint = useMemo(() => {
         if (services.length > 1 && architecture !== 'microservices') {
@@ -59,12 +95,11 @@ export function LanguageArchitectureForm() {
                         onChange={(e) => {
                             const next = e.target.value;
                             setLanguage(next);
-                            setFramework(next === 'go' ? 'fiber' : next === 'node' ? 'express' : 'fastapi');
+                            const nextFrameworks = languages.find((l) => l.id === next)?.frameworks ?? [];
+                            setFramework(nextFrameworks[0] ?? '');
                         }}
                     >
-                        <option value="go">Go</option>
-                        <option value="node">Node</option>
-                        <option value="python">Python</option>
+                        {languages.map((l) => <option key={l.id} value={l.id}>{LANGUAGE_LABELS[l.id] ?? l.id}</option>)}
                     </select>
                 </div>
                 <div className="field">
@@ -77,11 +112,7 @@ export function LanguageArchitectureForm() {
             <div className="field">
                 <label>Architecture</label>
                 <select value={architecture} onChange={(e) => handleArchChange(e.target.value)}>
-                    <option value="mvp">MVP</option>
-                    <option value="clean">Clean Architecture</option>
-                    <option value="hexagonal">Hexagonal</option>
-                    <option value="modular-monolith">Modular Monolith</option>
-                    <option value="microservices">Microservices (2-5)</option>
+                    {current.architectures.map((a) => <option key={a} value={a}>{ARCHITECTURE_LABELS[a] ?? a}</option>)}
                 </select>
                 {archHint && <div className="hint" style={{ marginTop: '6px', color: '#fbbf24' }}>{archHint}</div>}
             </div>
@@ -9,6 +9,7 @@ export function RootInitForm() {
         rootMode, setRootMode,
         rootName, setRootName,
         rootPath, setRootPath,
+        onConflict, setOnConflict,
         moduleName, setModuleName,
         gitInit, setGitInit
     } = useConfig();
@@ -40,6 +41,17 @@ export function RootInitForm() {
                     <input value={rootPath} onChange={(e) => setRootPath(e.target.value)} placeholder="existing path" />
                 )}
             </div>
+            {rootMode === 'existing' && (
+                <div className="field">
+                    <label>When a file already exists</label>
+                    <select value={onConflict} onChange={(e) => setOnConflict(e.target.value)}>
+                        <option value="merge">Merge .gitignore, go.mod, package.json; skip the rest</option>
+                        <option value="skip">Skip it</option>
+                        <option value="backup">Back up to .orig, then overwrite</option>
+                        <option value="overwrite">Overwrite it</option>
+                    </select>
+                </div>
+            )}
             <label className="toggle git-toggle">
                 <input type="checkbox" checked={gitInit} onChange={(e) => setGitInit(e.target.checked)} />
                 <span>Initialize Git repository</span>
@@ -1,9 +1,39 @@
 'use client';
 
-import { useConfig, SchemaField, SchemaModel } from '@/src/context/ConfigContext';
+import { useState } from 'react';
+import { useConfig, SchemaField, SchemaModel, SchemaRelation } from '@/src/context/ConfigContext';
+
+const relationKinds = ['belongs_to', 'has_many', 'many_to_many'];
 
 export function SchemaBuilder() {
     const { schemaModels, setSchemaModels } = useConfig();
+    const [sql, setSql] = useState('');
+    const [openapi, setOpenapi] = useState('');
+    const [importNotes, setImportNotes] = useState<string[]>([]);
+
+    // Replaces the models with the ones parsed by POST /models/from-sql or
+    // POST /models/from-openapi.
+    async function importModels(endpoint: string, body: Record<string, string>) {
+        const api = process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8080';
+        try {
+            const res = await fetch(`${api}${endpoint}`, {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify(body)
+            });
+            const result = await res.json();
+            if (!res.ok) {
+                setImportNotes([result.error || 'Import failed']);
+                return;
+            }
+            if (Array.isArray(result.models) && result.models.length > 0) {
+                setSchemaModels(result.models);
+            }
+            setImportNotes((result.warnings || []).map((w: { message: string }) => w.message));
+        } catch {
+            setImportNotes(['Backend unreachable']);
+        }
+    }
 
     function addModel() {
         setSchemaModels((prev: SchemaModel[]) => [...prev, { name: '', fields: [{ name: 'name', type: 'string' }] }]);
@@ -51,6 +81,42 @@ export function SchemaBuilder() {
         );
     }
 
+    function addRelation(modelIndex: number) {
+        setSchemaModels((prev: SchemaModel[]) =>
+            prev.map((model: SchemaModel, i: number) => (
+                i === modelIndex ? { ...model, relations: [...(model.relations || []), { kind: 'belongs_to', model: '' }] } : model
+            ))
+        );
+    }
+
+    function removeRelation(modelIndex: number, relationIndex: number) {
+        setSchemaModels((prev: SchemaModel[]) =>
+            prev.map((model: SchemaModel, i: number) => (
+                i === modelIndex
+                    ? { ...model, relations: (model.relations || []).filter((_: SchemaRelation, idx: number) => idx !== relationIndex) }
+                    : model
+            ))
+        );
+    }
+
+    function updateRelation(modelIndex: number, relationIndex: number, patch: Partial<SchemaRelation>) {
+        setSchemaModels((prev: SchemaModel[]) =>
+            prev.map((model: SchemaModel, i: number) => (
+                i === modelIndex
+                    ? {
+                        ...model,
+                        relations: (model.relations || []).map((relation: SchemaRelation, idx: number) => (idx === relationIndex ? { ...relation, ...patch } : relation))
+                    }
+                    : model
+            ))
+        );
+    }
+
+    function parseEnum(value: string): string[] | undefined {
+        const values = value.split(',').map((v) => v.trim()).filter(Boolean);
+        return values.length > 0 ? values : undefined;
+    }
+
     return (
         <div className="schema-builder">
             <label>Schema Builder</label>
@@ -80,14 +146,74 @@ export function SchemaBuilder() {
                                 <option value="float">float</option>
                                 <option value="bool">bool</option>
                                 <option value="datetime">datetime</option>
+                                <option value="enum">enum</option>
                             </select>
+                            {field.type === 'enum' && (
+                                <input
+                                    value={(field.enum || []).join(', ')}
+                                    onChange={(e) => updateField(modelIndex, fieldIndex, { enum: parseEnum(e.target.value) })}
+                                    placeholder="values (e.g. draft, published)"
+                                />
+                            )}
+                            <label className="toggle">
+                                <input
+                                    type="checkbox"
+                                    checked={Boolean(field.required)}
+                                    onChange={(e) => updateField(modelIndex, fieldIndex, { required: e.target.checked })}
+                                />
+                                <span>required</span>
+                            </label>
+                            <label className="toggle">
+                                <input
+                                    type="checkbox"
+                                    checked={Boolean(field.unique)}
+                                    onChange={(e) => updateField(modelIndex, fieldIndex, { unique: e.target.checked })}
+                                />
+                                <span>unique</span>
+                            </label>
                             <button type="button" className="ghost" onClick={() => removeField(modelIndex, fieldIndex)}>Remove Field</button>
                         </div>
                     ))}
                     <button type="button" className="ghost" onClick={() => addField(modelIndex)}>Add Field</button>
+                    {(model.relations || []).map((relation: SchemaRelation, relationIndex: number) => (
+                        <div className="row schema-field" key={`relation-${modelIndex}-${relationIndex}`}>
+                            <select
+                                value={relation.kind}
+                                onChange={(e) => updateRelation(modelIndex, relationIndex, { kind: e.target.value })}
+                            >
+                                {relationKinds.map((kind) => <option key={kind} value={kind}>{kind}</option>)}
+                            </select>
+                            <select
+                                value={relation.model}
+                                onChange={(e) => updateRelation(modelIndex, relationIndex, { model: e.target.value })}
+                            >
+                                <option value="">target model</option>
+                                {schemaModels
+                                    .filter((other: SchemaModel, i: number) => i !== modelIndex && other.name.trim() !== '')
+                                    .map((other: SchemaModel) => <option key={other.name} value={other.name}>{other.name}</option>)}
+                            </select>
+                            <button type="button" className="ghost" onClick={() => removeRelation(modelIndex, relationIndex)}>Remove Relation</button>
+                        </div>
+                    ))}
+                    <button type="button" className="ghost" onClick={() => addRelation(modelIndex)}>Add Relation</button>
                 </div>
             ))}
             <button type="button" className="ghost" onClick={addModel}>Add Model</button>
+            <textarea
+                value={sql}
+                onChange={(e) => setSql(e.target.value)}
+                placeholder="Paste CREATE TABLE statements (PostgreSQL or MySQL)"
+                rows={4}
+            />
+            <button type="button" className="ghost" onClick={() => importModels('/models/from-sql', { sql })} disabled={sql.trim() === ''}>Import from SQL</button>
+            <textarea
+                value={openapi}
+                onChange={(e) => setOpenapi(e.target.value)}
+                placeholder="Paste an OpenAPI 3 document (YAML or JSON)"
+                rows={4}
+            />
+            <button type="button" className="ghost" onClick={() => importModels('/models/from-openapi', { document: openapi })} disabled={openapi.trim() === ''}>Import from OpenAPI</button>
+            {importNotes.map((note, i) => <p className="hint" key={`import-note-${i}`}>{note}</p>)}
         </div>
     );
 }
@@ -59,7 +59,12 @@ export function SidebarPreview() {
             const body = await res.json();
             if (!res.ok) {
                 setError(body.error || 'Generation failed');
-                if (mode === 'manual') addToast(body.error || 'Generation failed', 'error');
+                if (mode === 'manual') {
+                    const messages: string[] = Array.isArray(body.errors) && body.errors.length > 0
+                        ? body.errors.map((e: { pointer: string; message: string }) => (e.pointer ? `${e.pointer}: ${e.message}` : e.message))
+                        : [body.error || 'Generation failed'];
+                    messages.forEach((m) => addToast(m, 'error'));
+                }
                 return;
             }
             setBashScript(body.bash_script || '');
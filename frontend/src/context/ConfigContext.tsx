@@ -5,8 +5,19 @@ import { createContext, useContext, useState, useMemo, ReactNode } from 'react';
 export type Service = { name: string; port: number };
 export type ToggleItem = { key: string; label: string };
 export type CustomFileEntry = { path: string; content: string };
-export type SchemaField = { name: string; type: string };
-export type SchemaModel = { name: string; fields: SchemaField[] };
+export type SchemaField = {
+    name: string;
+    type: string;
+    required?: boolean;
+    unique?: boolean;
+    default?: string;
+    max_length?: number;
+    enum?: string[];
+};
+export type SchemaIndex = { fields: string[]; unique?: boolean };
+export type SchemaRelation = { kind: string; model: string; required?: boolean };
+export type SchemaRoute = { operation_id: string; method: string; path: string; action?: string };
+export type SchemaModel = { name: string; table?: string; fields: SchemaField[]; indexes?: SchemaIndex[]; relations?: SchemaRelation[]; routes?: SchemaRoute[] };
 export type SavedPreset = { name: string; config: Record<string, unknown> };
 
 export const PRESET_STORAGE_KEY = 'stacksprint_presets_v1';
@@ -14,18 +25,24 @@ export const PRESET_STORAGE_KEY = 'stacksprint_presets_v1';
 export const infraKeys: ToggleItem[] = [
     { key: 'redis', label: 'Redis' },
     { key: 'kafka', label: 'Kafka' },
-    { key: 'nats', label: 'NATS' }
+    { key: 'nats', label: 'NATS' },
+    { key: 'nats_jetstream', label: 'NATS JetStream' },
+    { key: 'rabbitmq', label: 'RabbitMQ' }
 ];
 
 export const featureKeys: ToggleItem[] = [
     { key: 'jwt_auth', label: 'JWT Auth' },
+    { key: 'rbac', label: 'Role-Based Access Control' },
     { key: 'swagger', label: 'Swagger / OpenAPI' },
     { key: 'github_actions_ci', label: 'GitHub Actions CI' },
     { key: 'makefile', label: 'Makefile' },
     { key: 'logger', label: 'Logger Setup' },
     { key: 'global_error_handler', label: 'Global Error Handler' },
     { key: 'health_endpoint', label: 'Health Endpoint' },
-    { key: 'sample_test', label: 'Sample Test File' }
+    { key: 'sample_test', label: 'Sample Test File' },
+    { key: 'observability', label: 'Observability Stack' },
+    { key: 'kubernetes', label: 'Kubernetes Manifests' },
+    { key: 'helm', label: 'Helm Chart' }
 ];
 
 export const fileToggleKeys: ToggleItem[] = [
@@ -55,6 +72,7 @@ type ConfigState = {
     rootMode: string;
     rootName: string;
     rootPath: string;
+    onConflict: string;
     moduleName: string;
     gitInit: boolean;
     customFolders: string;
@@ -78,6 +96,7 @@ type ConfigContextType = ConfigState & {
     setRootMode: (val: string) => void;
     setRootName: (val: string) => void;
     setRootPath: (val: string) => void;
+    setOnConflict: (val: string) => void;
     setModuleName: (val: string) => void;
     setGitInit: (val: boolean) => void;
     setCustomFolders: (val: string) => void;
@@ -102,16 +121,20 @@ export function ConfigProvider({ children }: { children: ReactNode }) {
         { name: 'users', port: 8081 },
         { name: 'orders', port: 8082 }
     ]);
-    const [infra, setInfra] = useState<Record<string, boolean>>({ redis: false, kafka: false, nats: false });
+    const [infra, setInfra] = useState<Record<string, boolean>>({ redis: false, kafka: false, nats: false, nats_jetstream: false, rabbitmq: false });
     const [features, setFeatures] = useState<Record<string, boolean>>({
         jwt_auth: false,
+        rbac: false,
         swagger: true,
         github_actions_ci: true,
         makefile: true,
         logger: true,
         global_error_handler: true,
         health_endpoint: true,
-        sample_test: true
+        sample_test: true,
+        observability: false,
+        kubernetes: false,
+        helm: false
     });
     const [fileToggles, setFileToggles] = useState<Record<string, boolean>>({
         env: true,
@@ -128,6 +151,7 @@ export function ConfigProvider({ children }: { children: ReactNode }) {
     const [rootMode, setRootMode] = useState('new');
     const [rootName, setRootName] = useState('my-stacksprint-app');
     const [rootPath, setRootPath] = useState('.');
+    const [onConflict, setOnConflict] = useState('merge');
     const [moduleName, setModuleName] = useState('github.com/example/my-stacksprint-app');
     const [gitInit, setGitInit] = useState(true);
     const [customFolders, setCustomFolders] = useState('');
@@ -157,7 +181,10 @@ export function ConfigProvider({ children }: { children: ReactNode }) {
                 .filter((model) => model.name.trim() !== '')
                 .map((model) => ({
                     name: model.name.trim(),
-                    fields: model.fields.filter((field) => field.name.trim() !== '')
+                    fields: model.fields.filter((field) => field.name.trim() !== ''),
+                    indexes: model.indexes,
+                    relations: (model.relations || []).filter((relation) => relation.model.trim() !== ''),
+                    routes: model.routes
                 })),
             add_files: customFileEntries
                 .filter((item) => item.path.trim() !== '')
@@ -169,13 +196,14 @@ export function ConfigProvider({ children }: { children: ReactNode }) {
             mode: rootMode,
             name: rootName,
             path: rootPath,
+            on_conflict: rootMode === 'existing' ? onConflict : undefined,
             git_init: gitInit,
             module: moduleName
         }
     }), [
         language, framework, architecture, services, db, useORM, serviceCommunication,
         infra, features, fileToggles, customFolders, schemaModels, customFileEntries,
-        removeFolders, removeFiles, rootMode, rootName, rootPath, gitInit, moduleName
+        removeFolders, removeFiles, rootMode, rootName, rootPath, onConflict, gitInit, moduleName
     ]);
 
     const applyPreset = (config: Record<string, any>) => {
@@ -189,7 +217,7 @@ export function ConfigProvider({ children }: { children: ReactNode }) {
         const cfgServices = config.services || [];
         setServices(cfgServices.length > 0 ? cfgServices : [{ name: 'users', port: 8081 }, { name: 'orders', port: 8082 }]);
 
-        setInfra(config.infra || { redis: false, kafka: false, nats: false });
+        setInfra(config.infra || { redis: false, kafka: false, nats: false, nats_jetstream: false, rabbitmq: false });
         setFeatures(config.features || features);
         setFileToggles(config.file_toggles || fileToggles);
 
@@ -206,6 +234,7 @@ export function ConfigProvider({ children }: { children: ReactNode }) {
         setRootMode(root.mode || 'new');
         setRootName(root.name || 'my-stacksprint-app');
         setRootPath(root.path || '.');
+        setOnConflict(root.on_conflict || 'merge');
         setModuleName(root.module || 'github.com/example/my-stacksprint-app');
     };
 
@@ -224,6 +253,7 @@ export function ConfigProvider({ children }: { children: ReactNode }) {
             rootMode, setRootMode,
             rootName, setRootName,
             rootPath, setRootPath,
+            onConflict, setOnConflict,
             moduleName, setModuleName,
             gitInit, setGitInit,
             customFolders, setCustomFolders,
@@ -0,0 +1,11 @@
+// Package templates embeds the StackSprint template tree so the generator
+// binary does not depend on a template directory at runtime.
+package templates
+
+import "embed"
+
+// FS holds every language template, rooted at this directory
+// (e.g. "go/clean/cmd/server/main.tmpl").
+//
+//go:embed go node python
+var FS embed.FS
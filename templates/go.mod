@@ -0,0 +1,3 @@
+module stacksprint/templates
+
+go 1.24.0